@@ -0,0 +1,101 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Address is a 20-byte account address (Ethereum style).
+//
+// Inside a 32-byte word address is stored right-aligned, upper 12 bytes MUST be zero -
+// use ToWord / AddressFromWord instead of slicing words by hand.
+type Address [20]byte
+
+// AddressFromBytes converts slice of exactly 20 bytes to Address.
+func AddressFromBytes(b []byte) (Address, error) {
+	if len(b) != 20 {
+		return Address{}, fmt.Errorf("expected exactly 20 bytes for address, but got %d bytes", len(b))
+	}
+	return Address(b), nil
+}
+
+// AddressFromHex parses 40 hex digits with "0x" prefix.
+// All-lowercase and all-uppercase forms are accepted as is,
+// mixed-case form must carry valid EIP-55 checksum.
+func AddressFromHex(s string) (Address, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return Address{}, fmt.Errorf("address %q must start with 0x", s)
+	}
+
+	digits := s[2:]
+	if len(digits) != 40 {
+		return Address{}, fmt.Errorf("expected 40 hex digits in address, but got %d in %q", len(digits), s)
+	}
+
+	var a Address
+	if _, err := hex.Decode(a[:], []byte(digits)); err != nil {
+		return Address{}, fmt.Errorf("invalid hex in address %q: %w", s, err)
+	}
+
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) {
+		if expected := a.Hex(); expected[2:] != digits {
+			return Address{}, fmt.Errorf("invalid checksum in address %q, expected %s", s, expected)
+		}
+	}
+
+	return a, nil
+}
+
+// IsValidAddressHex reports whether s is accepted by AddressFromHex.
+func IsValidAddressHex(s string) bool {
+	_, err := AddressFromHex(s)
+	return err == nil
+}
+
+// AddressFromWord extracts address from right-aligned 32-byte word,
+// returns error if any of the upper 12 bytes is not zero (i.e. the word is not an address).
+func AddressFromWord(w Bytes32) (Address, error) {
+	for i := 0; i < 12; i++ {
+		if w[i] != 0 {
+			return Address{}, fmt.Errorf("word %s is not a valid address: upper 12 bytes must be zero", w.Hex())
+		}
+	}
+	return Address(w[12:]), nil
+}
+
+// ToWord returns address left-padded with zeros to 32-byte word.
+func (a Address) ToWord() Bytes32 {
+	var w Bytes32
+	copy(w[12:], a[:])
+	return w
+}
+
+// Hex returns "0x"-prefixed address with EIP-55 mixed-case checksum.
+func (a Address) Hex() string {
+	lower := hex.EncodeToString(a[:])
+	hash := keccak256([]byte(lower))
+
+	out := []byte("0x" + lower)
+	for i := 0; i < len(lower); i++ {
+		// nibble i of the hash decides case of hex digit i
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+
+		if lower[i] >= 'a' && nibble >= 8 {
+			out[2+i] = lower[i] - 'a' + 'A'
+		}
+	}
+
+	return string(out)
+}
+
+func (a Address) String() string {
+	return a.Hex()
+}
+
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
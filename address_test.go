@@ -0,0 +1,97 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestKeccak256(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+	}
+
+	for _, c := range cases {
+		got := keccak256([]byte(c.input))
+		if hex.EncodeToString(got[:]) != c.expected {
+			t.Errorf("keccak256(%q): expected %s got %x", c.input, c.expected, got)
+		}
+	}
+}
+
+func TestAddressChecksum(t *testing.T) {
+	// test vectors from EIP-55
+	vectors := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+
+	for _, v := range vectors {
+		a, err := AddressFromHex(strings.ToLower(v))
+		if err != nil {
+			t.Fatalf("AddressFromHex(%q) returned error: %v", v, err)
+		}
+		if a.Hex() != v {
+			t.Errorf("expected %s got %s", v, a.Hex())
+		}
+		if !IsValidAddressHex(v) {
+			t.Errorf("checksummed address %s must be valid", v)
+		}
+	}
+}
+
+func TestAddressFromHexInvalid(t *testing.T) {
+	cases := []string{
+		"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",     // no prefix
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea",     // too short
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD",   // bad checksum
+		"0xzzaeb6053f3e94c9b9a09f33669435e7ef1beaed",   // not hex
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed00", // too long
+	}
+
+	for _, c := range cases {
+		if IsValidAddressHex(c) {
+			t.Errorf("expected %q to be rejected", c)
+		}
+	}
+
+	if !IsValidAddressHex("0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED") {
+		t.Error("all-uppercase address must be accepted without checksum")
+	}
+}
+
+func TestAddressWord(t *testing.T) {
+	a, err := AddressFromHex("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := a.ToWord()
+	if w.Hex() != "0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed" {
+		t.Fatalf("unexpected word %s", w.Hex())
+	}
+
+	back, err := AddressFromWord(w)
+	if err != nil || back != a {
+		t.Fatalf("round-trip failed: %s (%v)", back, err)
+	}
+
+	w[0] = 1
+	if _, err := AddressFromWord(w); err == nil {
+		t.Fatal("expected error for dirty upper bytes")
+	}
+
+	if _, err := AddressFromBytes(make([]byte, 32)); err == nil {
+		t.Fatal("expected error for 32-byte slice")
+	}
+
+	if !(Address{}).IsZero() || a.IsZero() {
+		t.Fatal("IsZero mismatch")
+	}
+}
@@ -0,0 +1,84 @@
+package bytecast
+
+import "unsafe"
+
+// DefaultArenaChunkSize is chunk size used by NewArena when non-positive size is given.
+const DefaultArenaChunkSize = 64 * 1024
+
+// Arena allocates decoded byte slices and strings from large chunks,
+// so decoding millions of small records creates a few big heap objects instead of millions of tiny ones.
+//
+// Go has no manual memory management, so "freeing" means dropping references: a chunk is garbage collected
+// together, once none of the values allocated from it is reachable. Keep arena-backed values only as long
+// as the whole batch is needed, one long-living small string keeps its entire chunk alive.
+//
+// Arena is not safe for concurrent use.
+type Arena struct {
+	chunkSize int
+	chunk     []byte // free tail of the current chunk
+}
+
+func NewArena(chunkSize int) *Arena {
+	if chunkSize <= 0 {
+		chunkSize = DefaultArenaChunkSize
+	}
+	return &Arena{chunkSize: chunkSize}
+}
+
+// Alloc returns zeroed slice of n bytes. Capacity of returned slice is limited to n,
+// so appending to it reallocates instead of overwriting neighbour values.
+func (a *Arena) Alloc(n int) []byte {
+	if n <= 0 {
+		return []byte{}
+	}
+
+	// big allocations would waste most of the chunk, give them dedicated memory
+	if n > a.chunkSize/4 {
+		return make([]byte, n)
+	}
+
+	if n > len(a.chunk) {
+		a.chunk = make([]byte, a.chunkSize)
+	}
+
+	b := a.chunk[:n:n]
+	a.chunk = a.chunk[n:]
+
+	return b
+}
+
+// CopyBytes returns arena-backed copy of b.
+func (a *Arena) CopyBytes(b []byte) []byte {
+	out := a.Alloc(len(b))
+	copy(out, b)
+	return out
+}
+
+// String returns arena-backed string with content of b.
+func (a *Arena) String(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	out := a.CopyBytes(b)
+
+	// out is never exposed to the caller, so the string stays immutable
+	return unsafe.String(&out[0], len(out))
+}
+
+// StringFrom256Bytes is arena-backed version of StringFrom256Bytes.
+func (a *Arena) StringFrom256Bytes(byteVal [256]byte) string {
+	significantBytesCount := int(byteVal[0])
+
+	if significantBytesCount == 0 {
+		return ""
+	}
+
+	return a.String(byteVal[256-significantBytesCount:])
+}
+
+// Reset forgets current chunk, subsequent allocations start a new one.
+// Previously returned values stay valid.
+func (a *Arena) Reset() {
+	a.chunk = nil
+}
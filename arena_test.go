@@ -0,0 +1,74 @@
+package bytecast
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArenaAlloc(t *testing.T) {
+	a := NewArena(64)
+
+	first := a.Alloc(10)
+	second := a.Alloc(10)
+
+	if len(first) != 10 || cap(first) != 10 {
+		t.Fatalf("expected len and cap 10, got %d and %d", len(first), cap(first))
+	}
+
+	// appending to the first slice must not overwrite the second
+	second[0] = 0xaa
+	first = append(first, 0xff)
+	if second[0] != 0xaa {
+		t.Fatal("append to arena slice overwrote neighbour allocation")
+	}
+
+	// big allocation does not consume the chunk
+	big := a.Alloc(1000)
+	if len(big) != 1000 {
+		t.Fatalf("expected 1000 bytes, got %d", len(big))
+	}
+
+	if got := a.Alloc(0); len(got) != 0 {
+		t.Fatalf("expected empty slice, got %d bytes", len(got))
+	}
+}
+
+func TestArenaStrings(t *testing.T) {
+	a := NewArena(0)
+
+	src := []byte("hello")
+	s := a.String(src)
+	src[0] = 'j'
+	if s != "hello" {
+		t.Fatalf("arena string must not alias source, got %q", s)
+	}
+
+	for _, want := range []string{"", "a", strings.Repeat("z", 255)} {
+		b, err := StringTo256Bytes(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := a.StringFrom256Bytes(b); got != want {
+			t.Fatalf("expected %q got %q", want, got)
+		}
+	}
+
+	a.Reset()
+	if s != "hello" {
+		t.Fatalf("values must survive Reset, got %q", s)
+	}
+}
+
+func TestArenaAllocations(t *testing.T) {
+	a := NewArena(1 << 20)
+	b, _ := StringTo256Bytes("small record field")
+	a.StringFrom256Bytes(b) // allocate the chunk
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		a.StringFrom256Bytes(b)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations per string, got %v", allocs)
+	}
+}
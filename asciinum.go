@@ -0,0 +1,110 @@
+package bytecast
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// ASCII numeric fields as used by tar / ar / cpio headers, e.g. tar mode "0000644\x00"
+// (octal, zero-padded, NUL-terminated) or ar size "1234      " (decimal, left-aligned, space-padded).
+
+type asciiNumConfig struct {
+	pad       byte
+	leftAlign bool
+	nul       bool
+}
+
+type ASCIINumOption func(*asciiNumConfig)
+
+// WithSpacePad pads the number with leading spaces instead of zeros.
+func WithSpacePad() ASCIINumOption {
+	return func(c *asciiNumConfig) {
+		c.pad = ' '
+	}
+}
+
+// WithLeftAlign writes the number at the beginning of the field followed by spaces (ar style).
+func WithLeftAlign() ASCIINumOption {
+	return func(c *asciiNumConfig) {
+		c.leftAlign = true
+	}
+}
+
+// WithNULTerminator reserves the last byte of the field for NUL terminator (tar style).
+func WithNULTerminator() ASCIINumOption {
+	return func(c *asciiNumConfig) {
+		c.nul = true
+	}
+}
+
+// EncodeASCIIOctal writes value as octal digits into field of exactly width bytes,
+// zero-padded on the left by default.
+func EncodeASCIIOctal(value uint64, width int, opts ...ASCIINumOption) ([]byte, error) {
+	return encodeASCIINum(strconv.FormatUint(value, 8), width, opts)
+}
+
+// EncodeASCIIDecimal writes value as decimal digits into field of exactly width bytes,
+// zero-padded on the left by default.
+func EncodeASCIIDecimal(value uint64, width int, opts ...ASCIINumOption) ([]byte, error) {
+	return encodeASCIINum(strconv.FormatUint(value, 10), width, opts)
+}
+
+// ParseASCIIOctal parses field written by EncodeASCIIOctal with any options:
+// leading and trailing spaces and NULs are ignored, empty field is 0.
+func ParseASCIIOctal(field []byte) (uint64, error) {
+	return parseASCIINum(field, 8)
+}
+
+// ParseASCIIDecimal parses field written by EncodeASCIIDecimal with any options:
+// leading and trailing spaces and NULs are ignored, empty field is 0.
+func ParseASCIIDecimal(field []byte) (uint64, error) {
+	return parseASCIINum(field, 10)
+}
+
+func encodeASCIINum(digits string, width int, opts []ASCIINumOption) ([]byte, error) {
+	c := asciiNumConfig{pad: '0'}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	room := width
+	if c.nul {
+		room--
+	}
+
+	if len(digits) > room {
+		return nil, fmt.Errorf("number %s needs %d digits, but field has room for %d", digits, len(digits), max(room, 0))
+	}
+
+	out := make([]byte, width)
+	if c.leftAlign {
+		copy(out, digits)
+		for i := len(digits); i < room; i++ {
+			out[i] = ' '
+		}
+	} else {
+		for i := 0; i < room-len(digits); i++ {
+			out[i] = c.pad
+		}
+		copy(out[room-len(digits):], digits)
+	}
+
+	// out[room] (if any) is already NUL
+
+	return out, nil
+}
+
+func parseASCIINum(field []byte, base int) (uint64, error) {
+	digits := bytes.Trim(field, " \x00")
+	if len(digits) == 0 {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseUint(string(digits), base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid base-%d numeric field %q: %w", base, field, err)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,77 @@
+package bytecast
+
+import (
+	"testing"
+)
+
+func TestEncodeASCIINum(t *testing.T) {
+	tests := []struct {
+		name   string
+		encode func(uint64, int, ...ASCIINumOption) ([]byte, error)
+		value  uint64
+		width  int
+		opts   []ASCIINumOption
+		want   string
+	}{
+		{"tar mode", EncodeASCIIOctal, 0644, 8, []ASCIINumOption{WithNULTerminator()}, "0000644\x00"},
+		{"tar size", EncodeASCIIOctal, 1024, 12, []ASCIINumOption{WithNULTerminator()}, "00000002000\x00"},
+		{"space padded", EncodeASCIIOctal, 7, 4, []ASCIINumOption{WithSpacePad()}, "   7"},
+		{"ar size", EncodeASCIIDecimal, 1234, 10, []ASCIINumOption{WithLeftAlign()}, "1234      "},
+		{"decimal full", EncodeASCIIDecimal, 99, 2, nil, "99"},
+		{"zero", EncodeASCIIDecimal, 0, 3, nil, "000"},
+		{"left aligned with nul", EncodeASCIIDecimal, 5, 4, []ASCIINumOption{WithLeftAlign(), WithNULTerminator()}, "5  \x00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.encode(tt.value, tt.width, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("expected %q got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEncodeASCIINumOverflow(t *testing.T) {
+	if _, err := EncodeASCIIDecimal(100, 2); err == nil {
+		t.Fatal("expected error for 3 digits in 2-byte field")
+	}
+	if _, err := EncodeASCIIOctal(8, 2, WithNULTerminator()); err == nil {
+		t.Fatal("expected error: 010 octal does not fit before NUL")
+	}
+}
+
+func TestParseASCIINum(t *testing.T) {
+	tests := []struct {
+		field string
+		base  int
+		want  uint64
+	}{
+		{"0000644\x00", 8, 0644},
+		{"   7", 8, 7},
+		{"1234      ", 10, 1234},
+		{"\x00\x00\x00\x00", 8, 0},
+		{"", 10, 0},
+	}
+
+	for _, tt := range tests {
+		parse := ParseASCIIDecimal
+		if tt.base == 8 {
+			parse = ParseASCIIOctal
+		}
+
+		got, err := parse([]byte(tt.field))
+		if err != nil || got != tt.want {
+			t.Errorf("parse(%q) = %d (%v); want %d", tt.field, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"0009", "12 34", "-1"} {
+		if _, err := ParseASCIIOctal([]byte(bad)); err == nil {
+			t.Errorf("ParseASCIIOctal(%q): expected error", bad)
+		}
+	}
+}
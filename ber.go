@@ -0,0 +1,85 @@
+package bytecast
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBERIndefiniteLength is returned by DecodeBERLength for indefinite form (single 0x80 octet),
+// where content is terminated by end-of-contents octets instead of declared length.
+var ErrBERIndefiniteLength = errors.New("indefinite BER length")
+
+// EncodeBERLength
+//
+//	Encodes definite length in ASN.1 BER/DER form (X.690 section 8.1.3):
+//
+//	n < 128 → short form, single byte n
+//	else    → long form, 0x80|k followed by k big-endian bytes of n (minimal k, as DER requires)
+func EncodeBERLength(n uint64) []byte {
+	return AppendBERLength(nil, n)
+}
+
+// AppendBERLength appends encoding of n (see EncodeBERLength) to dst.
+func AppendBERLength(dst []byte, n uint64) []byte {
+	if n < 0x80 {
+		return append(dst, byte(n))
+	}
+
+	k := 0
+	for v := n; v > 0; v >>= 8 {
+		k++
+	}
+
+	dst = append(dst, 0x80|byte(k))
+	for i := k - 1; i >= 0; i-- {
+		dst = append(dst, byte(n>>(8*i)))
+	}
+	return dst
+}
+
+// DecodeBERLength
+//
+//	Decodes length octets at the beginning of b and returns length with number of consumed bytes.
+//	Non-minimal long forms are accepted as BER allows, use DecodeDERLength to reject them.
+//	Indefinite form yields ErrBERIndefiniteLength with 1 consumed byte. Long forms over 8 bytes
+//	and reserved 0xFF are rejected.
+func DecodeBERLength(b []byte) (uint64, int, error) {
+	if len(b) < 1 {
+		return 0, 0, fmt.Errorf("missing BER length")
+	}
+
+	first := b[0]
+	if first < 0x80 {
+		return uint64(first), 1, nil
+	}
+	if first == 0x80 {
+		return 0, 1, ErrBERIndefiniteLength
+	}
+
+	k := int(first & 0x7F)
+	if k > 8 {
+		return 0, 0, fmt.Errorf("BER length of %d bytes is not supported, max 8", k)
+	}
+	if len(b) < 1+k {
+		return 0, 0, fmt.Errorf("truncated BER length: expected %d bytes, but got %d", 1+k, len(b))
+	}
+
+	var n uint64
+	for _, c := range b[1 : 1+k] {
+		n = n<<8 | uint64(c)
+	}
+	return n, 1 + k, nil
+}
+
+// DecodeDERLength is DecodeBERLength accepting only minimal definite forms, as DER requires:
+// long form for lengths below 128 and leading zero bytes are rejected, indefinite form is an error.
+func DecodeDERLength(b []byte) (uint64, int, error) {
+	n, consumed, err := DecodeBERLength(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	if consumed > 1 && (n < 0x80 || b[1] == 0) {
+		return 0, 0, fmt.Errorf("non-minimal DER length encoding % X", b[:consumed])
+	}
+	return n, consumed, nil
+}
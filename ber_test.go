@@ -0,0 +1,70 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestBERLength(t *testing.T) {
+	cases := []struct {
+		n   uint64
+		hex string
+	}{
+		{0, "00"},
+		{127, "7f"},
+		{128, "8180"},
+		{255, "81ff"},
+		{256, "820100"},
+		{65536, "83010000"},
+		{1<<64 - 1, "88ffffffffffffffff"},
+	}
+	for _, c := range cases {
+		b := EncodeBERLength(c.n)
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("EncodeBERLength(%d) = %s, expected %s", c.n, got, c.hex)
+		}
+
+		// trailing content must not be consumed
+		in := append(b, 0xAA)
+		for _, decode := range []func([]byte) (uint64, int, error){DecodeBERLength, DecodeDERLength} {
+			n, consumed, err := decode(in)
+			if err != nil || n != c.n || consumed != len(b) {
+				t.Errorf("decode(%s) = %d, %d (%v), expected %d, %d", c.hex, n, consumed, err, c.n, len(b))
+			}
+		}
+	}
+
+	if got := AppendBERLength([]byte{0x04}, 3); hex.EncodeToString(got) != "0403" {
+		t.Errorf("AppendBERLength = %x", got)
+	}
+}
+
+func TestBERLengthNonMinimal(t *testing.T) {
+	for _, h := range []string{"8105", "82007f", "820080"} {
+		b, _ := hex.DecodeString(h)
+		if _, _, err := DecodeBERLength(b); err != nil {
+			t.Errorf("DecodeBERLength(%s): unexpected error %v", h, err)
+		}
+		if _, _, err := DecodeDERLength(b); err == nil {
+			t.Errorf("DecodeDERLength(%s): expected error", h)
+		}
+	}
+}
+
+func TestBERLengthErrors(t *testing.T) {
+	n, consumed, err := DecodeBERLength([]byte{0x80, 0x01})
+	if !errors.Is(err, ErrBERIndefiniteLength) || consumed != 1 || n != 0 {
+		t.Errorf("expected ErrBERIndefiniteLength with 1 consumed byte, got %d, %d (%v)", n, consumed, err)
+	}
+	if _, _, err := DecodeDERLength([]byte{0x80}); !errors.Is(err, ErrBERIndefiniteLength) {
+		t.Errorf("expected ErrBERIndefiniteLength from DER, got %v", err)
+	}
+
+	for _, h := range []string{"", "89000000000000000001", "ff", "8201"} {
+		b, _ := hex.DecodeString(h)
+		if _, _, err := DecodeBERLength(b); err == nil {
+			t.Errorf("DecodeBERLength(%q): expected error", h)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package bytecast
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Bit positions are counted from the least significant bit: offset 0 is bit 0 (value 1),
+// so a field with offset 4 and width 8 occupies mask 0x0FF0.
+
+// ExtractBits returns width bits of word starting at bit offset.
+func ExtractBits(word uint64, offset, width int) (uint64, error) {
+	if err := validateBitRange(offset, width); err != nil {
+		return 0, err
+	}
+	return (word >> uint(offset)) & bitMask(width), nil
+}
+
+// InsertBits returns word with width bits starting at bit offset replaced by value,
+// value must fit in width bits.
+func InsertBits(word uint64, offset, width int, value uint64) (uint64, error) {
+	if err := validateBitRange(offset, width); err != nil {
+		return 0, err
+	}
+
+	if bits.Len64(value) > width {
+		return 0, fmt.Errorf("value %d does not fit in %d bits", value, width)
+	}
+
+	mask := bitMask(width) << uint(offset)
+	return word&^mask | value<<uint(offset), nil
+}
+
+// FieldSpec describes sub-word field for PackFields / UnpackFields.
+type FieldSpec struct {
+	Name   string // used in error messages only
+	Offset int    // bit offset from the least significant bit
+	Width  int    // bits
+}
+
+// PackFields inserts values[i] into *word at position of specs[i], bits outside of the fields are kept.
+// Fields must not overlap, every value must fit in its field width. On error *word is not modified.
+//
+// Encode the resulting word with UintXXToBytesAndExpandWidth or Uint64/32/16 helpers.
+func PackFields(word *uint64, specs []FieldSpec, values []uint64) error {
+	if len(specs) != len(values) {
+		return fmt.Errorf("got %d field specs but %d values", len(specs), len(values))
+	}
+
+	if err := validateFieldSpecs(specs); err != nil {
+		return err
+	}
+
+	w := *word
+	for i, spec := range specs {
+		var err error
+		if w, err = InsertBits(w, spec.Offset, spec.Width, values[i]); err != nil {
+			return fmt.Errorf("field %q: %w", spec.Name, err)
+		}
+	}
+
+	*word = w
+	return nil
+}
+
+// UnpackFields is the inverse of PackFields, it returns values of all fields in specs order.
+func UnpackFields(word uint64, specs []FieldSpec) ([]uint64, error) {
+	if err := validateFieldSpecs(specs); err != nil {
+		return nil, err
+	}
+
+	values := make([]uint64, len(specs))
+	for i, spec := range specs {
+		values[i], _ = ExtractBits(word, spec.Offset, spec.Width) // range already validated
+	}
+
+	return values, nil
+}
+
+func validateFieldSpecs(specs []FieldSpec) error {
+	var used uint64
+
+	for _, spec := range specs {
+		if err := validateBitRange(spec.Offset, spec.Width); err != nil {
+			return fmt.Errorf("field %q: %w", spec.Name, err)
+		}
+
+		mask := bitMask(spec.Width) << uint(spec.Offset)
+		if used&mask != 0 {
+			return fmt.Errorf("field %q overlaps with previous fields", spec.Name)
+		}
+		used |= mask
+	}
+
+	return nil
+}
+
+func validateBitRange(offset, width int) error {
+	if width <= 0 || width > 64 {
+		return fmt.Errorf("unsupported bit width %d, must be 1..64", width)
+	}
+
+	if offset < 0 || offset+width > 64 {
+		return fmt.Errorf("bit range [%d, %d) does not fit in 64-bit word", offset, offset+width)
+	}
+
+	return nil
+}
+
+// bitMask returns mask of lower width bits, width 1..64.
+func bitMask(width int) uint64 {
+	return ^uint64(0) >> uint(64-width)
+}
@@ -0,0 +1,87 @@
+package bytecast
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractInsertBits(t *testing.T) {
+	tests := []struct {
+		word   uint64
+		offset int
+		width  int
+		want   uint64
+	}{
+		{0x0FF0, 4, 8, 0xFF},
+		{0x8000000000000000, 63, 1, 1},
+		{0xFFFFFFFFFFFFFFFF, 0, 64, 0xFFFFFFFFFFFFFFFF},
+		{0xABC, 0, 4, 0xC},
+	}
+
+	for _, tt := range tests {
+		got, err := ExtractBits(tt.word, tt.offset, tt.width)
+		if err != nil || got != tt.want {
+			t.Errorf("ExtractBits(%#x, %d, %d) = %#x (%v); want %#x", tt.word, tt.offset, tt.width, got, err, tt.want)
+		}
+	}
+
+	w, err := InsertBits(0xFFFF, 4, 8, 0x12)
+	if err != nil || w != 0xF12F {
+		t.Fatalf("InsertBits = %#x (%v); want 0xf12f", w, err)
+	}
+
+	if _, err := InsertBits(0, 0, 4, 16); err == nil {
+		t.Fatal("expected error for value not fitting in 4 bits")
+	}
+
+	for _, r := range [][2]int{{0, 0}, {-1, 4}, {60, 5}, {0, 65}} {
+		if _, err := ExtractBits(0, r[0], r[1]); err == nil {
+			t.Errorf("ExtractBits offset %d width %d: expected error", r[0], r[1])
+		}
+	}
+}
+
+func TestPackFields(t *testing.T) {
+	// 16-bit register: [ mode:3 | enabled:1 | reserved:4 | level:8 ]
+	specs := []FieldSpec{
+		{Name: "level", Offset: 0, Width: 8},
+		{Name: "enabled", Offset: 12, Width: 1},
+		{Name: "mode", Offset: 13, Width: 3},
+	}
+
+	word := uint64(0x0A00) // reserved bits must survive
+	if err := PackFields(&word, specs, []uint64{0x7F, 1, 5}); err != nil {
+		t.Fatal(err)
+	}
+	if word != 0xBA7F {
+		t.Fatalf("expected 0xba7f got %#x", word)
+	}
+
+	values, err := UnpackFields(word, specs)
+	if err != nil || !reflect.DeepEqual(values, []uint64{0x7F, 1, 5}) {
+		t.Fatalf("UnpackFields = %v (%v)", values, err)
+	}
+}
+
+func TestPackFieldsErrors(t *testing.T) {
+	word := uint64(0x1234)
+
+	err := PackFields(&word, []FieldSpec{{"a", 0, 4}, {"b", 3, 4}}, []uint64{0, 0})
+	if err == nil || !strings.Contains(err.Error(), `"b" overlaps`) {
+		t.Fatalf("expected overlap error, got %v", err)
+	}
+
+	err = PackFields(&word, []FieldSpec{{"a", 0, 4}, {"b", 4, 2}}, []uint64{1, 4})
+	if err == nil || !strings.Contains(err.Error(), `"b"`) {
+		t.Fatalf("expected range error for b, got %v", err)
+	}
+
+	if err := PackFields(&word, []FieldSpec{{"a", 0, 4}}, nil); err == nil {
+		t.Fatal("expected error for values count mismatch")
+	}
+
+	if word != 0x1234 {
+		t.Fatalf("word must not be modified on error, got %#x", word)
+	}
+}
@@ -0,0 +1,146 @@
+package bytecast
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+type bulkConfig struct {
+	parallelism int
+}
+
+// BulkOption configures EncodeAll and DecodeAll.
+type BulkOption func(*bulkConfig)
+
+// WithParallelism sets number of workers, runtime.GOMAXPROCS(0) by default. Values below 1 mean 1.
+func WithParallelism(n int) BulkOption {
+	return func(c *bulkConfig) {
+		c.parallelism = max(n, 1)
+	}
+}
+
+// EncodeAll encodes values (see Marshal) and returns their concatenation in the order of values.
+// Work is split into contiguous shards encoded in parallel. Values of fixed-size types are encoded
+// directly into the result; other values are encoded per shard and then joined.
+// If several values fail, error of the first one is returned.
+func EncodeAll[T any](values []T, opts ...BulkOption) ([]byte, error) {
+	c := newBulkConfig(opts)
+
+	size, fixed := fixedDecodeSize(new(T))
+	shards := shardRanges(len(values), c.parallelism)
+	parts := make([][]byte, len(shards))
+
+	var out []byte
+	if fixed {
+		out = make([]byte, size*len(values))
+	}
+
+	err := runShards(shards, func(shard int, lo, hi int) (int, error) {
+		var buf []byte
+		if fixed {
+			buf = out[lo*size : lo*size : hi*size]
+		}
+
+		for i := lo; i < hi; i++ {
+			var err error
+			if buf, err = MarshalAppend(buf, values[i]); err != nil {
+				return i, err
+			}
+		}
+
+		parts[shard] = buf
+		return hi, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if fixed {
+		return out, nil
+	}
+
+	total := 0
+	for _, p := range parts {
+		total += len(p)
+	}
+	out = make([]byte, 0, total)
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out, nil
+}
+
+// DecodeAll decodes back-to-back records of fixed-size type T (see RecordReader) in parallel,
+// preserving their order. data must contain whole records only.
+// If several records fail, error of the first one is returned.
+func DecodeAll[T any](data []byte, opts ...BulkOption) ([]T, error) {
+	c := newBulkConfig(opts)
+
+	size, err := recordSize[T]()
+	if err != nil {
+		return nil, err
+	}
+	if len(data)%size != 0 {
+		return nil, fmt.Errorf("data length %d is not a multiple of record size %d", len(data), size)
+	}
+
+	values := make([]T, len(data)/size)
+
+	err = runShards(shardRanges(len(values), c.parallelism), func(_ int, lo, hi int) (int, error) {
+		for i := lo; i < hi; i++ {
+			if err := Unmarshal(data[i*size:(i+1)*size], &values[i]); err != nil {
+				return i, err
+			}
+		}
+		return hi, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func newBulkConfig(opts []BulkOption) bulkConfig {
+	c := bulkConfig{parallelism: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// shardRanges splits [0, n) into at most parts contiguous ranges of nearly equal length.
+func shardRanges(n, parts int) [][2]int {
+	parts = max(min(parts, n), 1)
+	shards := make([][2]int, parts)
+	for i := range shards {
+		shards[i] = [2]int{n * i / parts, n * (i + 1) / parts}
+	}
+	return shards
+}
+
+// runShards runs work for every shard in its own goroutine. work returns index of the failed
+// element along with the error; the error of the lowest index is returned, prefixed with the index.
+func runShards(shards [][2]int, work func(shard, lo, hi int) (int, error)) error {
+	failed := make([]int, len(shards))
+	errs := make([]error, len(shards))
+
+	var wg sync.WaitGroup
+	for i, s := range shards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			failed[i], errs[i] = work(i, s[0], s[1])
+		}()
+	}
+	wg.Wait()
+
+	// shards are ordered, so the first failed shard holds the lowest index
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("value %d: %w", failed[i], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package bytecast
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeAll(t *testing.T) {
+	records := make([]dumpTestRecord, 1000)
+	for i := range records {
+		records[i] = dumpTestRecord{Version: uint8(i), Delta: int16(-i), Ok: i%2 == 0}
+	}
+
+	var sequential []byte
+	for _, r := range records {
+		sequential, _ = MarshalAppend(sequential, r)
+	}
+
+	for _, p := range []int{0, 1, 3, 8, 5000} {
+		data, err := EncodeAll(records, WithParallelism(p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, sequential) {
+			t.Fatalf("parallelism %d: output differs from sequential encoding", p)
+		}
+
+		decoded, err := DecodeAll[dumpTestRecord](data, WithParallelism(p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(decoded, records) {
+			t.Fatalf("parallelism %d: decoded records differ", p)
+		}
+	}
+
+	if data, err := EncodeAll([]uint32{}); err != nil || len(data) != 0 {
+		t.Fatalf("expected empty output, got % x (%v)", data, err)
+	}
+}
+
+func TestEncodeAllVariableSize(t *testing.T) {
+	values := [][]string{{"a"}, {}, {"bc", "d"}, {"e"}}
+
+	var sequential []byte
+	for _, v := range values {
+		sequential, _ = MarshalAppend(sequential, v)
+	}
+
+	data, err := EncodeAll(values, WithParallelism(3))
+	if err != nil || !bytes.Equal(data, sequential) {
+		t.Fatalf("expected % x, got % x (%v)", sequential, data, err)
+	}
+
+	if _, err := DecodeAll[[]string](data); err == nil {
+		t.Fatal("expected error for variable-size type")
+	}
+}
+
+func TestEncodeDecodeAllErrors(t *testing.T) {
+	values := []*optionalTestRecord{{ID: 1}, {ID: 2}, nil, {ID: 4}, nil}
+	_, err := EncodeAll(values, WithParallelism(2))
+	if err == nil || !strings.HasPrefix(err.Error(), "value 2: ") {
+		t.Fatalf("expected error of value 2, got %v", err)
+	}
+
+	data := []byte{0, 1, 2, 3, 0xff, 5}
+	type flag struct{ On *bool }
+	_, err = DecodeAll[flag](data[:5])
+	if err == nil {
+		t.Fatal("expected error for partial record")
+	}
+	_, err = DecodeAll[flag](data, WithParallelism(4))
+	if err == nil || !strings.HasPrefix(err.Error(), "value 1: ") {
+		t.Fatalf("expected error of record 1, got %v", err)
+	}
+}
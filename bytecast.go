@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
 	"reflect"
 )
 
@@ -30,6 +31,8 @@ func ToTypedValue[T any](v reflect.Value) (T, error) {
 // IntXXToBytesAndExpandWidth
 //
 //	Takes value passed in int64 container, but handles it as xx-bit value, represents as bytes slice and expand to specified width.
+//	xx may be any number of bits 1..64, not only multiple of 8 (e.g. 12, 20, 36 for register maps);
+//	value occupies (xx+7)/8 lower bytes and all bits above xx are copies of the sign bit.
 //
 //	NOTE:
 //	xx => bits
@@ -42,43 +45,32 @@ func IntXXToBytesAndExpandWidth(value int64, xx int, width int) ([]byte, error)
 	// [ s xxxxxxx xxxxxxxx ... xxxxxxxx ]   ← 56 bits
 	//   ^
 	//   sign bit (bit 55)
-
-	maxPossibleV := int64(1)<<(xx-1) - 1
-	minPossibleV := int64(-1) << (xx - 1)
-
-	if value < minPossibleV || value > maxPossibleV {
+	//
+	// value ^ (value >> 63) flips all bits of negative value (-v - 1 >= 0) and keeps positive as is,
+	// so value fits in xx bits if what is left occupies no more than xx-1 bits (1 bit reserved for sign)
+	if bits.Len64(uint64(value^(value>>63))) > xx-1 {
 		return nil, fmt.Errorf("value %d does not fit in int%d", value, xx)
 	}
 
-	// take lower 56 bits
-	// (1 << 56)     = 100000...000
-	// (1 << 56) - 1 = 011111...111
-	var u uint64
-	if value < 0 {
-		u = uint64(value + (1 << xx)) // two's complement: 2^N + v (де v < 0)
-	} else {
-		u = uint64(value)
+	// How many bytes "netto" we need to store value?
+	neededBytesNum := (xx + 7) / 8
+
+	if width < neededBytesNum {
+		return nil, fmt.Errorf("provided width too short, got %d expected min %d for int%d", width, neededBytesNum, xx)
 	}
 
-	// How many bytes "netto" we need to store value?
-	neededBytesNum := int(math.Ceil(float64(xx) / float64(8)))
+	// int64 is already two's complement, sign-extended to 64 bits, so its lower neededBytesNum bytes
+	// are the correctly sign-extended xx-bit value even if xx is not a multiple of 8,
+	// e.g. -1 as int12 → 0xFF 0xFF (not 0x0F 0xFF)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(value))
 
 	out := make([]byte, width)
 
 	// Write into last neededBytesNum bytes (big-endian)
 	// Example for int56 (neededBytesNum == 7) and width == 32:
-	// 	out[25] = byte(u >> 48)
-	//	out[26] = byte(u >> 40)
-	//	out[27] = byte(u >> 32)
-	//	out[28] = byte(u >> 24)
-	//	out[29] = byte(u >> 16)
-	//	out[30] = byte(u >> 8)
-	//	out[31] = byte(u)
-	offset := (neededBytesNum - 1) * 8
-	for i := width - neededBytesNum; i < width; i++ {
-		out[i] = byte(u >> uint(offset))
-		offset -= 8
-	}
+	//	out[25:32] = buf[1:8]
+	copy(out[width-neededBytesNum:], buf[8-neededBytesNum:])
 
 	// sign extension (only for negative values)
 	if value < 0 {
@@ -94,6 +86,7 @@ func IntXXToBytesAndExpandWidth(value int64, xx int, width int) ([]byte, error)
 //
 //	Takes value passed in uint64 container, but handles it as xx-bit unsigned value,
 //	represents it as bytes slice and expands to specified width (e.g., 32 bytes for EVM).
+//	xx may be any number of bits 1..64, not only multiple of 8.
 //
 //	NOTE:
 //	xx => bits
@@ -104,25 +97,24 @@ func UintXXToBytesAndExpandWidth(value uint64, xx int, width int) ([]byte, error
 	}
 
 	// Перевіряємо, чи число поміщається в xx біт
-	maxPossibleV := uint64(1)<<xx - 1
-	if value > maxPossibleV {
+	if bits.Len64(value) > xx {
 		return nil, fmt.Errorf("value %d does not fit in uint%d", value, xx)
 	}
 
-	// Відкидаємо старші біти, залишаємо тільки xx бітів
-	u := value & maxPossibleV
-
 	// Скільки байт реально потрібно для зберігання xx бітів?
-	neededBytesNum := int(math.Ceil(float64(xx) / 8.0))
+	neededBytesNum := (xx + 7) / 8
+
+	if width < neededBytesNum {
+		return nil, fmt.Errorf("provided width too short, got %d expected min %d for uint%d", width, neededBytesNum, xx)
+	}
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], value)
 
 	out := make([]byte, width)
 
 	// Записуємо в останні neededBytesNum байт (big-endian)
-	offset := (neededBytesNum - 1) * 8
-	for i := width - neededBytesNum; i < width; i++ {
-		out[i] = byte(u >> uint(offset))
-		offset -= 8
-	}
+	copy(out[width-neededBytesNum:], buf[8-neededBytesNum:])
 
 	// Для unsigned чисел старші байти просто нулі (у нас out вже zeroed)
 	return out, nil
@@ -138,7 +130,7 @@ func IntXXFromBytes(bytes []byte, xx int) (int64, error) {
 	}
 
 	// Скільки байт реально потрібно для зберігання xx бітів?
-	neededBytesNum := int(math.Ceil(float64(xx) / 8.0))
+	neededBytesNum := (xx + 7) / 8
 
 	if len(bytes) < neededBytesNum {
 		return 0, fmt.Errorf(
@@ -148,17 +140,22 @@ func IntXXFromBytes(bytes []byte, xx int) (int64, error) {
 	}
 
 	// We take last "neededBytes" bytes from received "bytes" bytes:
-	var u uint64
-	offset := (neededBytesNum - 1) * 8
-	start := len(bytes) - neededBytesNum
-
-	for i := start; i < len(bytes); i++ {
-		u |= uint64(bytes[i]) << offset
-		offset -= 8
-	}
+	var buf [8]byte
+	copy(buf[8-neededBytesNum:], bytes[len(bytes)-neededBytesNum:])
+	// bits above xx (possible if xx is not a multiple of 8) must be either zeros (masked two's complement,
+	// as written by older encoder) or copies of the sign bit
+	raw := binary.BigEndian.Uint64(buf[:])
+	u := raw & (math.MaxUint64 >> (64 - xx))
 
 	signBitPosition := xx - 1
 
+	if high := raw &^ u; high != 0 {
+		signExtension := (uint64(math.MaxUint64) >> (64 - 8*neededBytesNum)) &^ (math.MaxUint64 >> (64 - xx))
+		if u&(1<<signBitPosition) == 0 || high != signExtension {
+			return 0, fmt.Errorf("bits above %d are neither zero nor sign extension in int%d value 0x%x", xx, xx, raw)
+		}
+	}
+
 	// For int64 2s complement not needed, int64 already has correct sign
 	if xx == 64 || u&(1<<signBitPosition) == 0 {
 		return int64(u), nil
@@ -180,9 +177,7 @@ func IntXXFromBytes(bytes []byte, xx int) (int64, error) {
 	// v after OR:
 	// 11111111 1xxxxxxx xxxxxxxx xxxxxxxx
 
-	var mask uint64
-	mask = ^((1 << xx) - 1)
-	u |= mask
+	u |= ^(math.MaxUint64 >> (64 - xx))
 
 	return int64(u), nil
 }
@@ -197,7 +192,7 @@ func UintXXFromBytes(bytes []byte, xx int) (uint64, error) {
 	}
 
 	// How many bytes needed to store xx bits?
-	neededBytesNum := int(math.Ceil(float64(xx) / 8.0))
+	neededBytesNum := (xx + 7) / 8
 
 	if len(bytes) < neededBytesNum {
 		return 0, fmt.Errorf(
@@ -207,15 +202,14 @@ func UintXXFromBytes(bytes []byte, xx int) (uint64, error) {
 	}
 
 	// Take last neededBytesNum bytes (big-endian)
-	var u uint64
-	offset := (neededBytesNum - 1) * 8
-	start := len(bytes) - neededBytesNum
+	var buf [8]byte
+	copy(buf[8-neededBytesNum:], bytes[len(bytes)-neededBytesNum:])
 
-	for i := start; i < len(bytes); i++ {
-		u |= uint64(bytes[i]) << offset
-		offset -= 8
+	// bits above xx (possible if xx is not a multiple of 8) must be zeros
+	u := binary.BigEndian.Uint64(buf[:])
+	if u>>(xx-1)>>1 != 0 { // two shifts, because shift by 64 is not allowed for xx == 64
+		return 0, fmt.Errorf("bits above %d are not zero in uint%d value 0x%x", xx, xx, u)
 	}
-
 	return u, nil
 }
 
@@ -410,14 +404,19 @@ func BigIntToBytesAndExpandWidth(bigInt *big.Int, width int) ([]byte, error) {
 	mod := new(big.Int).Lsh(big.NewInt(1), bitLen)
 	twos := new(big.Int).Add(mod, bigInt)
 
-	if twos.Sign() < 0 || twos.BitLen() > int(bitLen) {
+	// v >= -2^(N-1) exactly when the sign bit of 2^N + v is set, smaller values would wrap to positive
+	if twos.Sign() <= 0 || twos.BitLen() != int(bitLen) {
 		return nil, fmt.Errorf("integer %s cannot fit in %d bytes", bigInt.String(), width)
 	}
 
-	// вже правильне представлення у width байт, не добиваємо нулями
+	// sign bit is set, so the representation is exactly width bytes
 	return twos.Bytes(), nil
 }
 
+// BigIntFromBytes
+//
+//	Interprets bytes as signed two's complement value, width is inferred from input length.
+//	When width and signedness are known in advance, use BigIntFromBytesWidth.
 func BigIntFromBytes(byteValue []byte) *big.Int {
 	// unsigned interpretation
 	x := new(big.Int).SetBytes(byteValue)
@@ -435,6 +434,55 @@ func BigIntFromBytes(byteValue []byte) *big.Int {
 	return x.Sub(x, mod)
 }
 
+// BigIntFromBytesWidth
+//
+//	Interprets bytes as "bits"-bit integer, signed (two's complement) or unsigned,
+//	so 64-byte and 128-byte words can be decoded either way: BigIntFromBytesWidth(word, 512, false).
+//
+//	Input length must be exactly ceil(bits / 8) bytes. If bits is not a multiple of 8,
+//	unused high bits must be zeros (unsigned) or copies of the sign bit (signed).
+func BigIntFromBytesWidth(byteValue []byte, bits int, signed bool) (*big.Int, error) {
+	if bits <= 0 {
+		return nil, fmt.Errorf("unsupported bit size %d, must be positive", bits)
+	}
+
+	neededBytesNum := (bits + 7) / 8
+
+	if len(byteValue) != neededBytesNum {
+		return nil, fmt.Errorf(
+			"expected exactly %d bytes to interpret as %d-bit value, but got %d bytes",
+			neededBytesNum, bits, len(byteValue),
+		)
+	}
+
+	x := new(big.Int).SetBytes(byteValue)
+	fullBits := neededBytesNum * 8
+
+	if !signed {
+		if x.BitLen() > bits {
+			return nil, fmt.Errorf("value 0x%x does not fit in uint%d", byteValue, bits)
+		}
+		return x, nil
+	}
+
+	// all bits from sign bit up to the top of the input must be equal
+	signBit := x.Bit(bits - 1)
+	for i := bits; i < fullBits; i++ {
+		if x.Bit(i) != signBit {
+			return nil, fmt.Errorf("value 0x%x is not sign-extended int%d", byteValue, bits)
+		}
+	}
+
+	if signBit == 0 {
+		return x, nil
+	}
+
+	// negative number:
+	// x = x - 2^(8 * len(b))
+	mod := new(big.Int).Lsh(big.NewInt(1), uint(fullBits))
+	return x.Sub(x, mod), nil
+}
+
 // BigIntXXXFromBytes
 //
 //	Takes "bytes" bytes from input and interpret them as big.Int-xxx (int128, int256) value,
@@ -474,6 +522,62 @@ func BigIntXXXFromBytes(bytes []byte, xxx int) (*big.Int, error) {
 	return resultUnsigned.Sub(resultUnsigned, mod), nil
 }
 
+// BigIntToMinimalBytes
+//
+//	Represents big.Int as 1 sign byte (0x00 for zero and positive, 0x01 for negative)
+//	followed by minimal-length big-endian magnitude, without any fixed padding:
+//
+//	0    → 00
+//	255  → 00 ff
+//	-256 → 01 01 00
+func BigIntToMinimalBytes(bigInt *big.Int) []byte {
+	if bigInt == nil {
+		bigInt = big.NewInt(0)
+	}
+
+	magnitude := bigInt.Bytes() // absolute value, minimal length, empty for zero
+
+	out := make([]byte, 0, 1+len(magnitude))
+	if bigInt.Sign() < 0 {
+		out = append(out, 0x01)
+	} else {
+		out = append(out, 0x00)
+	}
+
+	return append(out, magnitude...)
+}
+
+// BigIntFromMinimalBytes
+//
+//	Inverse of BigIntToMinimalBytes. Accepts only canonical form:
+//	sign byte must be 0x00 or 0x01, magnitude must not have leading zero bytes, zero must not be negative.
+func BigIntFromMinimalBytes(byteValue []byte) (*big.Int, error) {
+	if len(byteValue) == 0 {
+		return nil, fmt.Errorf("expected at least 1 byte (sign), but got 0 bytes")
+	}
+
+	sign, magnitude := byteValue[0], byteValue[1:]
+
+	if sign > 0x01 {
+		return nil, fmt.Errorf("invalid sign byte 0x%02x, expected 0x00 or 0x01", sign)
+	}
+
+	if len(magnitude) > 0 && magnitude[0] == 0x00 {
+		return nil, fmt.Errorf("non-minimal encoding, magnitude has leading zero byte")
+	}
+
+	if sign == 0x01 && len(magnitude) == 0 {
+		return nil, fmt.Errorf("non-canonical encoding, negative zero")
+	}
+
+	v := new(big.Int).SetBytes(magnitude)
+	if sign == 0x01 {
+		v.Neg(v)
+	}
+
+	return v, nil
+}
+
 func BoolTo1Byte(boolVal bool) [1]byte {
 	if boolVal {
 		return [1]byte{0x01} // завжди 1 для true
@@ -499,21 +603,46 @@ func BoolFrom1Byte(bytesVal [1]byte) bool {
 //
 //	IMPORTANT! Max input string length LIMITED TO 255 bytes
 //	(this is 255 ascii symbols where 1 symbol can be represented by 1 byte)
+//
+//	Layout: [ length (1 byte) | zero padding | string bytes ], string is aligned to the end of array.
 func StringTo256Bytes(stringValue string) ([256]byte, error) {
-	stringBytes := []byte(stringValue)
-	l := len(stringBytes)
+	var dataArray [256]byte
 
-	if l > 255 {
-		return [256]byte{}, fmt.Errorf("string length exceeded, max 255 bytes allowed")
+	if err := putString256(dataArray[:], stringValue); err != nil {
+		return [256]byte{}, err
 	}
 
-	fixedWidthData := make([]byte, 0, 256)
-	fixedWidthData = append(fixedWidthData, uint8(l))
-	fixedWidthData = append(fixedWidthData, LeftPadBytes00(stringBytes, 255)...)
+	return dataArray, nil
+}
+
+// AppendString256 appends 256-byte representation of string (see StringTo256Bytes) to dst
+// and returns extended slice, so records can be built in reusable buffer without intermediate arrays.
+func AppendString256(dst []byte, stringValue string) ([]byte, error) {
+	if len(stringValue) > 255 {
+		return dst, fmt.Errorf("string length exceeded, max 255 bytes allowed")
+	}
+
+	n := len(dst)
+	dst = append(dst, make([]byte, 256)...)
+
+	// length is checked above, so error is impossible here
+	_ = putString256(dst[n:], stringValue)
+
+	return dst, nil
+}
+
+// putString256 writes length byte and right-aligned payload directly into 256-byte zeroed out.
+func putString256(out []byte, stringValue string) error {
+	l := len(stringValue)
+
+	if l > 255 {
+		return fmt.Errorf("string length exceeded, max 255 bytes allowed")
+	}
 
-	dataArray := (*[256]byte)(fixedWidthData)
+	out[0] = uint8(l)
+	copy(out[256-l:], stringValue)
 
-	return *dataArray, nil
+	return nil
 }
 
 func StringFrom256Bytes(byteVal [256]byte) string {
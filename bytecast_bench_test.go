@@ -0,0 +1,364 @@
+package bytecast
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// Sinks prevent compiler from optimizing benchmarked calls away.
+var (
+	benchBytes  []byte
+	benchInt64  int64
+	benchUint64 uint64
+	benchBigInt *big.Int
+	benchString string
+	benchBool   bool
+	benchFloat  float64
+	benchErr    error
+
+	benchArray256 [256]byte
+)
+
+func BenchmarkIntXXToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = IntXXToBytesAndExpandWidth(-1234567890123, 56, 32)
+	}
+}
+
+func BenchmarkUintXXToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = UintXXToBytesAndExpandWidth(1234567890123, 56, 32)
+	}
+}
+
+func BenchmarkIntXXFromBytes(b *testing.B) {
+	in, _ := IntXXToBytesAndExpandWidth(-1234567890123, 56, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchInt64, benchErr = IntXXFromBytes(in, 56)
+	}
+}
+
+func BenchmarkUintXXFromBytes(b *testing.B) {
+	in, _ := UintXXToBytesAndExpandWidth(1234567890123, 56, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchUint64, benchErr = UintXXFromBytes(in, 56)
+	}
+}
+
+func BenchmarkInt64To8Bytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := Int64To8Bytes(int64(i))
+		benchBytes = v[:]
+	}
+}
+
+func BenchmarkInt64ToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = Int64ToBytesAndExpandWidth(-int64(i), 32)
+	}
+}
+
+func BenchmarkInt64From8Bytes(b *testing.B) {
+	in := Int64To8Bytes(-1234567890123)
+	for i := 0; i < b.N; i++ {
+		benchInt64 = Int64From8Bytes(in)
+	}
+}
+
+func BenchmarkInt32To4Bytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := Int32To4Bytes(int32(i))
+		benchBytes = v[:]
+	}
+}
+
+func BenchmarkInt32ToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = Int32ToBytesAndExpandWidth(-int32(i), 32)
+	}
+}
+
+func BenchmarkInt32From4Bytes(b *testing.B) {
+	in := Int32To4Bytes(-123456)
+	for i := 0; i < b.N; i++ {
+		benchInt64 = int64(Int32From4Bytes(in))
+	}
+}
+
+func BenchmarkUint32To4Bytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := Uint32To4Bytes(uint32(i))
+		benchBytes = v[:]
+	}
+}
+
+func BenchmarkUint32ToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = Uint32ToBytesAndExpandWidth(uint32(i), 32)
+	}
+}
+
+func BenchmarkUint32From4Bytes(b *testing.B) {
+	in := Uint32To4Bytes(123456)
+	for i := 0; i < b.N; i++ {
+		benchUint64 = uint64(Uint32From4Bytes(in))
+	}
+}
+
+func BenchmarkInt16To2Bytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := Int16To2Bytes(int16(i))
+		benchBytes = v[:]
+	}
+}
+
+func BenchmarkInt16ToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = Int16ToBytesAndExpandWidth(-int16(i), 32)
+	}
+}
+
+func BenchmarkInt16From2Bytes(b *testing.B) {
+	in := Int16To2Bytes(-12345)
+	for i := 0; i < b.N; i++ {
+		benchInt64 = int64(Int16From2Bytes(in))
+	}
+}
+
+func BenchmarkUint16To2Bytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := Uint16To2Bytes(uint16(i))
+		benchBytes = v[:]
+	}
+}
+
+func BenchmarkUint16ToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = Uint16ToBytesAndExpandWidth(uint16(i), 32)
+	}
+}
+
+func BenchmarkUint16From2Bytes(b *testing.B) {
+	in := Uint16To2Bytes(12345)
+	for i := 0; i < b.N; i++ {
+		benchUint64 = uint64(Uint16From2Bytes(in))
+	}
+}
+
+func BenchmarkInt8To1Byte(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := Int8To1Byte(int8(i))
+		benchBytes = v[:]
+	}
+}
+
+func BenchmarkInt8ToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = Int8ToBytesAndExpandWidth(-int8(i), 32)
+	}
+}
+
+func BenchmarkInt8From1Byte(b *testing.B) {
+	in := Int8To1Byte(-123)
+	for i := 0; i < b.N; i++ {
+		benchInt64 = int64(Int8From1Byte(in))
+	}
+}
+
+func BenchmarkUint8To1Byte(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := Uint8To1Byte(uint8(i))
+		benchBytes = v[:]
+	}
+}
+
+func BenchmarkUint8ToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = Uint8ToBytesAndExpandWidth(uint8(i), 32)
+	}
+}
+
+func BenchmarkUint8From1Byte(b *testing.B) {
+	in := Uint8To1Byte(123)
+	for i := 0; i < b.N; i++ {
+		benchUint64 = uint64(Uint8From1Byte(in))
+	}
+}
+
+func BenchmarkBigIntToBytesAndExpandWidth(b *testing.B) {
+	v := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 200))
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = BigIntToBytesAndExpandWidth(v, 32)
+	}
+}
+
+func BenchmarkBigIntFromBytes(b *testing.B) {
+	in, _ := BigIntToBytesAndExpandWidth(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 200)), 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchBigInt = BigIntFromBytes(in)
+	}
+}
+
+func BenchmarkBigIntFromBytesWidth(b *testing.B) {
+	in, _ := BigIntToBytesAndExpandWidth(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 200)), 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchBigInt, benchErr = BigIntFromBytesWidth(in, 256, true)
+	}
+}
+
+func BenchmarkBigIntXXXFromBytes(b *testing.B) {
+	in, _ := BigIntToBytesAndExpandWidth(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 100)), 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchBigInt, benchErr = BigIntXXXFromBytes(in, 128)
+	}
+}
+
+func BenchmarkBigIntToMinimalBytes(b *testing.B) {
+	v := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 200))
+	for i := 0; i < b.N; i++ {
+		benchBytes = BigIntToMinimalBytes(v)
+	}
+}
+
+func BenchmarkBigIntFromMinimalBytes(b *testing.B) {
+	in := BigIntToMinimalBytes(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 200)))
+	for i := 0; i < b.N; i++ {
+		benchBigInt, benchErr = BigIntFromMinimalBytes(in)
+	}
+}
+
+func BenchmarkBoolTo1Byte(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := BoolTo1Byte(i&1 == 0)
+		benchBytes = v[:]
+	}
+}
+
+func BenchmarkBoolToBytesAndExpandWidth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = BoolToBytesAndExpandWidth(i&1 == 0, 32)
+	}
+}
+
+func BenchmarkBoolFrom1Byte(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBool = BoolFrom1Byte([1]byte{byte(i)})
+	}
+}
+
+func BenchmarkStringTo256Bytes(b *testing.B) {
+	s := strings.Repeat("a", 100)
+	for i := 0; i < b.N; i++ {
+		benchArray256, benchErr = StringTo256Bytes(s)
+	}
+}
+
+func BenchmarkStringFrom256Bytes(b *testing.B) {
+	in, _ := StringTo256Bytes(strings.Repeat("a", 100))
+	for i := 0; i < b.N; i++ {
+		benchString = StringFrom256Bytes(in)
+	}
+}
+
+func BenchmarkLeftPadBytes(b *testing.B) {
+	in := []byte{0x01, 0x02, 0x03}
+	for i := 0; i < b.N; i++ {
+		benchBytes = LeftPadBytes(in, 32, 0xAB)
+	}
+}
+
+func BenchmarkFixedPointToBytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = FixedPointToBytes(-123.456, 16, 16, 4)
+	}
+}
+
+func BenchmarkFixedPointFromBytes(b *testing.B) {
+	in, _ := FixedPointToBytes(-123.456, 16, 16, 4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchFloat, benchErr = FixedPointFromBytes(in, 16, 16)
+	}
+}
+
+func BenchmarkDecimalToBytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = DecimalToBytes("-1234567.89", 2, 32)
+	}
+}
+
+func BenchmarkDecimalFromBytes(b *testing.B) {
+	in, _ := DecimalToBytes("-1234567.89", 2, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchString, benchErr = DecimalFromBytes(in, 2)
+	}
+}
+
+func BenchmarkDecimal64ToBytesBID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v, err := Decimal64ToBytesBID("-1234567.89")
+		benchBytes, benchErr = v[:], err
+	}
+}
+
+func BenchmarkDecimal64FromBytesBID(b *testing.B) {
+	in, _ := Decimal64ToBytesBID("-1234567.89")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchString = Decimal64FromBytesBID(in)
+	}
+}
+
+func BenchmarkDecimal64ToBytesDPD(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v, err := Decimal64ToBytesDPD("-1234567.89")
+		benchBytes, benchErr = v[:], err
+	}
+}
+
+func BenchmarkDecimal64FromBytesDPD(b *testing.B) {
+	in, _ := Decimal64ToBytesDPD("-1234567.89")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchString = Decimal64FromBytesDPD(in)
+	}
+}
+
+func BenchmarkSwapEndian16(b *testing.B) {
+	data := make([]byte, 1<<16)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		benchErr = SwapEndian16(data)
+	}
+}
+
+func BenchmarkSwapEndian32(b *testing.B) {
+	data := make([]byte, 1<<16)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		benchErr = SwapEndian32(data)
+	}
+}
+
+func BenchmarkSwapEndian64(b *testing.B) {
+	data := make([]byte, 1<<16)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		benchErr = SwapEndian64(data)
+	}
+}
+
+func BenchmarkAppendString256(b *testing.B) {
+	s := strings.Repeat("a", 100)
+	buf := make([]byte, 0, 256)
+	for i := 0; i < b.N; i++ {
+		benchBytes, benchErr = AppendString256(buf[:0], s)
+	}
+}
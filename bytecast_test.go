@@ -1,6 +1,7 @@
 package bytecast
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -200,6 +201,94 @@ func TestUintXXFromBytes(t *testing.T) {
 	}
 }
 
+func TestIntXXNonByteAlignedWidths(t *testing.T) {
+	tests := []struct {
+		value int64
+		bits  int
+		want  string // 4 bytes
+	}{
+		{-1, 12, "ffffffff"},
+		{-2048, 12, "fffff800"},
+		{2047, 12, "000007ff"},
+		{-524288, 20, "fff80000"},
+		{524287, 20, "0007ffff"},
+		{-1, 1, "ffffffff"},
+		{0, 1, "00000000"},
+	}
+
+	for _, tt := range tests {
+		out, err := IntXXToBytesAndExpandWidth(tt.value, tt.bits, 4)
+		if err != nil {
+			t.Fatalf("IntXXToBytesAndExpandWidth(%d, %d) returned error: %v", tt.value, tt.bits, err)
+		}
+		if got := hex.EncodeToString(out); got != tt.want {
+			t.Fatalf("IntXXToBytesAndExpandWidth(%d, %d) = %s; want %s", tt.value, tt.bits, got, tt.want)
+		}
+
+		back, err := IntXXFromBytes(out, tt.bits)
+		if err != nil || back != tt.value {
+			t.Fatalf("IntXXFromBytes(%s, %d) = %d (%v); want %d", tt.want, tt.bits, back, err, tt.value)
+		}
+
+		// padded result is the same value as big integer
+		if BigIntFromBytes(out).Int64() != tt.value {
+			t.Fatalf("sign extension broken for %d as int%d: %s", tt.value, tt.bits, tt.want)
+		}
+	}
+
+	// 36 bits → 5 bytes
+	out, err := IntXXToBytesAndExpandWidth(-(1 << 35), 36, 5)
+	if err != nil || hex.EncodeToString(out) != "f800000000" {
+		t.Fatalf("int36 min: got %x (%v)", out, err)
+	}
+
+	outOfRange := []struct {
+		value int64
+		bits  int
+	}{
+		{2048, 12}, {-2049, 12}, {1 << 19, 20}, {1 << 35, 36}, {1, 1},
+	}
+	for _, tt := range outOfRange {
+		if _, err := IntXXToBytesAndExpandWidth(tt.value, tt.bits, 8); err == nil {
+			t.Errorf("expected range error for %d as int%d", tt.value, tt.bits)
+		}
+	}
+
+	// bits above xx may be zeros on decode (older encoder wrote masked values), but not garbage
+	if v, err := IntXXFromBytes([]byte{0x0f, 0xff}, 12); err != nil || v != -1 {
+		t.Fatalf("expected -1 from masked int12, got %d (%v)", v, err)
+	}
+	for _, garbage := range []string{"f001", "1001", "8fff", "70000000"} {
+		in, _ := hex.DecodeString(garbage)
+		if v, err := IntXXFromBytes(in, 12+8*(len(in)-2)); err == nil {
+			t.Errorf("expected error for int with invalid upper bits %s, got %d", garbage, v)
+		}
+	}
+}
+
+func TestUintXXNonByteAlignedWidths(t *testing.T) {
+	out, err := UintXXToBytesAndExpandWidth(4095, 12, 2)
+	if err != nil || hex.EncodeToString(out) != "0fff" {
+		t.Fatalf("uint12 max: got %x (%v)", out, err)
+	}
+
+	if _, err := UintXXToBytesAndExpandWidth(4096, 12, 2); err == nil {
+		t.Fatal("expected range error for 4096 as uint12")
+	}
+
+	out, err = UintXXToBytesAndExpandWidth(1<<36-1, 36, 5)
+	if err != nil || hex.EncodeToString(out) != "0fffffffff" {
+		t.Fatalf("uint36 max: got %x (%v)", out, err)
+	}
+
+	if v, err := UintXXFromBytes([]byte{0x0f, 0xff, 0xff}, 20); err != nil || v != 1<<20-1 {
+		t.Fatalf("expected 2^20-1, got %d (%v)", v, err)
+	}
+	if v, err := UintXXFromBytes([]byte{0xff, 0xff, 0xff}, 20); err == nil {
+		t.Fatalf("expected error for non-zero upper bits, got %d", v)
+	}
+}
+
 func TestInt32SignExtension(t *testing.T) {
 	cases := []int32{
 		100,
@@ -365,6 +454,16 @@ func TestWidthTooSmall(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error")
 	}
+
+	_, err = BigIntToBytesAndExpandWidth(big.NewInt(-129), 1)
+	if err == nil {
+		t.Fatal("expected error for negative overflow")
+	}
+
+	out, err := BigIntToBytesAndExpandWidth(big.NewInt(-128), 2)
+	if err != nil || hex.EncodeToString(out) != "ff80" {
+		t.Fatalf("expected ff80, got %x (%v)", out, err)
+	}
 }
 
 func TestBoolFromByte(t *testing.T) {
@@ -424,3 +523,207 @@ func TestString256Boundaries(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestBigIntMinimalBytes(t *testing.T) {
+	tests := []struct {
+		value *big.Int
+		want  string
+	}{
+		{big.NewInt(0), "00"},
+		{big.NewInt(1), "0001"},
+		{big.NewInt(255), "00ff"},
+		{big.NewInt(-1), "0101"},
+		{big.NewInt(-256), "010100"},
+		{new(big.Int).Lsh(big.NewInt(1), 255), "008000000000000000000000000000000000000000000000000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		b := BigIntToMinimalBytes(tt.value)
+
+		got := fmt.Sprintf("%x", b)
+		if got != tt.want {
+			t.Errorf("BigIntToMinimalBytes(%s) = %s; want %s", tt.value, got, tt.want)
+		}
+
+		back, err := BigIntFromMinimalBytes(b)
+		if err != nil {
+			t.Fatalf("BigIntFromMinimalBytes(%x) returned error: %v", b, err)
+		}
+
+		if back.Cmp(tt.value) != 0 {
+			t.Fatalf("expected %s got %s", tt.value, back)
+		}
+	}
+}
+
+func TestBigIntFromMinimalBytesNonCanonical(t *testing.T) {
+	cases := []string{
+		"",       // no sign byte
+		"02",     // invalid sign
+		"0000",   // leading zero in magnitude
+		"010001", // leading zero in magnitude
+		"01",     // negative zero
+	}
+
+	for _, c := range cases {
+		b, _ := hex.DecodeString(c)
+		if _, err := BigIntFromMinimalBytes(b); err == nil {
+			t.Errorf("BigIntFromMinimalBytes(%s): expected error", c)
+		}
+	}
+}
+
+func TestBigIntFromBytesWidth(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputHex  string
+		bits      int
+		signed    bool
+		expected  string // decimal string
+		expectErr bool
+	}{
+		{
+			name:     "uint512 all ones",
+			inputHex: strings.Repeat("ff", 64),
+			bits:     512,
+			signed:   false,
+			expected: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 512), big.NewInt(1)).String(),
+		},
+		{
+			name:     "int512 all ones",
+			inputHex: strings.Repeat("ff", 64),
+			bits:     512,
+			signed:   true,
+			expected: "-1",
+		},
+		{
+			name:     "int1024 one",
+			inputHex: strings.Repeat("00", 127) + "01",
+			bits:     1024,
+			signed:   true,
+			expected: "1",
+		},
+		{
+			name:     "int12 negative",
+			inputHex: "f800",
+			bits:     12,
+			signed:   true,
+			expected: "-2048",
+		},
+		{
+			name:     "uint12 max",
+			inputHex: "0fff",
+			bits:     12,
+			signed:   false,
+			expected: "4095",
+		},
+		{
+			name:      "uint12 overflow",
+			inputHex:  "1fff",
+			bits:      12,
+			expectErr: true,
+		},
+		{
+			name:      "int12 not sign-extended",
+			inputHex:  "0800",
+			bits:      12,
+			signed:    true,
+			expectErr: true,
+		},
+		{
+			name:      "wrong length",
+			inputHex:  strings.Repeat("00", 32),
+			bits:      512,
+			expectErr: true,
+		},
+		{
+			name:      "zero bits",
+			inputHex:  "",
+			bits:      0,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputBytes, err := hex.DecodeString(tt.inputHex)
+			if err != nil {
+				t.Fatalf("failed to decode hex: %v", err)
+			}
+
+			result, err := BigIntFromBytesWidth(inputBytes, tt.bits, tt.signed)
+
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expected error but got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.String() != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStringTo256BytesLayout(t *testing.T) {
+	b, err := StringTo256Bytes("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "03" + strings.Repeat("00", 252) + "616263"
+	if got := hex.EncodeToString(b[:]); got != want {
+		t.Fatalf("expected %s got %s", want, got)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		b, _ = StringTo256Bytes("abc")
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestAppendString256(t *testing.T) {
+	dst := []byte{0xaa}
+
+	dst, err := AppendString256(dst, "hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dst) != 257 || dst[0] != 0xaa {
+		t.Fatalf("unexpected result length %d or prefix %x", len(dst), dst[0])
+	}
+
+	if got := StringFrom256Bytes([256]byte(dst[1:])); got != "hi" {
+		t.Fatalf("expected %q got %q", "hi", got)
+	}
+
+	// reused buffer with garbage must still produce zero padding
+	buf := bytes.Repeat([]byte{0xff}, 512)[:0]
+	buf, err = AppendString256(buf, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf[1:255], make([]byte, 254)) {
+		t.Fatal("expected zero padding in reused buffer")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = AppendString256(buf[:0], "abc")
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations with enough capacity, got %v", allocs)
+	}
+
+	if _, err := AppendString256(nil, strings.Repeat("a", 256)); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,3 @@
+// Package bytecasttest provides helpers for tests of code using bytecast: golden files
+// locking wire formats of encoded values, and runners of external test vectors.
+package bytecasttest
@@ -0,0 +1,130 @@
+package bytecasttest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anxp/bytecast"
+)
+
+// UpdateEnv is environment variable which makes Golden (re)write golden files instead of comparing:
+//
+//	BYTECAST_UPDATE_GOLDEN=1 go test ./...
+const UpdateEnv = "BYTECAST_UPDATE_GOLDEN"
+
+// Golden compares bytecast.Marshal(v) with golden file testdata/<name>.golden and reports mismatch
+// as test error. For structs the error lists differing fields with their decoded values (see bytecast.Diff).
+//
+// Golden file is hex, struct fields are written one per line with their names as comments;
+// whitespace and text after '#' are ignored, so the files are easy to review in pull requests.
+// Set UpdateEnv to create or update golden files.
+func Golden(t testing.TB, name string, v any) {
+	t.Helper()
+	GoldenFile(t, filepath.Join("testdata", name+".golden"), v)
+}
+
+// GoldenFile is Golden with explicit path of the golden file.
+func GoldenFile(t testing.TB, path string, v any) {
+	t.Helper()
+
+	got, err := bytecast.Marshal(v)
+	if err != nil {
+		t.Errorf("golden %s: encoding %T: %v", path, v, err)
+		return
+	}
+
+	schema, _ := bytecast.SchemaOf(v) // nil for non-struct values
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("golden %s: %v", path, err)
+			return
+		}
+		if err := os.WriteFile(path, []byte(FormatGolden(got, schema)), 0o644); err != nil {
+			t.Errorf("golden %s: %v", path, err)
+		}
+		return
+	}
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("golden %s: %v (run with %s=1 to create it)", path, err, UpdateEnv)
+		return
+	}
+
+	want, err := ParseGolden(string(text))
+	if err != nil {
+		t.Errorf("golden %s: %v", path, err)
+		return
+	}
+
+	if bytes.Equal(got, want) {
+		return
+	}
+
+	t.Errorf("golden %s: encoding of %T changed:\n%s", path, v, describeMismatch(want, got, schema))
+}
+
+// FormatGolden formats data as golden file content. If schema is not nil,
+// every field takes its own line followed by field name and kind.
+func FormatGolden(data []byte, schema *bytecast.Schema) string {
+	var sb strings.Builder
+
+	if schema == nil {
+		for len(data) > 0 {
+			n := min(len(data), 16)
+			sb.WriteString(hex.EncodeToString(data[:n]))
+			sb.WriteByte('\n')
+			data = data[n:]
+		}
+		return sb.String()
+	}
+
+	sb.WriteString("# " + schema.Type().String() + "\n")
+	for _, f := range schema.Fields() {
+		fmt.Fprintf(&sb, "%s  # %s %s\n", hex.EncodeToString(data[f.Offset:f.Offset+f.Width]), f.Name, f.Kind)
+	}
+	if len(data) > schema.Size() {
+		fmt.Fprintf(&sb, "%s  # <trailing>\n", hex.EncodeToString(data[schema.Size():]))
+	}
+	return sb.String()
+}
+
+// ParseGolden parses golden file content written by FormatGolden.
+func ParseGolden(text string) ([]byte, error) {
+	var digits strings.Builder
+	for i, line := range strings.Split(text, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		for _, field := range strings.Fields(line) {
+			if _, err := hex.DecodeString(field); err != nil {
+				return nil, fmt.Errorf("line %d: invalid hex %q", i+1, field)
+			}
+			digits.WriteString(field)
+		}
+	}
+	return hex.DecodeString(digits.String())
+}
+
+func describeMismatch(want, got []byte, schema *bytecast.Schema) string {
+	var sb strings.Builder
+
+	if schema != nil {
+		for _, d := range bytecast.Diff(want, got, schema) {
+			fmt.Fprintf(&sb, "\t%s at offset %d: golden %s, got %s\n", d.Field.Name, d.Field.Offset, d.A, d.B)
+		}
+		return sb.String()
+	}
+
+	i := 0
+	for i < len(want) && i < len(got) && want[i] == got[i] {
+		i++
+	}
+	fmt.Fprintf(&sb, "\tfirst difference at offset %d (golden %d bytes, got %d bytes)\n", i, len(want), len(got))
+	fmt.Fprintf(&sb, "\tgolden: %x\n\tgot:    %x\n", want, got)
+	return sb.String()
+}
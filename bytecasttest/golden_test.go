@@ -0,0 +1,89 @@
+package bytecasttest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type record struct {
+	Version uint8
+	Delta   int16
+	Ok      bool
+}
+
+// recorder captures reported errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestGoldenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "record.golden")
+
+	r := &recorder{TB: t}
+	GoldenFile(r, path, record{Version: 1, Delta: -2, Ok: true})
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], UpdateEnv) {
+		t.Fatalf("expected missing file error with hint, got %q", r.errors)
+	}
+
+	t.Setenv(UpdateEnv, "1")
+	GoldenFile(t, path, record{Version: 1, Delta: -2, Ok: true})
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "# bytecasttest.record\n01  # Version uint\nfffe  # Delta int\n01  # Ok bool\n"
+	if string(text) != expected {
+		t.Fatalf("unexpected golden file:\n%s\nexpected:\n%s", text, expected)
+	}
+
+	t.Setenv(UpdateEnv, "")
+	GoldenFile(t, path, record{Version: 1, Delta: -2, Ok: true})
+
+	r = &recorder{TB: t}
+	GoldenFile(r, path, record{Version: 1, Delta: 3, Ok: true})
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "Delta at offset 1: golden -2, got 3") {
+		t.Fatalf("expected field-annotated diff, got %q", r.errors)
+	}
+}
+
+func TestGoldenFileNonStruct(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.golden")
+	if err := os.WriteFile(path, []byte("# list\n00000001 00000002 6869\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	GoldenFile(t, path, []string{"hi"})
+
+	r := &recorder{TB: t}
+	GoldenFile(r, path, []string{"ho"})
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "first difference at offset 9") {
+		t.Fatalf("expected offset of difference, got %q", r.errors)
+	}
+}
+
+func TestParseGolden(t *testing.T) {
+	if _, err := ParseGolden("01 zz\n"); err == nil {
+		t.Error("expected error for invalid hex")
+	}
+	if _, err := ParseGolden("012\n"); err == nil {
+		t.Error("expected error for odd number of digits")
+	}
+
+	data := make([]byte, 40)
+	data[39] = 0xff
+	parsed, err := ParseGolden(FormatGolden(data, nil))
+	if err != nil || len(parsed) != 40 || parsed[39] != 0xff {
+		t.Fatalf("expected round trip, got %x (%v)", parsed, err)
+	}
+}
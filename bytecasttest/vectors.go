@@ -0,0 +1,164 @@
+package bytecasttest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/anxp/bytecast"
+)
+
+// Vector is one external test vector: encoding in hex and the value in textual form.
+type Vector struct {
+	Name  string `json:"name"`
+	Hex   string `json:"hex"`
+	Value string `json:"value"`
+	Error bool   `json:"error"` // decoding of Hex must fail, Value is ignored
+}
+
+// Codec converts values between textual form of vectors and encoding under test.
+type Codec struct {
+	Encode func(value string) ([]byte, error)
+	Decode func(data []byte) (string, error)
+}
+
+// MarshalCodec is Codec of bytecast.Marshal / bytecast.Unmarshal for type T. Values are parsed with
+// fmt.Sscan (strings are taken as is) and formatted with fmt.Sprint.
+func MarshalCodec[T any]() Codec {
+	return Codec{
+		Encode: func(value string) ([]byte, error) {
+			var v T
+			if s, ok := any(&v).(*string); ok {
+				*s = value
+			} else if _, err := fmt.Sscan(value, &v); err != nil {
+				return nil, fmt.Errorf("parsing value %q as %T: %w", value, v, err)
+			}
+			return bytecast.Marshal(v)
+		},
+		Decode: func(data []byte) (string, error) {
+			var v T
+			if err := bytecast.Unmarshal(data, &v); err != nil {
+				return "", err
+			}
+			return fmt.Sprint(v), nil
+		},
+	}
+}
+
+// LoadVectors reads vectors from JSON (.json) or CSV (.csv) file, see LoadVectorsJSON and LoadVectorsCSV.
+func LoadVectors(path string) ([]Vector, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return LoadVectorsJSON(f)
+	case ".csv":
+		return LoadVectorsCSV(f)
+	}
+	return nil, fmt.Errorf("unsupported test vector file %s, expected .json or .csv", path)
+}
+
+// LoadVectorsJSON reads JSON array of vectors:
+//
+//	[{"name": "max int16", "hex": "7fff", "value": "32767"}, {"hex": "80", "error": true}]
+func LoadVectorsJSON(r io.Reader) ([]Vector, error) {
+	var vectors []Vector
+	if err := json.NewDecoder(r).Decode(&vectors); err != nil {
+		return nil, fmt.Errorf("parsing test vectors: %w", err)
+	}
+	return vectors, nil
+}
+
+// LoadVectorsCSV reads CSV with header row naming columns hex and value, and optionally name and error
+// (true / false); other columns are ignored, so vectors published with extra comments can be used as is.
+func LoadVectorsCSV(r io.Reader) ([]Vector, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing test vectors: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("parsing test vectors: missing header row")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["hex"]; !ok {
+		return nil, errors.New("parsing test vectors: missing hex column")
+	}
+
+	cell := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	vectors := make([]Vector, 0, len(records)-1)
+	for line, record := range records[1:] {
+		v := Vector{Name: cell(record, "name"), Hex: cell(record, "hex"), Value: cell(record, "value")}
+		if e := cell(record, "error"); e != "" {
+			if v.Error, err = strconv.ParseBool(e); err != nil {
+				return nil, fmt.Errorf("parsing test vectors: line %d: invalid error column %q", line+2, e)
+			}
+		}
+		vectors = append(vectors, v)
+	}
+	return vectors, nil
+}
+
+// RunVectors runs every vector as subtest: Hex must decode to Value, and Value must encode to Hex.
+// Vectors with Error set must fail to decode.
+func RunVectors(t *testing.T, vectors []Vector, codec Codec) {
+	t.Helper()
+
+	for i, v := range vectors {
+		name := v.Name
+		if name == "" {
+			name = fmt.Sprintf("vector %d", i)
+		}
+
+		t.Run(name, func(t *testing.T) {
+			data, err := hex.DecodeString(strings.TrimPrefix(strings.ReplaceAll(v.Hex, " ", ""), "0x"))
+			if err != nil {
+				t.Fatalf("invalid hex %q: %v", v.Hex, err)
+			}
+
+			decoded, err := codec.Decode(data)
+			if v.Error {
+				if err == nil {
+					t.Fatalf("decoding %x: expected error, got %q", data, decoded)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decoding %x: %v", data, err)
+			}
+			if decoded != v.Value {
+				t.Errorf("decoding %x: expected %q, got %q", data, v.Value, decoded)
+			}
+
+			encoded, err := codec.Encode(v.Value)
+			if err != nil {
+				t.Fatalf("encoding %q: %v", v.Value, err)
+			}
+			if !bytes.Equal(encoded, data) {
+				t.Errorf("encoding %q: expected %x, got %x", v.Value, data, encoded)
+			}
+		})
+	}
+}
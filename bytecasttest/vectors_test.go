@@ -0,0 +1,62 @@
+package bytecasttest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anxp/bytecast"
+)
+
+func TestRunVectorsJSON(t *testing.T) {
+	vectors, err := LoadVectors("testdata/int16.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) != 4 || !vectors[3].Error {
+		t.Fatalf("unexpected vectors %+v", vectors)
+	}
+
+	RunVectors(t, vectors, MarshalCodec[int16]())
+}
+
+func TestRunVectorsCSV(t *testing.T) {
+	vectors, err := LoadVectors("testdata/strings.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) != 3 || vectors[1].Value != "[hi]" || !vectors[2].Error {
+		t.Fatalf("unexpected vectors %+v", vectors)
+	}
+
+	codec := MarshalCodec[[]string]()
+	codec.Encode = func(value string) ([]byte, error) { // fmt.Sscan can not parse lists
+		return bytecast.Marshal(strings.Fields(strings.Trim(value, "[]")))
+	}
+	RunVectors(t, vectors, codec)
+}
+
+func TestMarshalCodecString(t *testing.T) {
+	codec := MarshalCodec[string]()
+	data, err := codec.Encode("with spaces")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := codec.Decode(data); err != nil || s != "with spaces" {
+		t.Fatalf("expected round trip, got %q (%v)", s, err)
+	}
+}
+
+func TestLoadVectorsErrors(t *testing.T) {
+	if _, err := LoadVectorsCSV(strings.NewReader("name,value\nx,1\n")); err == nil {
+		t.Error("expected error for missing hex column")
+	}
+	if _, err := LoadVectorsCSV(strings.NewReader("hex,error\n00,maybe\n")); err == nil {
+		t.Error("expected error for invalid error column")
+	}
+	if _, err := LoadVectorsJSON(strings.NewReader("{")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if _, err := LoadVectors("testdata/vectors.txt"); err == nil {
+		t.Error("expected error for unsupported extension")
+	}
+}
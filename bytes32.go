@@ -0,0 +1,74 @@
+package bytecast
+
+import (
+	"bytes"
+	"math/big"
+)
+
+// Bytes32 is a 32-byte word (e.g. EVM word, hash), values are stored big-endian,
+// signed values in two's complement - the same layout as produced by XXToBytesAndExpandWidth(..., 32).
+type Bytes32 [32]byte
+
+// Bytes32FromSlice converts slice of exactly 32 bytes to Bytes32.
+func Bytes32FromSlice(b []byte) (out Bytes32, err error) {
+	return out, fixedFromSlice(out[:], b)
+}
+
+// Bytes32FromHex parses 64 hex digits, optionally prefixed with "0x".
+func Bytes32FromHex(s string) (out Bytes32, err error) {
+	return out, fixedFromHex(out[:], s)
+}
+
+// Bytes32FromBigInt stores x as 256-bit two's complement value.
+func Bytes32FromBigInt(x *big.Int) (out Bytes32, err error) {
+	return out, fixedFromBigInt(out[:], x)
+}
+
+// Bytes32FromInt64 stores v sign-extended to 32 bytes.
+func Bytes32FromInt64(v int64) (out Bytes32) {
+	fixedFromInt64(out[:], v)
+	return out
+}
+
+// Hex returns "0x"-prefixed lowercase hex representation.
+func (b Bytes32) Hex() string {
+	return fixedHex(b[:])
+}
+
+func (b Bytes32) String() string {
+	return b.Hex()
+}
+
+// Bytes returns copy of the word as slice.
+func (b Bytes32) Bytes() []byte {
+	return bytes.Clone(b[:])
+}
+
+// BigInt interprets the word as signed (two's complement) 256-bit value.
+func (b Bytes32) BigInt() *big.Int {
+	return BigIntFromBytes(b[:])
+}
+
+// BigUint interprets the word as unsigned 256-bit value.
+func (b Bytes32) BigUint() *big.Int {
+	return new(big.Int).SetBytes(b[:])
+}
+
+// Int64 interprets the word as signed value and returns error if it does not fit in int64.
+func (b Bytes32) Int64() (int64, error) {
+	return fixedInt64(b[:])
+}
+
+func (b Bytes32) IsZero() bool {
+	return b == Bytes32{}
+}
+
+func (b Bytes32) Equal(other Bytes32) bool {
+	return b == other
+}
+
+// Compare compares words lexicographically (which is unsigned numeric order),
+// returns -1, 0 or +1 like bytes.Compare.
+func (b Bytes32) Compare(other Bytes32) int {
+	return bytes.Compare(b[:], other[:])
+}
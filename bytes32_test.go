@@ -0,0 +1,95 @@
+package bytecast
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestBytes32FromHex(t *testing.T) {
+	want := "0x" + strings.Repeat("00", 31) + "2a"
+
+	for _, in := range []string{want, strings.TrimPrefix(want, "0x"), strings.ToUpper(want)} {
+		b, err := Bytes32FromHex(strings.Replace(in, "0X", "0x", 1))
+		if err != nil {
+			t.Fatalf("Bytes32FromHex(%q) returned error: %v", in, err)
+		}
+		if b.Hex() != want {
+			t.Fatalf("expected %s got %s", want, b.Hex())
+		}
+	}
+
+	for _, in := range []string{"0x2a", "0x" + strings.Repeat("zz", 32), strings.Repeat("00", 33)} {
+		if _, err := Bytes32FromHex(in); err == nil {
+			t.Errorf("Bytes32FromHex(%q): expected error", in)
+		}
+	}
+}
+
+func TestBytes32Conversions(t *testing.T) {
+	minusOne := Bytes32FromInt64(-1)
+	if minusOne.Hex() != "0x"+strings.Repeat("ff", 32) {
+		t.Fatalf("unexpected -1 representation %s", minusOne)
+	}
+
+	if minusOne.BigInt().Int64() != -1 {
+		t.Fatalf("expected -1, got %s", minusOne.BigInt())
+	}
+
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	if minusOne.BigUint().Cmp(maxUint256) != 0 {
+		t.Fatalf("expected 2^256-1, got %s", minusOne.BigUint())
+	}
+
+	for _, v := range []int64{0, 1, -1, math.MaxInt64, math.MinInt64} {
+		got, err := Bytes32FromInt64(v).Int64()
+		if err != nil || got != v {
+			t.Fatalf("Int64 round-trip of %d: got %d (%v)", v, got, err)
+		}
+	}
+
+	tooBig, err := Bytes32FromBigInt(new(big.Int).Lsh(big.NewInt(1), 63))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tooBig.Int64(); err == nil {
+		t.Fatal("expected range error for 2^63")
+	}
+
+	negative, err := Bytes32FromBigInt(big.NewInt(-256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if negative.BigInt().Int64() != -256 {
+		t.Fatalf("expected -256, got %s", negative.BigInt())
+	}
+
+	if _, err := Bytes32FromSlice(make([]byte, 31)); err == nil {
+		t.Fatal("expected error for 31 bytes")
+	}
+}
+
+func TestBytes32Comparison(t *testing.T) {
+	zero := Bytes32{}
+	one := Bytes32FromInt64(1)
+	minusOne := Bytes32FromInt64(-1)
+
+	if !zero.IsZero() || one.IsZero() {
+		t.Fatal("IsZero mismatch")
+	}
+
+	if !one.Equal(Bytes32FromInt64(1)) || one.Equal(zero) {
+		t.Fatal("Equal mismatch")
+	}
+
+	if zero.Compare(one) != -1 || one.Compare(one) != 0 || minusOne.Compare(one) != 1 {
+		t.Fatal("Compare mismatch")
+	}
+
+	b := one.Bytes()
+	b[31] = 0xff
+	if one[31] != 1 {
+		t.Fatal("Bytes must return a copy")
+	}
+}
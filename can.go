@@ -0,0 +1,156 @@
+package bytecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// CANByteOrder is byte order of a CAN signal as defined in DBC files.
+type CANByteOrder int
+
+const (
+	CANIntel    CANByteOrder = iota // little-endian, DBC "@1", start bit is the least significant bit
+	CANMotorola                     // big-endian, DBC "@0", start bit is the most significant bit
+)
+
+// CANSignal
+//
+//	Describes a signal inside CAN frame payload with DBC semantics:
+//
+//	SG_ EngineSpeed : 24|16@1+ (0.125,0) → CANSignal{StartBit: 24, Length: 16, Order: CANIntel, Scale: 0.125}
+//
+//	Bits are numbered as in DBC: bit n is bit n%8 (0 = least significant) of byte n/8. Intel signals grow
+//	from StartBit towards higher bytes, Motorola signals start at their most significant bit and continue
+//	with lower bits of the same byte, then bit 7 of the next byte. Physical value = raw*Scale + Offset.
+//	Payload may be classic 8-byte frame or CAN FD frame up to 64 bytes.
+type CANSignal struct {
+	StartBit int
+	Length   int
+	Order    CANByteOrder
+	Signed   bool
+	Scale    float64
+	Offset   float64
+}
+
+// DecodeRaw extracts raw signal bits from payload. For Signed signals raw is sign-extended to 64 bits,
+// convert it with int64(raw).
+func (s CANSignal) DecodeRaw(payload []byte) (uint64, error) {
+	positions, err := s.bitPositions(len(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	var raw uint64
+	for _, pos := range positions {
+		raw = raw<<1 | uint64(payload[pos/8]>>(pos%8)&1)
+	}
+
+	if s.Signed && s.Length < 64 && raw&(1<<(s.Length-1)) != 0 {
+		raw |= math.MaxUint64 << s.Length
+	}
+	return raw, nil
+}
+
+// EncodeRaw writes low Length bits of raw into payload in place, other bits of payload are kept.
+func (s CANSignal) EncodeRaw(payload []byte, raw uint64) error {
+	positions, err := s.bitPositions(len(payload))
+	if err != nil {
+		return err
+	}
+
+	for i, pos := range positions {
+		bit := byte(raw >> (s.Length - 1 - i) & 1)
+		payload[pos/8] = payload[pos/8]&^(1<<(pos%8)) | bit<<(pos%8)
+	}
+	return nil
+}
+
+// Decode returns physical value of the signal.
+func (s CANSignal) Decode(payload []byte) (float64, error) {
+	if err := s.validateScale(); err != nil {
+		return 0, err
+	}
+
+	raw, err := s.DecodeRaw(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.Signed {
+		return float64(int64(raw))*s.Scale + s.Offset, nil
+	}
+	return float64(raw)*s.Scale + s.Offset, nil
+}
+
+// Encode converts value to raw = round((value - Offset) / Scale) and writes it into payload in place.
+// Values outside of raw range are rejected.
+func (s CANSignal) Encode(payload []byte, value float64) error {
+	if err := s.validateScale(); err != nil {
+		return err
+	}
+	if _, err := s.bitPositions(len(payload)); err != nil {
+		return err
+	}
+
+	raw := math.Round((value - s.Offset) / s.Scale)
+
+	var lo, hi float64
+	if s.Signed {
+		lo, hi = -math.Ldexp(1, s.Length-1), math.Ldexp(1, s.Length-1)
+	} else {
+		lo, hi = 0, math.Ldexp(1, s.Length)
+	}
+	// hi is exclusive: it is exact power of two, so the comparison stays correct for 64-bit signals
+	if !(raw >= lo && raw < hi) {
+		return fmt.Errorf("value %v (raw %v) is out of range of %d-bit signal", value, raw, s.Length)
+	}
+
+	if s.Signed {
+		return s.EncodeRaw(payload, uint64(int64(raw)))
+	}
+	return s.EncodeRaw(payload, uint64(raw))
+}
+
+// bitPositions returns frame bit numbers of the signal from the most significant bit.
+func (s CANSignal) bitPositions(payloadLen int) ([]int, error) {
+	if s.Length < 1 || s.Length > 64 {
+		return nil, fmt.Errorf("unsupported CAN signal length %d, must be 1..64 bits", s.Length)
+	}
+	if s.StartBit < 0 || s.StartBit >= 8*payloadLen {
+		return nil, fmt.Errorf("CAN signal start bit %d is outside of %d-byte payload", s.StartBit, payloadLen)
+	}
+
+	positions := make([]int, s.Length)
+	switch s.Order {
+	case CANIntel:
+		for i := range positions {
+			positions[s.Length-1-i] = s.StartBit + i
+		}
+	case CANMotorola:
+		pos := s.StartBit
+		for i := range positions {
+			positions[i] = pos
+			if pos%8 == 0 {
+				pos += 15
+			} else {
+				pos--
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown CAN byte order %d", s.Order)
+	}
+
+	for _, pos := range positions {
+		if pos >= 8*payloadLen {
+			return nil, fmt.Errorf("CAN signal %d|%d does not fit %d-byte payload", s.StartBit, s.Length, payloadLen)
+		}
+	}
+	return positions, nil
+}
+
+func (s CANSignal) validateScale() error {
+	if s.Scale == 0 || math.IsNaN(s.Scale) || math.IsInf(s.Scale, 0) {
+		return fmt.Errorf("invalid CAN signal scale %v", s.Scale)
+	}
+	return nil
+}
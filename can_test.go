@@ -0,0 +1,102 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+func TestCANSignalRaw(t *testing.T) {
+	cases := []struct {
+		name    string
+		signal  CANSignal
+		payload string
+		raw     uint64
+	}{
+		{"intel 16 bit", CANSignal{StartBit: 8, Length: 16, Order: CANIntel}, "0034120000000000", 0x1234},
+		{"intel 4 bit", CANSignal{StartBit: 4, Length: 4, Order: CANIntel}, "a000000000000000", 0xA},
+		{"intel across bytes", CANSignal{StartBit: 6, Length: 4, Order: CANIntel}, "c002000000000000", 0xB},
+		{"motorola 16 bit", CANSignal{StartBit: 7, Length: 16, Order: CANMotorola}, "1234000000000000", 0x1234},
+		{"motorola 12 bit", CANSignal{StartBit: 3, Length: 12, Order: CANMotorola}, "0abc000000000000", 0xABC},
+		{"intel signed", CANSignal{StartBit: 0, Length: 8, Order: CANIntel, Signed: true}, "fe00000000000000", math.MaxUint64 - 1},
+		{"intel 64 bit", CANSignal{StartBit: 0, Length: 64, Order: CANIntel}, "0102030405060708", 0x0807060504030201},
+	}
+	for _, c := range cases {
+		payload, _ := hex.DecodeString(c.payload)
+		raw, err := c.signal.DecodeRaw(payload)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if raw != c.raw {
+			t.Errorf("%s: DecodeRaw = %#x, expected %#x", c.name, raw, c.raw)
+		}
+
+		fresh := make([]byte, len(payload))
+		if err := c.signal.EncodeRaw(fresh, c.raw); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := hex.EncodeToString(fresh); got != c.payload {
+			t.Errorf("%s: EncodeRaw = %s, expected %s", c.name, got, c.payload)
+		}
+	}
+}
+
+func TestCANSignalPhysical(t *testing.T) {
+	speed := CANSignal{StartBit: 24, Length: 16, Order: CANIntel, Scale: 0.125}
+	temp := CANSignal{StartBit: 7, Length: 8, Order: CANMotorola, Scale: 1, Offset: -40}
+	torque := CANSignal{StartBit: 47, Length: 12, Order: CANMotorola, Signed: true, Scale: 0.5}
+
+	payload := make([]byte, 8)
+	for _, step := range []struct {
+		signal CANSignal
+		value  float64
+	}{{speed, 2500.375}, {temp, 85}, {torque, -100.5}} {
+		if err := step.signal.Encode(payload, step.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := hex.EncodeToString(payload); got != "7d0000234ef37000" {
+		t.Errorf("unexpected payload %s", got)
+	}
+
+	for _, step := range []struct {
+		signal CANSignal
+		value  float64
+	}{{speed, 2500.375}, {temp, 85}, {torque, -100.5}} {
+		got, err := step.signal.Decode(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != step.value {
+			t.Errorf("Decode = %v, expected %v", got, step.value)
+		}
+	}
+}
+
+func TestCANSignalErrors(t *testing.T) {
+	payload := make([]byte, 8)
+
+	invalid := []CANSignal{
+		{StartBit: 0, Length: 0, Scale: 1},
+		{StartBit: 0, Length: 65, Scale: 1},
+		{StartBit: 64, Length: 1, Scale: 1},
+		{StartBit: 60, Length: 8, Order: CANIntel, Scale: 1},
+		{StartBit: 56, Length: 9, Order: CANMotorola, Scale: 1},
+		{StartBit: 0, Length: 8, Order: CANByteOrder(7), Scale: 1},
+	}
+	for _, s := range invalid {
+		if _, err := s.DecodeRaw(payload); err == nil {
+			t.Errorf("%+v: expected error", s)
+		}
+	}
+
+	s := CANSignal{StartBit: 0, Length: 8, Scale: 1}
+	for _, v := range []float64{-1, 256, math.NaN()} {
+		if err := s.Encode(payload, v); err == nil {
+			t.Errorf("Encode(%v): expected error", v)
+		}
+	}
+	if _, err := (CANSignal{Length: 8}).Decode(payload); err == nil {
+		t.Error("expected error for zero scale")
+	}
+}
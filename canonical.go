@@ -0,0 +1,43 @@
+package bytecast
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// IsCanonical reports whether data is the unique canonical encoding of a value of type typ,
+// i.e. decoding it and encoding the result again yields exactly the same bytes.
+// Must be checked before hashing or signing received payloads: non-canonical inputs
+// (bool byte other than 0x00/0x01, garbage in string padding, non-minimal big integers,
+// unsorted maps, bytes after absent pointer field, ...) decode to the same value as canonical ones.
+//
+// typ is reflect.Type or any value of the type (pointer to struct stands for the struct type,
+// except *big.Int).
+// Error is returned if data can not be decoded at all.
+func IsCanonical(data []byte, typ any) (bool, error) {
+	t, ok := typ.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(typ)
+		if t != nil && t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct && t != reflect.TypeFor[*big.Int]() {
+			t = t.Elem()
+		}
+	}
+
+	if t == nil {
+		return false, fmt.Errorf("IsCanonical requires type, got nil")
+	}
+
+	target := reflect.New(t)
+	if err := Unmarshal(data, target.Interface()); err != nil {
+		return false, err
+	}
+
+	encoded, err := Marshal(target.Elem().Interface())
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(encoded, data), nil
+}
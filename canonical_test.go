@@ -0,0 +1,85 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestIsCanonical(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		typ  any
+		want bool
+	}{
+		{"bool true", "01", false, true},
+		{"bool 0x02", "02", false, false},
+		{"int32", "fffffffe", int32(0), true},
+		{"big.Int minimal", "0101", (*big.Int)(nil), true},
+		{"sorted map", "02" + "0161" + "0131" + "0162" + "0132", map[string]string{}, true},
+		{"pointer absent", "01" + "00" + "00000000" + "00" + "00" + "0000", &optionalTestRecord{}, true},
+		{"garbage after absent pointer", "01" + "00" + "00000005" + "00" + "00" + "0000", &optionalTestRecord{}, false},
+		{"bool field 0xff", "ff", reflect.TypeOf(struct{ B bool }{}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tt.data)
+
+			got, err := IsCanonical(data, tt.typ)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %v got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIsCanonicalString(t *testing.T) {
+	s, _ := StringTo256Bytes("abc")
+	if ok, err := IsCanonical(s[:], ""); err != nil || !ok {
+		t.Fatalf("expected canonical string, got %v (%v)", ok, err)
+	}
+
+	s[10] = 0xaa // garbage in padding
+	if ok, err := IsCanonical(s[:], ""); err != nil || ok {
+		t.Fatalf("expected non-canonical string, got %v (%v)", ok, err)
+	}
+}
+
+func TestIsCanonicalErrors(t *testing.T) {
+	if _, err := IsCanonical([]byte{1}, nil); err == nil {
+		t.Fatal("expected error for nil type")
+	}
+	if _, err := IsCanonical([]byte{1, 2}, int32(0)); err == nil {
+		t.Fatal("expected error for undecodable data")
+	}
+	// BigIntFromMinimalBytes already rejects non-minimal magnitude
+	if _, err := IsCanonical([]byte{1, 0, 1}, (*big.Int)(nil)); err == nil {
+		t.Fatal("expected error for non-minimal big integer")
+	}
+	if _, err := IsCanonical([]byte{1}, 1.5); err == nil {
+		t.Fatal("expected error for unsupported type")
+	}
+}
+
+func TestMarshalCollections(t *testing.T) {
+	for _, v := range []any{[]string{"a", "bc"}, map[string]string{"k": "v"}} {
+		data, err := Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		target := reflect.New(reflect.TypeOf(v))
+		if err := Unmarshal(data, target.Interface()); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(target.Elem().Interface(), v) {
+			t.Fatalf("expected %v got %v", v, target.Elem().Interface())
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package bytecast
+
+import "fmt"
+
+// COBSEncode
+//
+//	Encodes data with Consistent Overhead Byte Stuffing, so result contains no 0x00 bytes
+//	and 0x00 can be used as frame delimiter on serial links. Overhead is at most 1 byte per 254 bytes of data.
+//
+//	Delimiter itself is NOT appended, typical frame is:
+//
+//	frame := append(COBSEncode(record), 0x00)
+func COBSEncode(data []byte) []byte {
+	out := make([]byte, 1, len(data)+len(data)/254+2)
+
+	codeIndex := 0 // where code byte of current block goes
+	code := byte(1)
+
+	for i, b := range data {
+		if b == 0x00 {
+			out[codeIndex] = code
+			codeIndex = len(out)
+			out = append(out, 0)
+			code = 1
+			continue
+		}
+
+		out = append(out, b)
+		code++
+
+		// block of 254 non-zero bytes is complete, next block (if any) starts without implicit zero
+		if code == 0xFF && i < len(data)-1 {
+			out[codeIndex] = code
+			codeIndex = len(out)
+			out = append(out, 0)
+			code = 1
+		}
+	}
+
+	out[codeIndex] = code
+
+	return out
+}
+
+// COBSDecode
+//
+//	Decodes COBS-encoded data (without trailing 0x00 delimiter) back to original bytes.
+func COBSDecode(encoded []byte) ([]byte, error) {
+	if len(encoded) == 0 {
+		return nil, fmt.Errorf("empty COBS input, expected at least 1 code byte")
+	}
+
+	out := make([]byte, 0, len(encoded))
+
+	for i := 0; i < len(encoded); {
+		code := int(encoded[i])
+		if code == 0 {
+			return nil, fmt.Errorf("unexpected 0x00 byte at offset %d in COBS input", i)
+		}
+		i++
+
+		end := i + code - 1
+		if end > len(encoded) {
+			return nil, fmt.Errorf("truncated COBS block at offset %d, need %d bytes, got %d", i-1, code-1, len(encoded)-i)
+		}
+
+		for j := i; j < end; j++ {
+			if encoded[j] == 0x00 {
+				return nil, fmt.Errorf("unexpected 0x00 byte at offset %d in COBS input", j)
+			}
+		}
+
+		out = append(out, encoded[i:end]...)
+		i = end
+
+		// every block except 254-byte ones and the last one is followed by zero
+		if code < 0xFF && i < len(encoded) {
+			out = append(out, 0x00)
+		}
+	}
+
+	return out, nil
+}
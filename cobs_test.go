@@ -0,0 +1,80 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func seq(from, to int) string {
+	var sb strings.Builder
+	for i := from; i <= to; i++ {
+		fmt.Fprintf(&sb, "%02x", i)
+	}
+	return sb.String()
+}
+
+func TestCOBSEncode(t *testing.T) {
+	// examples from the original COBS paper / Wikipedia
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"", "01"},
+		{"00", "0101"},
+		{"0000", "010101"},
+		{"001100", "01021101"},
+		{"11220033", "03112202 33"},
+		{"11223344", "0511223344"},
+		{"11000000", "02110101 01"},
+		{seq(1, 254), "ff" + seq(1, 254)},
+		{seq(0, 254), "01ff" + seq(1, 254)},
+		{seq(1, 255), "ff" + seq(1, 254) + "02ff"},
+		{seq(2, 255) + "00", "ff" + seq(2, 255) + "0101"},
+		{seq(3, 255) + "0001", "fe" + seq(3, 255) + "0201"},
+	}
+
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.data)
+		want := strings.ReplaceAll(tt.want, " ", "")
+
+		encoded := COBSEncode(data)
+		if got := fmt.Sprintf("%x", encoded); got != want {
+			t.Errorf("COBSEncode(%s) = %s; want %s", tt.data, got, want)
+			continue
+		}
+
+		if bytes.IndexByte(encoded, 0x00) >= 0 {
+			t.Errorf("COBSEncode(%s) contains zero byte", tt.data)
+		}
+
+		decoded, err := COBSDecode(encoded)
+		if err != nil {
+			t.Errorf("COBSDecode(%x) returned error: %v", encoded, err)
+			continue
+		}
+
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("COBSDecode(%x) = %x; want %x", encoded, decoded, data)
+		}
+	}
+}
+
+func TestCOBSDecodeErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"00",
+		"0311",     // truncated block
+		"03110022", // zero inside block
+		"0211" + "00",
+	}
+
+	for _, c := range cases {
+		b, _ := hex.DecodeString(c)
+		if _, err := COBSDecode(b); err == nil {
+			t.Errorf("COBSDecode(%s): expected error", c)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package bytecast
+
+import (
+	"crypto/subtle"
+	"fmt"
+)
+
+// EqualConstantTime reports whether a and b are equal, taking time independent of their content,
+// so encoded secrets and MACs can be compared without timing leaks.
+// Length is not secret: slices of different length return false immediately.
+func EqualConstantTime(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// IntXXFromBytesConstantTime
+//
+//	Constant-time variant of IntXXFromBytes which also validates that input is canonical:
+//	all bytes and bits above xx-bit value must be copies of its sign bit (see IntXXToBytesAndExpandWidth).
+//
+//	Execution time depends only on len(bytes) and xx, not on content. The only data-dependent outcome
+//	is whether error is returned, i.e. whether input is canonical.
+func IntXXFromBytesConstantTime(bytes []byte, xx int) (int64, error) {
+	u, padding, err := loadXXConstantTime(bytes, xx)
+	if err != nil {
+		return 0, err
+	}
+
+	shift := uint(64 - xx)
+	v := int64(u<<shift) >> shift // branch-free sign extension
+	sign := uint64(v >> 63)       // all ones for negative, zero for positive
+
+	neededBits := uint((xx + 7) / 8 * 8)
+	invalid := (u ^ uint64(v)) & (^uint64(0) >> (64 - neededBits))
+
+	var acc byte
+	for _, b := range padding {
+		acc |= b ^ byte(sign)
+	}
+
+	if subtle.ConstantTimeEq(int32(uint32(invalid|invalid>>32)|uint32(acc)), 0) != 1 {
+		return 0, fmt.Errorf("input is not canonical sign-extended int%d", xx)
+	}
+
+	return v, nil
+}
+
+// UintXXFromBytesConstantTime
+//
+//	Constant-time variant of UintXXFromBytes which also validates that input is canonical:
+//	all bytes and bits above xx-bit value must be zeros.
+//
+//	Execution time depends only on len(bytes) and xx, not on content.
+func UintXXFromBytesConstantTime(bytes []byte, xx int) (uint64, error) {
+	u, padding, err := loadXXConstantTime(bytes, xx)
+	if err != nil {
+		return 0, err
+	}
+
+	invalid := u >> uint(xx-1) >> 1 // two shifts, because shift by 64 is not allowed for xx == 64
+
+	var acc byte
+	for _, b := range padding {
+		acc |= b
+	}
+
+	if subtle.ConstantTimeEq(int32(uint32(invalid|invalid>>32)|uint32(acc)), 0) != 1 {
+		return 0, fmt.Errorf("input is not canonical zero-extended uint%d", xx)
+	}
+
+	return u, nil
+}
+
+// BoolFrom1ByteConstantTime works as BoolFrom1Byte without data-dependent branches.
+func BoolFrom1ByteConstantTime(bytesVal [1]byte) bool {
+	return subtle.ConstantTimeByteEq(bytesVal[0], 0) == 0
+}
+
+// loadXXConstantTime reads last ceil(xx / 8) bytes as big-endian uint64 and returns leading padding bytes.
+func loadXXConstantTime(bytes []byte, xx int) (uint64, []byte, error) {
+	if xx <= 0 || xx > 64 {
+		return 0, nil, fmt.Errorf("unsupported bit size %d, must be 1..64", xx)
+	}
+
+	neededBytesNum := (xx + 7) / 8
+
+	if len(bytes) < neededBytesNum {
+		return 0, nil, fmt.Errorf(
+			"expected at least %d bytes to interpret as %d-bit value, but got only %d bytes",
+			neededBytesNum, xx, len(bytes),
+		)
+	}
+
+	start := len(bytes) - neededBytesNum
+
+	var u uint64
+	for _, b := range bytes[start:] {
+		u = u<<8 | uint64(b)
+	}
+
+	return u, bytes[:start], nil
+}
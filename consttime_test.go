@@ -0,0 +1,110 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestEqualConstantTime(t *testing.T) {
+	if !EqualConstantTime([]byte{1, 2, 3}, []byte{1, 2, 3}) {
+		t.Error("expected equal")
+	}
+	if EqualConstantTime([]byte{1, 2, 3}, []byte{1, 2, 4}) {
+		t.Error("expected not equal")
+	}
+	if EqualConstantTime([]byte{1, 2}, []byte{1, 2, 3}) {
+		t.Error("expected not equal for different lengths")
+	}
+	if !EqualConstantTime(nil, []byte{}) {
+		t.Error("expected empty inputs to be equal")
+	}
+}
+
+func TestIntXXFromBytesConstantTime(t *testing.T) {
+	tests := []struct {
+		inputHex  string
+		bits      int
+		want      int64
+		expectErr bool
+	}{
+		{"0000000001", 24, 1, false},
+		{"ffffffffff", 24, -1, false},
+		{"fffffd0ba2", 24, -193630, false},
+		{"7fffff", 24, 8388607, false},
+		{"800000", 24, -8388608, false},
+		{"f800", 12, -2048, false},
+		{"07ff", 12, 2047, false},
+		{"ffffffffffffffff", 64, -1, false},
+
+		{"00ffffff", 24, 0, true}, // negative int24 with zero padding
+		{"ff000001", 24, 0, true}, // positive int24 with 0xff padding
+		{"0800", 12, 0, true},     // high nibble is not sign extension
+		{"ff", 24, 0, true},       // too short
+	}
+
+	for _, tt := range tests {
+		in, _ := hex.DecodeString(tt.inputHex)
+
+		got, err := IntXXFromBytesConstantTime(in, tt.bits)
+		if tt.expectErr {
+			if err == nil {
+				t.Errorf("IntXXFromBytesConstantTime(%s, %d): expected error", tt.inputHex, tt.bits)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("IntXXFromBytesConstantTime(%s, %d) returned error: %v", tt.inputHex, tt.bits, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("IntXXFromBytesConstantTime(%s, %d) = %d; want %d", tt.inputHex, tt.bits, got, tt.want)
+		}
+
+		// must agree with regular decoder on canonical input
+		if regular, _ := IntXXFromBytes(in, tt.bits); regular != got {
+			t.Errorf("IntXXFromBytes(%s, %d) = %d, constant-time variant = %d", tt.inputHex, tt.bits, regular, got)
+		}
+	}
+}
+
+func TestUintXXFromBytesConstantTime(t *testing.T) {
+	tests := []struct {
+		inputHex  string
+		bits      int
+		want      uint64
+		expectErr bool
+	}{
+		{"0000ffffff", 24, 16777215, false},
+		{"0fff", 12, 4095, false},
+		{"ffffffffffffffff", 64, 18446744073709551615, false},
+
+		{"01ffffff", 24, 0, true},
+		{"1fff", 12, 0, true},
+	}
+
+	for _, tt := range tests {
+		in, _ := hex.DecodeString(tt.inputHex)
+
+		got, err := UintXXFromBytesConstantTime(in, tt.bits)
+		if tt.expectErr {
+			if err == nil {
+				t.Errorf("UintXXFromBytesConstantTime(%s, %d): expected error", tt.inputHex, tt.bits)
+			}
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("UintXXFromBytesConstantTime(%s, %d) = %d, %v; want %d", tt.inputHex, tt.bits, got, err, tt.want)
+		}
+	}
+}
+
+func TestBoolFrom1ByteConstantTime(t *testing.T) {
+	for b := 0; b <= 0xFF; b++ {
+		if got, want := BoolFrom1ByteConstantTime([1]byte{byte(b)}), BoolFrom1Byte([1]byte{byte(b)}); got != want {
+			t.Fatalf("byte 0x%02X: expected %v, got %v", b, want, got)
+		}
+	}
+}
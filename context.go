@@ -0,0 +1,59 @@
+package bytecast
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Context variants of streaming operations (ReadFrameContext, FrameReader.ReadFrameContext,
+// Encoder.FlushContext, ...) check ctx before doing any I/O. If the underlying stream has
+// read / write deadlines (net.Conn, os.File of a pipe, ...), cancellation of ctx also interrupts
+// the blocked call by moving the deadline to the past; the deadline is left there, since the stream
+// position is undefined after an interrupted call anyway. Streams without deadlines are only
+// checked between calls. Interrupted operation returns ctx.Err().
+
+type readDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+type writeDeadliner interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// withReadContext runs read on r, interrupting it by cancellation of ctx (see above).
+func withReadContext(ctx context.Context, r io.Reader, read func() error) error {
+	var interrupt func()
+	if d, ok := r.(readDeadliner); ok {
+		interrupt = func() { _ = d.SetReadDeadline(time.Unix(1, 0)) }
+	}
+	return withContext(ctx, interrupt, read)
+}
+
+// withWriteContext runs write on w, interrupting it by cancellation of ctx (see above).
+func withWriteContext(ctx context.Context, w io.Writer, write func() error) error {
+	var interrupt func()
+	if d, ok := w.(writeDeadliner); ok {
+		interrupt = func() { _ = d.SetWriteDeadline(time.Unix(1, 0)) }
+	}
+	return withContext(ctx, interrupt, write)
+}
+
+func withContext(ctx context.Context, interrupt func(), op func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if interrupt != nil {
+		stop := context.AfterFunc(ctx, interrupt)
+		defer stop()
+	}
+
+	if err := op(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package bytecast
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestReadFrameContextInterruptsBlockedRead(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	// nothing is ever written, so only cancellation can end the read
+	_, err := NewFrameReader(client, 1024).ReadFrameContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestReadFrameContext(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go func() {
+		_ = WriteFrameContext(context.Background(), server, []byte("hello"))
+	}()
+
+	payload, err := ReadFrameContext(context.Background(), client, 1024)
+	if err != nil || string(payload) != "hello" {
+		t.Fatalf("expected hello, got %q (%v)", payload, err)
+	}
+}
+
+func TestContextVariantsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var frame bytes.Buffer
+	if err := WriteFrame(&frame, []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	data := frame.Bytes()
+
+	framer, err := NewFramer(0x7e, 0x7d, 0x20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr, err := NewRecordReader[uint8](bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := map[string]func() error{
+		"ReadFrameContext":  func() error { _, err := ReadFrameContext(ctx, bytes.NewReader(data), 16); return err },
+		"WriteFrameContext": func() error { return WriteFrameContext(ctx, &bytes.Buffer{}, []byte{1}) },
+		"FrameReader":       func() error { _, err := NewFrameReader(bytes.NewReader(data), 16).ReadFrameContext(ctx); return err },
+		"DelimitedFrameReader": func() error {
+			_, err := framer.NewReader(bytes.NewReader(framer.Encode([]byte{1}))).ReadFrameContext(ctx)
+			return err
+		},
+		"SLIPReader": func() error {
+			_, err := NewSLIPReader(bytes.NewReader(SLIPEncode([]byte{1}))).ReadFrameContext(ctx)
+			return err
+		},
+		"RecordReader": func() error { _, err := rr.NextContext(ctx); return err },
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got %v", name, err)
+		}
+	}
+
+	// cancelled flush keeps the buffer
+	var out bytes.Buffer
+	enc := NewEncoder(&out)
+	enc.PutUint16(0x0102)
+	if err := enc.FlushContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if err := enc.FlushContext(context.Background()); err != nil || !bytes.Equal(out.Bytes(), []byte{1, 2}) {
+		t.Fatalf("expected buffered bytes written after cancelled flush, got % x (%v)", out.Bytes(), err)
+	}
+}
@@ -0,0 +1,99 @@
+package bytecast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CountryCode is an ISO 3166-1 alpha-2 country code stored as 2 ASCII bytes ("UA", "US").
+type CountryCode [2]byte
+
+// iso3166 lists officially assigned ISO 3166-1 alpha-2 codes.
+const iso3166 = "" +
+	"AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS " +
+	"BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE " +
+	"EG EH ER ES ET FI FJ FK FM FO FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM " +
+	"HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC " +
+	"LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ NA " +
+	"NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW " +
+	"SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO " +
+	"TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW"
+
+// iso3166Set holds packed 10-bit values of assigned codes.
+var iso3166Set = func() map[uint16]bool {
+	m := make(map[uint16]bool)
+	for _, code := range strings.Fields(iso3166) {
+		m[packCountry(code[0], code[1])] = true
+	}
+	return m
+}()
+
+// ParseCountryCode parses assigned ISO 3166-1 alpha-2 code, case-insensitive.
+func ParseCountryCode(s string) (CountryCode, error) {
+	if len(s) != 2 {
+		return CountryCode{}, fmt.Errorf("expected 2 letters in country code, but got %q", s)
+	}
+	c := CountryCode{upperASCII(s[0]), upperASCII(s[1])}
+	if !c.Valid() {
+		return CountryCode{}, fmt.Errorf("unknown ISO 3166-1 country code %q", s)
+	}
+	return c, nil
+}
+
+// CountryCodeFromBytes converts 2-byte upper-case field to CountryCode, validating it against assigned codes.
+func CountryCodeFromBytes(b []byte) (CountryCode, error) {
+	if len(b) != 2 {
+		return CountryCode{}, fmt.Errorf("expected exactly 2 bytes for country code, but got %d bytes", len(b))
+	}
+	c := CountryCode(b)
+	if !c.Valid() {
+		return CountryCode{}, fmt.Errorf("unknown ISO 3166-1 country code %q", b)
+	}
+	return c, nil
+}
+
+// CountryCodeFromPacked
+//
+//	Decodes 10-bit packed code written by CountryCode.Packed: (first-'A')*26 + (second-'A').
+//	Bits above 10 must be zero.
+func CountryCodeFromPacked(v uint16) (CountryCode, error) {
+	if v >= 26*26 {
+		return CountryCode{}, fmt.Errorf("packed country code %d is out of range, max %d", v, 26*26-1)
+	}
+	c := CountryCode{byte('A' + v/26), byte('A' + v%26)}
+	if !iso3166Set[v] {
+		return CountryCode{}, fmt.Errorf("unknown ISO 3166-1 country code %q", c[:])
+	}
+	return c, nil
+}
+
+// String returns alpha-2 code.
+func (c CountryCode) String() string {
+	return string(c[:])
+}
+
+// Valid reports whether c is an assigned ISO 3166-1 alpha-2 code.
+func (c CountryCode) Valid() bool {
+	if c[0] < 'A' || c[0] > 'Z' || c[1] < 'A' || c[1] > 'Z' {
+		return false
+	}
+	return iso3166Set[packCountry(c[0], c[1])]
+}
+
+// Packed returns code packed into 10 bits for bit-level layouts (see InsertBits), e.g. UA → 520.
+// Result is meaningless for invalid codes, check Valid first.
+func (c CountryCode) Packed() uint16 {
+	return packCountry(c[0], c[1])
+}
+
+func packCountry(a byte, b byte) uint16 {
+	return uint16(a-'A')*26 + uint16(b-'A')
+}
+
+// upperASCII upper-cases ASCII letter c, other bytes (including parts of multi-byte runes) are returned as is.
+func upperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
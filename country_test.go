@@ -0,0 +1,65 @@
+package bytecast
+
+import "testing"
+
+func TestCountryCode(t *testing.T) {
+	cases := []struct {
+		code   string
+		packed uint16
+	}{
+		{"AD", 3},
+		{"UA", 520},
+		{"US", 538},
+		{"ZW", 672},
+	}
+	for _, c := range cases {
+		cc, err := ParseCountryCode(c.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cc.String() != c.code || cc.Packed() != c.packed {
+			t.Errorf("%s: got %s/%d, expected %s/%d", c.code, cc, cc.Packed(), c.code, c.packed)
+		}
+		if got, err := CountryCodeFromBytes(cc[:]); err != nil || got != cc {
+			t.Errorf("CountryCodeFromBytes(%s) = %s (%v)", c.code, got, err)
+		}
+		if got, err := CountryCodeFromPacked(c.packed); err != nil || got != cc {
+			t.Errorf("CountryCodeFromPacked(%d) = %s (%v), expected %s", c.packed, got, err, c.code)
+		}
+
+		word, err := InsertBits(0, 3, 10, uint64(cc.Packed()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, _ := ExtractBits(word, 3, 10); v != uint64(c.packed) {
+			t.Errorf("%s: bitfield round trip gave %d", c.code, v)
+		}
+	}
+
+	if cc, err := ParseCountryCode("gb"); err != nil || cc.String() != "GB" {
+		t.Errorf("expected lowercase code to parse as GB, got %s (%v)", cc, err)
+	}
+	if len(iso3166Set) != 249 {
+		t.Errorf("expected 249 assigned codes, got %d", len(iso3166Set))
+	}
+}
+
+func TestCountryCodeErrors(t *testing.T) {
+	for _, s := range []string{"", "U", "USA", "XX", "A1", "UK", "ı", "ſ"} {
+		if _, err := ParseCountryCode(s); err == nil {
+			t.Errorf("ParseCountryCode(%q): expected error", s)
+		}
+	}
+	if _, err := CountryCodeFromBytes([]byte("ua")); err == nil {
+		t.Error("expected error for lowercase field")
+	}
+	if _, err := CountryCodeFromPacked(676); err == nil {
+		t.Error("expected error for out of range packed value")
+	}
+	if _, err := CountryCodeFromPacked(0); err == nil {
+		t.Error("expected error for unassigned code AA")
+	}
+	if (CountryCode{}).Valid() {
+		t.Error("expected zero country code to be invalid")
+	}
+}
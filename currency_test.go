@@ -0,0 +1,65 @@
+package bytecast
+
+import "testing"
+
+func TestCurrencyCode(t *testing.T) {
+	cases := []struct {
+		code    string
+		numeric uint16
+	}{
+		{"USD", 840},
+		{"EUR", 978},
+		{"UAH", 980},
+		{"JPY", 392},
+		{"ALL", 8},
+		{"XCG", 532},
+	}
+	for _, c := range cases {
+		cc, err := ParseCurrencyCode(c.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cc.String() != c.code || cc.Numeric() != c.numeric {
+			t.Errorf("%s: got %s/%03d, expected %s/%03d", c.code, cc, cc.Numeric(), c.code, c.numeric)
+		}
+
+		if got, err := CurrencyCodeFromBytes(cc[:]); err != nil || got != cc {
+			t.Errorf("CurrencyCodeFromBytes(%s) = %s (%v)", c.code, got, err)
+		}
+
+		nb, err := cc.NumericBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := CurrencyCodeFromNumericBytes(nb); err != nil || got != cc {
+			t.Errorf("CurrencyCodeFromNumericBytes(%x) = %s (%v), expected %s", nb, got, err, c.code)
+		}
+	}
+
+	if cc, err := ParseCurrencyCode("gbp"); err != nil || cc.String() != "GBP" {
+		t.Errorf("expected lowercase code to parse as GBP, got %s (%v)", cc, err)
+	}
+}
+
+func TestCurrencyCodeErrors(t *testing.T) {
+	for _, s := range []string{"", "US", "USDD", "ABC", "HRK", "uſd"} {
+		if _, err := ParseCurrencyCode(s); err == nil {
+			t.Errorf("ParseCurrencyCode(%q): expected error", s)
+		}
+	}
+	if _, err := CurrencyCodeFromBytes([]byte("usd")); err == nil {
+		t.Error("expected error for lowercase field")
+	}
+	if _, err := CurrencyCodeFromNumeric(1); err == nil {
+		t.Error("expected error for unknown numeric code")
+	}
+	if (CurrencyCode{'A', 'B', 'C'}).Valid() {
+		t.Error("expected ABC to be invalid")
+	}
+	if _, err := (CurrencyCode{}).NumericBytes(); err == nil {
+		t.Error("expected error for zero currency code")
+	}
+	if len(iso4217Numeric) != len(iso4217) {
+		t.Errorf("numeric codes are not unique: %d numeric for %d alphabetic", len(iso4217Numeric), len(iso4217))
+	}
+}
@@ -0,0 +1,130 @@
+package bytecast
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DecimalToBytes
+//
+//	Takes decimal string (e.g. "123.45", "-0.5", "+10") and stores it as exact scaled integer
+//	(value * 10^scale) in two's complement representation, sign-extended to specified width.
+//	Value never passes through float64, so it is safe for monetary amounts:
+//
+//	DecimalToBytes("123.45", 2, 32) → 0x00...3039 (12345)
+//
+//	Fractional digits beyond scale are accepted only if they are zeros ("1.230" with scale 2),
+//	any other loss of precision is reported as error.
+func DecimalToBytes(decimal string, scale int, width int) ([]byte, error) {
+	if scale < 0 {
+		return nil, fmt.Errorf("unsupported scale %d, must be >= 0", scale)
+	}
+
+	coefficient, exponent, err := parseDecimal(decimal)
+	if err != nil {
+		return nil, err
+	}
+
+	scaled, err := rescaleDecimal(coefficient, exponent, -scale)
+	if err != nil {
+		return nil, fmt.Errorf("decimal %q cannot be stored with scale %d: %w", decimal, scale, err)
+	}
+
+	// BigIntToBytesAndExpandWidth accepts unsigned values up to full width,
+	// but here the highest bit is reserved for sign: allowed range is [-2^(8*width-1), 2^(8*width-1)-1]
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(max(width*8-1, 0)))
+	if scaled.Cmp(limit) >= 0 || scaled.Cmp(limit.Neg(limit)) < 0 {
+		return nil, fmt.Errorf("decimal %q with scale %d out of range of %d bytes", decimal, scale, width)
+	}
+
+	out, err := BigIntToBytesAndExpandWidth(scaled, width)
+	if err != nil {
+		return nil, fmt.Errorf("decimal %q cannot be stored with scale %d: %w", decimal, scale, err)
+	}
+
+	return out, nil
+}
+
+// DecimalFromBytes
+//
+//	Inverse of DecimalToBytes: interprets bytes as signed scaled integer and formats it
+//	as decimal string with exactly "scale" fractional digits (12345 with scale 2 → "123.45").
+func DecimalFromBytes(bytes []byte, scale int) (string, error) {
+	if scale < 0 {
+		return "", fmt.Errorf("unsupported scale %d, must be >= 0", scale)
+	}
+
+	return formatDecimal(BigIntFromBytes(bytes), scale), nil
+}
+
+// parseDecimal splits plain decimal string into integer coefficient and base-10 exponent,
+// so "-123.45" → (-12345, -2).
+func parseDecimal(decimal string) (*big.Int, int, error) {
+	s := decimal
+	negative := false
+
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	digits := intPart + fracPart
+
+	if digits == "" {
+		return nil, 0, fmt.Errorf("invalid decimal %q", decimal)
+	}
+
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return nil, 0, fmt.Errorf("invalid decimal %q", decimal)
+		}
+	}
+
+	coefficient, _ := new(big.Int).SetString(digits, 10)
+	if negative {
+		coefficient.Neg(coefficient)
+	}
+
+	return coefficient, -len(fracPart), nil
+}
+
+// rescaleDecimal returns coefficient * 10^(exponent - targetExponent),
+// failing if non-zero digits would be dropped.
+func rescaleDecimal(coefficient *big.Int, exponent int, targetExponent int) (*big.Int, error) {
+	diff := exponent - targetExponent
+
+	if diff >= 0 {
+		multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(diff)), nil)
+		return new(big.Int).Mul(coefficient, multiplier), nil
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-diff)), nil)
+	quotient, remainder := new(big.Int).QuoRem(coefficient, divisor, new(big.Int))
+	if remainder.Sign() != 0 {
+		return nil, fmt.Errorf("too many significant fractional digits")
+	}
+
+	return quotient, nil
+}
+
+// formatDecimal formats scaled integer as plain decimal string with "scale" fractional digits.
+func formatDecimal(scaled *big.Int, scale int) string {
+	digits := new(big.Int).Abs(scaled).String()
+
+	sign := ""
+	if scaled.Sign() < 0 {
+		sign = "-"
+	}
+
+	if scale == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
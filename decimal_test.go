@@ -0,0 +1,129 @@
+package bytecast
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecimalToBytes(t *testing.T) {
+	tests := []struct {
+		decimal string
+		scale   int
+		want    string // hex representation of 8 bytes
+	}{
+		{"0", 2, "0000000000000000"},
+		{"123.45", 2, "0000000000003039"},
+		{"-123.45", 2, "ffffffffffffcfc7"},
+		{"+1", 2, "0000000000000064"},
+		{"1.230", 2, "000000000000007b"},
+		{".5", 2, "0000000000000032"},
+		{"7.", 0, "0000000000000007"},
+		{"-0.01", 2, "ffffffffffffffff"},
+		{"92233720368547758.07", 2, "7fffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		out, err := DecimalToBytes(tt.decimal, tt.scale, 8)
+		if err != nil {
+			t.Errorf("DecimalToBytes(%q, %d) returned error: %v", tt.decimal, tt.scale, err)
+			continue
+		}
+
+		got := fmt.Sprintf("%x", out)
+		if got != tt.want {
+			t.Errorf("DecimalToBytes(%q, %d) = %s; want %s", tt.decimal, tt.scale, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalToBytesErrors(t *testing.T) {
+	tests := []struct {
+		decimal string
+		scale   int
+	}{
+		{"", 2},
+		{"-", 2},
+		{".", 2},
+		{"1.2.3", 2},
+		{"1e5", 2},
+		{"12a", 2},
+		{"1.234", 2},                // precision loss
+		{"92233720368547758.08", 2}, // does not fit in 8 bytes
+		{"1", -1},
+	}
+
+	for _, tt := range tests {
+		_, err := DecimalToBytes(tt.decimal, tt.scale, 8)
+		if err == nil {
+			t.Errorf("DecimalToBytes(%q, %d): expected error", tt.decimal, tt.scale)
+		}
+	}
+}
+
+func TestDecimalToBytesWidth(t *testing.T) {
+	tests := []struct {
+		decimal string
+		scale   int
+		width   int
+		want    string // hex, empty if error is expected
+	}{
+		{"-1.28", 2, 1, "80"},
+		{"1.27", 2, 1, "7f"},
+		{"1.28", 2, 1, ""},
+		{"-1.29", 2, 1, ""},
+		{"-2.00", 2, 1, ""},
+		{"-327.68", 2, 2, "8000"},
+		{"-327.69", 2, 2, ""},
+		{"-655.00", 2, 2, ""},
+		{"-0.01", 2, 2, "ffff"},
+	}
+
+	for _, tt := range tests {
+		out, err := DecimalToBytes(tt.decimal, tt.scale, tt.width)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("DecimalToBytes(%q, %d, %d): expected error, got %x", tt.decimal, tt.scale, tt.width, out)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("DecimalToBytes(%q, %d, %d) returned error: %v", tt.decimal, tt.scale, tt.width, err)
+			continue
+		}
+		if got := fmt.Sprintf("%x", out); got != tt.want || len(out) != tt.width {
+			t.Errorf("DecimalToBytes(%q, %d, %d) = %s; want %s", tt.decimal, tt.scale, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalRoundTrip(t *testing.T) {
+	tests := []struct {
+		decimal string
+		scale   int
+		want    string
+	}{
+		{"123.45", 2, "123.45"},
+		{"-123.45", 2, "-123.45"},
+		{"0", 2, "0.00"},
+		{"-0.05", 2, "-0.05"},
+		{"1.5", 4, "1.5000"},
+		{"-42", 0, "-42"},
+		{"123456789012345678901234567890.123456789", 9, "123456789012345678901234567890.123456789"},
+	}
+
+	for _, tt := range tests {
+		b, err := DecimalToBytes(tt.decimal, tt.scale, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := DecimalFromBytes(b, tt.scale)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("expected %s got %s", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package bytecast
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// IEEE 754-2008 decimal floating-point interchange formats (decimal64, decimal128)
+// in both significand encodings:
+//
+//	BID - binary integer decimal, significand stored as plain binary integer;
+//	DPD - densely packed decimal, significand stored as 10-bit declets (3 decimal digits each).
+//
+// Values are passed as decimal strings ("123.45", "-1.5E+3", "Infinity", "NaN") and encoded exactly:
+// if value does not fit into format precision without losing non-zero digits, error is returned
+// instead of rounding. Decoded values are formatted with to-scientific-string rules of
+// General Decimal Arithmetic, so the exponent (cohort) of the encoded value is preserved:
+// "1.50" stays "1.50", and 15 with exponent 2 is "1.5E+3".
+
+type decimalFloatFormat struct {
+	name      string
+	size      int // bytes
+	precision int // decimal digits
+	expBits   int // exponent continuation bits
+	bias      int
+}
+
+var (
+	decimal64Format  = decimalFloatFormat{name: "decimal64", size: 8, precision: 16, expBits: 8, bias: 398}
+	decimal128Format = decimalFloatFormat{name: "decimal128", size: 16, precision: 34, expBits: 12, bias: 6176}
+)
+
+type decimalFloatKind int
+
+const (
+	decimalFinite decimalFloatKind = iota
+	decimalInfinity
+	decimalQuietNaN
+	decimalSignalingNaN
+)
+
+type decimalFloat struct {
+	kind        decimalFloatKind
+	negative    bool
+	coefficient *big.Int
+	exponent    int
+}
+
+func Decimal64ToBytesBID(decimal string) ([8]byte, error) {
+	b, err := encodeDecimalFloat(decimal, decimal64Format, false)
+	if err != nil {
+		return [8]byte{}, err
+	}
+	return [8]byte(b), nil
+}
+
+func Decimal64FromBytesBID(byteValue [8]byte) string {
+	return decodeDecimalFloat(byteValue[:], decimal64Format, false)
+}
+
+func Decimal64ToBytesDPD(decimal string) ([8]byte, error) {
+	b, err := encodeDecimalFloat(decimal, decimal64Format, true)
+	if err != nil {
+		return [8]byte{}, err
+	}
+	return [8]byte(b), nil
+}
+
+func Decimal64FromBytesDPD(byteValue [8]byte) string {
+	return decodeDecimalFloat(byteValue[:], decimal64Format, true)
+}
+
+func Decimal128ToBytesBID(decimal string) ([16]byte, error) {
+	b, err := encodeDecimalFloat(decimal, decimal128Format, false)
+	if err != nil {
+		return [16]byte{}, err
+	}
+	return [16]byte(b), nil
+}
+
+func Decimal128FromBytesBID(byteValue [16]byte) string {
+	return decodeDecimalFloat(byteValue[:], decimal128Format, false)
+}
+
+func Decimal128ToBytesDPD(decimal string) ([16]byte, error) {
+	b, err := encodeDecimalFloat(decimal, decimal128Format, true)
+	if err != nil {
+		return [16]byte{}, err
+	}
+	return [16]byte(b), nil
+}
+
+func Decimal128FromBytesDPD(byteValue [16]byte) string {
+	return decodeDecimalFloat(byteValue[:], decimal128Format, true)
+}
+
+func encodeDecimalFloat(decimal string, format decimalFloatFormat, dpd bool) ([]byte, error) {
+	v, err := parseDecimalFloat(decimal)
+	if err != nil {
+		return nil, err
+	}
+
+	totalBits := format.size * 8
+	trailingBits := totalBits - 6 - format.expBits
+
+	word := new(big.Int)
+	if v.negative {
+		word.SetBit(word, totalBits-1, 1)
+	}
+
+	switch v.kind {
+	case decimalInfinity:
+		word.Or(word, new(big.Int).Lsh(big.NewInt(0b11110), uint(totalBits-6)))
+		return LeftPadBytes00(word.Bytes(), format.size), nil
+	case decimalQuietNaN, decimalSignalingNaN:
+		word.Or(word, new(big.Int).Lsh(big.NewInt(0b11111), uint(totalBits-6)))
+		if v.kind == decimalSignalingNaN {
+			word.SetBit(word, totalBits-7, 1)
+		}
+		return LeftPadBytes00(word.Bytes(), format.size), nil
+	}
+
+	coefficient, biasedExponent, err := fitDecimalFloat(v.coefficient, v.exponent, format)
+	if err != nil {
+		return nil, fmt.Errorf("decimal %q cannot be encoded as %s: %w", decimal, format.name, err)
+	}
+
+	exponent := big.NewInt(int64(biasedExponent))
+
+	if !dpd {
+		// coefficient fits into trailing bits + 3 bits of combination field → "short" form:
+		//   s | exponent (expBits+2) | coefficient (trailingBits+3)
+		// otherwise coefficient has implicit 100 prefix:
+		//   s | 11 | exponent (expBits+2) | coefficient (trailingBits+1)
+		if coefficient.BitLen() <= trailingBits+3 {
+			word.Or(word, exponent.Lsh(exponent, uint(trailingBits+3)))
+			word.Or(word, coefficient)
+		} else {
+			word.Or(word, new(big.Int).Lsh(big.NewInt(0b11), uint(totalBits-3)))
+			word.Or(word, exponent.Lsh(exponent, uint(trailingBits+1)))
+			for i := 0; i <= trailingBits; i++ {
+				word.SetBit(word, i, coefficient.Bit(i))
+			}
+		}
+		return LeftPadBytes00(word.Bytes(), format.size), nil
+	}
+
+	// DPD: leading digit goes to combination field, the rest - to declets
+	digits := fmt.Sprintf("%0*s", format.precision, coefficient.String())
+	leadingDigit := int64(digits[0] - '0')
+	exponentHigh := int64(biasedExponent >> format.expBits)
+
+	var combination int64
+	if leadingDigit < 8 {
+		combination = exponentHigh<<3 | leadingDigit
+	} else {
+		combination = 0b11000 | exponentHigh<<1 | leadingDigit&1
+	}
+
+	word.Or(word, new(big.Int).Lsh(big.NewInt(combination), uint(totalBits-6)))
+
+	exponentLow := int64(biasedExponent & (1<<format.expBits - 1))
+	word.Or(word, new(big.Int).Lsh(big.NewInt(exponentLow), uint(trailingBits)))
+
+	for i := 1; i < len(digits); i += 3 {
+		group, _ := strconv.Atoi(digits[i : i+3])
+		shift := trailingBits - (i+2)/3*10
+		word.Or(word, new(big.Int).Lsh(big.NewInt(int64(encodeDeclet(group))), uint(shift)))
+	}
+
+	return LeftPadBytes00(word.Bytes(), format.size), nil
+}
+
+func decodeDecimalFloat(bytes []byte, format decimalFloatFormat, dpd bool) string {
+	totalBits := format.size * 8
+	trailingBits := totalBits - 6 - format.expBits
+
+	word := new(big.Int).SetBytes(bytes)
+	negative := word.Bit(totalBits-1) == 1
+	combination := bitsOf(word, totalBits-6, 5)
+
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+
+	switch {
+	case combination == 0b11110:
+		return sign + "Infinity"
+	case combination == 0b11111 && word.Bit(totalBits-7) == 1:
+		return sign + "sNaN"
+	case combination == 0b11111:
+		return sign + "NaN"
+	}
+
+	var coefficient *big.Int
+	var biasedExponent int
+
+	if !dpd {
+		if combination>>3 != 0b11 {
+			biasedExponent = bitsOf(word, trailingBits+3, format.expBits+2)
+			coefficient = new(big.Int)
+			for i := 0; i < trailingBits+3; i++ {
+				coefficient.SetBit(coefficient, i, word.Bit(i))
+			}
+		} else {
+			biasedExponent = bitsOf(word, trailingBits+1, format.expBits+2)
+			coefficient = new(big.Int).Lsh(big.NewInt(0b100), uint(trailingBits+1))
+			for i := 0; i <= trailingBits; i++ {
+				coefficient.SetBit(coefficient, i, word.Bit(i))
+			}
+		}
+
+		// non-canonical significands (greater than maximum for precision) are treated as zero
+		maxCoefficient := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(format.precision)), nil)
+		if coefficient.Cmp(maxCoefficient) >= 0 {
+			coefficient.SetInt64(0)
+		}
+	} else {
+		var exponentHigh, leadingDigit int
+		if combination>>3 != 0b11 {
+			exponentHigh = combination >> 3
+			leadingDigit = combination & 0b111
+		} else {
+			exponentHigh = combination >> 1 & 0b11
+			leadingDigit = 8 | combination&1
+		}
+
+		biasedExponent = exponentHigh<<format.expBits | bitsOf(word, trailingBits, format.expBits)
+
+		var digits strings.Builder
+		digits.WriteByte(byte('0' + leadingDigit))
+		for shift := trailingBits - 10; shift >= 0; shift -= 10 {
+			fmt.Fprintf(&digits, "%03d", decodeDeclet(uint16(bitsOf(word, shift, 10))))
+		}
+
+		coefficient, _ = new(big.Int).SetString(digits.String(), 10)
+	}
+
+	return sign + formatDecimalFloat(coefficient, biasedExponent-format.bias)
+}
+
+// fitDecimalFloat adjusts coefficient and exponent to format precision and exponent range
+// without losing non-zero digits, returns coefficient and biased exponent.
+func fitDecimalFloat(coefficient *big.Int, exponent int, format decimalFloatFormat) (*big.Int, int, error) {
+	ten := big.NewInt(10)
+	maxBiased := 3<<format.expBits - 1
+	minExponent := -format.bias
+	maxExponent := maxBiased - format.bias
+
+	c := new(big.Int).Set(coefficient)
+	q := exponent
+	digitsCount := func() int { return len(c.String()) }
+
+	if c.Sign() == 0 {
+		q = min(max(q, minExponent), maxExponent)
+		return c, q + format.bias, nil
+	}
+
+	// too many digits: drop trailing zeros
+	for digitsCount() > format.precision || q < minExponent {
+		quotient, remainder := new(big.Int).QuoRem(c, ten, new(big.Int))
+		if remainder.Sign() != 0 {
+			break
+		}
+		c = quotient
+		q++
+	}
+
+	if digitsCount() > format.precision {
+		return nil, 0, fmt.Errorf("more than %d significant digits", format.precision)
+	}
+
+	if q < minExponent {
+		return nil, 0, fmt.Errorf("exponent underflow")
+	}
+
+	// too large exponent: append zeros to coefficient while precision allows
+	for q > maxExponent && digitsCount() < format.precision {
+		c.Mul(c, ten)
+		q--
+	}
+
+	if q > maxExponent {
+		return nil, 0, fmt.Errorf("exponent overflow")
+	}
+
+	return c, q + format.bias, nil
+}
+
+func parseDecimalFloat(decimal string) (decimalFloat, error) {
+	s := decimal
+	v := decimalFloat{}
+
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		v.negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	switch strings.ToLower(s) {
+	case "inf", "infinity":
+		v.kind = decimalInfinity
+		return v, nil
+	case "nan":
+		v.kind = decimalQuietNaN
+		return v, nil
+	case "snan":
+		v.kind = decimalSignalingNaN
+		return v, nil
+	}
+
+	mantissa, exponentPart, hasExponent := strings.Cut(strings.ToLower(s), "e")
+
+	exponent := 0
+	if hasExponent {
+		e, err := strconv.Atoi(exponentPart)
+		if err != nil {
+			return v, fmt.Errorf("invalid decimal %q", decimal)
+		}
+		exponent = e
+	}
+
+	if len(mantissa) > 0 && (mantissa[0] == '+' || mantissa[0] == '-') {
+		return v, fmt.Errorf("invalid decimal %q", decimal)
+	}
+
+	coefficient, mantissaExponent, err := parseDecimal(mantissa)
+	if err != nil {
+		return v, fmt.Errorf("invalid decimal %q", decimal)
+	}
+
+	v.coefficient = coefficient
+	v.exponent = exponent + mantissaExponent
+
+	return v, nil
+}
+
+// formatDecimalFloat implements to-scientific-string conversion of finite (non-negative) value.
+func formatDecimalFloat(coefficient *big.Int, exponent int) string {
+	digits := coefficient.String()
+	adjusted := exponent + len(digits) - 1
+
+	if exponent <= 0 && adjusted >= -6 {
+		return formatDecimal(coefficient, -exponent)
+	}
+
+	out := digits[:1]
+	if len(digits) > 1 {
+		out += "." + digits[1:]
+	}
+
+	if adjusted >= 0 {
+		return out + "E+" + strconv.Itoa(adjusted)
+	}
+	return out + "E" + strconv.Itoa(adjusted)
+}
+
+// encodeDeclet packs three decimal digits (0..999) into 10-bit DPD declet.
+func encodeDeclet(value int) uint16 {
+	d2, d1, d0 := uint16(value/100), uint16(value/10%10), uint16(value%10)
+
+	a, b, c, d := d2>>3&1, d2>>2&1, d2>>1&1, d2&1
+	e, f, g, h := d1>>3&1, d1>>2&1, d1>>1&1, d1&1
+	i, j, k, m := d0>>3&1, d0>>2&1, d0>>1&1, d0&1
+
+	pack := func(bits ...uint16) uint16 {
+		var out uint16
+		for _, bit := range bits {
+			out = out<<1 | bit
+		}
+		return out
+	}
+
+	switch a<<2 | e<<1 | i {
+	case 0b000:
+		return pack(b, c, d, f, g, h, 0, j, k, m)
+	case 0b001:
+		return pack(b, c, d, f, g, h, 1, 0, 0, m)
+	case 0b010:
+		return pack(b, c, d, j, k, h, 1, 0, 1, m)
+	case 0b011:
+		return pack(b, c, d, 1, 0, h, 1, 1, 1, m)
+	case 0b100:
+		return pack(j, k, d, f, g, h, 1, 1, 0, m)
+	case 0b101:
+		return pack(f, g, d, 0, 1, h, 1, 1, 1, m)
+	case 0b110:
+		return pack(j, k, d, 0, 0, h, 1, 1, 1, m)
+	default:
+		return pack(0, 0, d, 1, 1, h, 1, 1, 1, m)
+	}
+}
+
+// decodeDeclet unpacks 10-bit DPD declet into value 0..999 (non-canonical declets included).
+func decodeDeclet(declet uint16) int {
+	bit := func(n int) uint16 { return declet >> n & 1 }
+	p, q, r, s, t, u, v, w, x, y := bit(9), bit(8), bit(7), bit(6), bit(5), bit(4), bit(3), bit(2), bit(1), bit(0)
+
+	digit := func(b3, b2, b1, b0 uint16) int { return int(b3<<3 | b2<<2 | b1<<1 | b0) }
+
+	var d2, d1, d0 int
+	switch {
+	case v == 0:
+		d2, d1, d0 = digit(0, p, q, r), digit(0, s, t, u), digit(0, w, x, y)
+	case w == 0 && x == 0:
+		d2, d1, d0 = digit(0, p, q, r), digit(0, s, t, u), digit(1, 0, 0, y)
+	case w == 0 && x == 1:
+		d2, d1, d0 = digit(0, p, q, r), digit(1, 0, 0, u), digit(0, s, t, y)
+	case w == 1 && x == 0:
+		d2, d1, d0 = digit(1, 0, 0, r), digit(0, s, t, u), digit(0, p, q, y)
+	case s == 0 && t == 0:
+		d2, d1, d0 = digit(1, 0, 0, r), digit(1, 0, 0, u), digit(0, p, q, y)
+	case s == 0 && t == 1:
+		d2, d1, d0 = digit(1, 0, 0, r), digit(0, p, q, u), digit(1, 0, 0, y)
+	case s == 1 && t == 0:
+		d2, d1, d0 = digit(0, p, q, r), digit(1, 0, 0, u), digit(1, 0, 0, y)
+	default:
+		d2, d1, d0 = digit(1, 0, 0, r), digit(1, 0, 0, u), digit(1, 0, 0, y)
+	}
+
+	return d2*100 + d1*10 + d0
+}
+
+// bitsOf returns "count" bits of x starting from bit "from" (LSB = 0).
+func bitsOf(x *big.Int, from int, count int) int {
+	out := 0
+	for i := from + count - 1; i >= from; i-- {
+		out = out<<1 | int(x.Bit(i))
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+package bytecast
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecimal64Encoding(t *testing.T) {
+	tests := []struct {
+		decimal string
+		bid     string
+		dpd     string
+	}{
+		{"0", "31c0000000000000", "2238000000000000"},
+		{"1", "31c0000000000001", "2238000000000001"},
+		{"-1", "b1c0000000000001", "a238000000000001"},
+		{"9999999999999999", "6c7386f26fc0ffff", "6e38ff3fcff3fcff"},
+		{"9.999999999999999E+384", "77fb86f26fc0ffff", "77fcff3fcff3fcff"},
+		{"1E-398", "0000000000000001", "0000000000000001"},
+		{"Infinity", "7800000000000000", "7800000000000000"},
+		{"-Inf", "f800000000000000", "f800000000000000"},
+		{"NaN", "7c00000000000000", "7c00000000000000"},
+	}
+
+	for _, tt := range tests {
+		bid, err := Decimal64ToBytesBID(tt.decimal)
+		if err != nil {
+			t.Errorf("Decimal64ToBytesBID(%q) returned error: %v", tt.decimal, err)
+		} else if got := fmt.Sprintf("%x", bid); got != tt.bid {
+			t.Errorf("Decimal64ToBytesBID(%q) = %s; want %s", tt.decimal, got, tt.bid)
+		}
+
+		dpd, err := Decimal64ToBytesDPD(tt.decimal)
+		if err != nil {
+			t.Errorf("Decimal64ToBytesDPD(%q) returned error: %v", tt.decimal, err)
+		} else if got := fmt.Sprintf("%x", dpd); got != tt.dpd {
+			t.Errorf("Decimal64ToBytesDPD(%q) = %s; want %s", tt.decimal, got, tt.dpd)
+		}
+	}
+}
+
+func TestDecimal128Encoding(t *testing.T) {
+	tests := []struct {
+		decimal string
+		bid     string
+		dpd     string
+	}{
+		{"1", "30400000000000000000000000000001", "22080000000000000000000000000001"},
+		{"-1", "b0400000000000000000000000000001", "a2080000000000000000000000000001"},
+		{"9.999999999999999999999999999999999E+6144", "5fffed09bead87c0378d8e63ffffffff", "77ffcff3fcff3fcff3fcff3fcff3fcff"},
+	}
+
+	for _, tt := range tests {
+		bid, err := Decimal128ToBytesBID(tt.decimal)
+		if err != nil {
+			t.Errorf("Decimal128ToBytesBID(%q) returned error: %v", tt.decimal, err)
+		} else if got := fmt.Sprintf("%x", bid); got != tt.bid {
+			t.Errorf("Decimal128ToBytesBID(%q) = %s; want %s", tt.decimal, got, tt.bid)
+		}
+
+		dpd, err := Decimal128ToBytesDPD(tt.decimal)
+		if err != nil {
+			t.Errorf("Decimal128ToBytesDPD(%q) returned error: %v", tt.decimal, err)
+		} else if got := fmt.Sprintf("%x", dpd); got != tt.dpd {
+			t.Errorf("Decimal128ToBytesDPD(%q) = %s; want %s", tt.decimal, got, tt.dpd)
+		}
+	}
+}
+
+func TestDecimalFloatRoundTrip(t *testing.T) {
+	tests := []struct {
+		decimal string
+		want    string
+	}{
+		{"123.45", "123.45"},
+		{"-123.45", "-123.45"},
+		{"1.50", "1.50"},
+		{"-0", "-0"},
+		{"0.000001", "0.000001"},
+		{"0.0000001", "1E-7"},
+		{"1.5E+3", "1.5E+3"},
+		{"1500", "1500"},
+		{"1E+370", "1.0E+370"}, // exponent folded into coefficient
+		{"12345678901234560", "1.234567890123456E+16"},
+		{"sNaN", "sNaN"},
+		{"-Infinity", "-Infinity"},
+	}
+
+	for _, tt := range tests {
+		bid, err := Decimal64ToBytesBID(tt.decimal)
+		if err != nil {
+			t.Fatalf("Decimal64ToBytesBID(%q) returned error: %v", tt.decimal, err)
+		}
+		if got := Decimal64FromBytesBID(bid); got != tt.want {
+			t.Errorf("BID round-trip of %q: expected %s got %s", tt.decimal, tt.want, got)
+		}
+
+		dpd, err := Decimal64ToBytesDPD(tt.decimal)
+		if err != nil {
+			t.Fatalf("Decimal64ToBytesDPD(%q) returned error: %v", tt.decimal, err)
+		}
+		if got := Decimal64FromBytesDPD(dpd); got != tt.want {
+			t.Errorf("DPD round-trip of %q: expected %s got %s", tt.decimal, tt.want, got)
+		}
+	}
+
+	long := "1234567890123456789012345678901234"
+	b, err := Decimal128ToBytesDPD(long)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Decimal128FromBytesDPD(b); got != long {
+		t.Fatalf("expected %s got %s", long, got)
+	}
+}
+
+func TestDecimalFloatErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"abc",
+		"1E",
+		"1E+-3",
+		"12345678901234567", // 17 significant digits
+		"1E+385",
+		"1E-399",
+	}
+
+	for _, c := range cases {
+		if _, err := Decimal64ToBytesBID(c); err == nil {
+			t.Errorf("Decimal64ToBytesBID(%q): expected error", c)
+		}
+	}
+}
+
+func TestDecletRoundTrip(t *testing.T) {
+	for v := 0; v < 1000; v++ {
+		if got := decodeDeclet(encodeDeclet(v)); got != v {
+			t.Fatalf("declet round-trip failed for %d: got %d", v, got)
+		}
+	}
+}
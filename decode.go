@@ -0,0 +1,125 @@
+package bytecast
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// ErrTrailingBytes is returned by Unmarshal and Decode when data contains bytes
+// after the declared layout of fixed-size value.
+var ErrTrailingBytes = errors.New("trailing bytes after encoded value")
+
+// ErrNonZeroPadding is returned by Decode with StrictPadding when reserved bytes of struct are not zeros.
+var ErrNonZeroPadding = errors.New("non-zero padding")
+
+type decodeConfig struct {
+	allowTrailing bool
+	strictPadding bool
+	fillDefaults  bool
+	limits        Limits
+}
+
+// DecodeOption configures Decode.
+type DecodeOption func(*decodeConfig)
+
+// AllowTrailingBytes makes Decode ignore bytes after the declared layout of fixed-size value,
+// so newer producers can append fields without breaking older consumers.
+// Types without fixed size ([]byte, *big.Int) always consume all data.
+func AllowTrailingBytes() DecodeOption {
+	return func(c *decodeConfig) {
+		c.allowTrailing = true
+	}
+}
+
+// RejectTrailingBytes is strict mode for validators: any leftover bytes after the declared layout
+// yield ErrTrailingBytes. This is the default, the option exists to override AllowTrailingBytes
+// given earlier in the same option list.
+func RejectTrailingBytes() DecodeOption {
+	return func(c *decodeConfig) {
+		c.allowTrailing = false
+	}
+}
+
+// StrictPadding makes decoding of structs verify that all reserved bytes (tag options pad, align
+// and gaps before offset) are zeros, otherwise ErrNonZeroPadding is returned.
+func StrictPadding() DecodeOption {
+	return func(c *decodeConfig) {
+		c.strictPadding = true
+	}
+}
+
+// FillDefaults makes decoding of structs accept input shorter than the layout, as written by
+// producers of older, shorter versions of the record. Fields missing from the input entirely
+// are set to value of their default tag option, or zero; a field cut in the middle is an error.
+func FillDefaults() DecodeOption {
+	return func(c *decodeConfig) {
+		c.fillDefaults = true
+	}
+}
+
+// Decode is Unmarshal with options, it returns number of bytes consumed from data.
+// Without options it behaves exactly like Unmarshal and consumes all data.
+func Decode(data []byte, v any, opts ...DecodeOption) (int, error) {
+	c := newDecodeConfig(opts)
+
+	n := len(data)
+	if c.allowTrailing {
+		if size, ok := fixedDecodeSize(v); ok && size < n {
+			n = size
+		}
+	}
+
+	if err := unmarshal(data[:n], v, c); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// checkTrailingBytes returns ErrTrailingBytes if data is longer than fixed size of value pointed by v.
+func checkTrailingBytes(data []byte, v any) error {
+	if size, ok := fixedDecodeSize(v); ok && len(data) > size {
+		return fmt.Errorf("%w: %d bytes after %d-byte %s", ErrTrailingBytes, len(data)-size, size, reflect.TypeOf(v).Elem())
+	}
+	return nil
+}
+
+// fixedDecodeSize returns encoded size of value pointed by v if it does not depend on the value.
+func fixedDecodeSize(v any) (int, bool) {
+	switch v.(type) {
+	case *bool, *int8, *uint8:
+		return 1, true
+	case *int16, *uint16:
+		return 2, true
+	case *int32, *uint32:
+		return 4, true
+	case *int64, *uint64, *Bytes8:
+		return 8, true
+	case *Bytes16:
+		return 16, true
+	case *Bytes20, *Address:
+		return 20, true
+	case *Bytes32:
+		return 32, true
+	case *Bytes64:
+		return 64, true
+	case *string:
+		return 256, true
+	case *big.Int:
+		return 0, false // struct, but encoded with variable length
+	}
+
+	if t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Pointer && lookupCustomCodec(t.Elem()) != nil {
+		return 0, false // registered codecs decide themselves
+	}
+
+	if t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct {
+		if s, err := SchemaOf(t); err == nil {
+			return s.size, true
+		}
+	}
+
+	return 0, false
+}
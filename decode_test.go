@@ -0,0 +1,193 @@
+package bytecast
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeAllowTrailingBytes(t *testing.T) {
+	// newer producer appended Priority to versionedTestV1 layout
+	newer, err := Marshal(versionedTestV2{ID: 7, Priority: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var old versionedTestV1
+	if _, err := Decode(newer, &old); err == nil {
+		t.Fatal("expected error without AllowTrailingBytes")
+	}
+
+	n, err := Decode(newer, &old, AllowTrailingBytes())
+	if err != nil || n != 4 || old.ID != 7 {
+		t.Fatalf("expected ID 7 and 4 bytes consumed, got %+v, %d (%v)", old, n, err)
+	}
+
+	var u16 uint16
+	n, err = Decode([]byte{0x01, 0x02, 0xff}, &u16, AllowTrailingBytes())
+	if err != nil || n != 2 || u16 != 0x0102 {
+		t.Fatalf("expected 0x0102 and 2 bytes consumed, got %#x, %d (%v)", u16, n, err)
+	}
+
+	// variable-size types consume everything
+	var raw []byte
+	n, err = Decode([]byte{1, 2, 3}, &raw, AllowTrailingBytes())
+	if err != nil || n != 3 || len(raw) != 3 {
+		t.Fatalf("expected all 3 bytes consumed, got %d (%v)", n, err)
+	}
+
+	// short data is still an error
+	if _, err := Decode([]byte{0x01}, &u16, AllowTrailingBytes()); err == nil {
+		t.Fatal("expected error for short data")
+	}
+}
+
+func TestDecodeRejectTrailingBytes(t *testing.T) {
+	data := []byte{0x00, 0x00, 0x00, 0x07, 0xee}
+
+	var rec versionedTestV1
+	if err := Unmarshal(data, &rec); !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("expected ErrTrailingBytes from Unmarshal, got %v", err)
+	}
+
+	if _, err := Decode(data, &rec, AllowTrailingBytes(), RejectTrailingBytes()); !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("expected ErrTrailingBytes in strict mode, got %v", err)
+	}
+
+	var s string
+	if err := Unmarshal(make([]byte, 257), &s); !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("expected ErrTrailingBytes for string, got %v", err)
+	}
+
+	// short data is a different error
+	if err := Unmarshal(data[:3], &rec); err == nil || errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("expected length error, got %v", err)
+	}
+}
+
+func TestDecodeStrictPadding(t *testing.T) {
+	type record struct {
+		Kind  uint8  `bytecast:"pad=1"`
+		Value uint32 `bytecast:"align=4"`
+		Flags uint8  `bytecast:"pad=2"`
+	}
+
+	layout, err := DescribeLayout(record{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []FieldLayout{
+		{"Kind", 0, 1, KindUint, false},
+		{"<reserved>", 1, 1, KindReserved, false},
+		{"<reserved>", 2, 2, KindReserved, false},
+		{"Value", 4, 4, KindUint, false},
+		{"Flags", 8, 1, KindUint, false},
+		{"<reserved>", 9, 2, KindReserved, false},
+	}
+	if !reflect.DeepEqual(layout, expected) {
+		t.Fatalf("unexpected layout:\n%v\nexpected:\n%v", layout, expected)
+	}
+
+	in := record{Kind: 1, Value: 0xdeadbeef, Flags: 0x80}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{1, 0, 0, 0, 0xde, 0xad, 0xbe, 0xef, 0x80, 0, 0}; !bytes.Equal(data, want) {
+		t.Fatalf("expected % x, got % x", want, data)
+	}
+
+	data[2] = 0xaa
+	var out record
+	if _, err := Decode(data, &out); err != nil || out != in {
+		t.Fatalf("expected reserved bytes to be ignored, got %+v (%v)", out, err)
+	}
+
+	_, err = Decode(data, &out, StrictPadding())
+	if !errors.Is(err, ErrNonZeroPadding) {
+		t.Fatalf("expected ErrNonZeroPadding, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "offset 2") {
+		t.Errorf("expected offset in error, got %v", err)
+	}
+}
+
+func TestDecodeFillDefaults(t *testing.T) {
+	type record struct {
+		ID       uint32
+		Priority uint8  `bytecast:"default=5"`
+		Offset   int16  `bytecast:"default=-2,le"`
+		Enabled  bool   `bytecast:"default=true"`
+		Label    string `bytecast:"width=8,default=none"`
+		Extra    *uint16
+		Count    uint8
+	}
+
+	legacy := []byte{0x00, 0x00, 0x00, 0x07} // ID only
+	var rec record
+	if _, err := Decode(legacy, &rec); err == nil {
+		t.Fatal("expected error for short input without FillDefaults")
+	}
+
+	rec = record{Count: 9, Extra: new(uint16)}
+	if _, err := Decode(legacy, &rec, FillDefaults()); err != nil {
+		t.Fatal(err)
+	}
+	expected := record{ID: 7, Priority: 5, Offset: -2, Enabled: true, Label: "none"}
+	if !reflect.DeepEqual(rec, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, rec)
+	}
+
+	// present fields win over defaults
+	rec = record{}
+	if _, err := Decode([]byte{0, 0, 0, 7, 1}, &rec, FillDefaults()); err != nil || rec.Priority != 1 || rec.Offset != -2 {
+		t.Fatalf("expected Priority 1 and default Offset, got %+v (%v)", rec, err)
+	}
+
+	// field cut in the middle
+	if _, err := Decode([]byte{0, 0, 0, 7, 1, 0xfe}, &rec, FillDefaults()); err == nil {
+		t.Fatal("expected error for truncated field")
+	}
+
+	// full record decodes the same with and without the option
+	full, err := Marshal(record{ID: 1, Label: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decode(full, &rec, FillDefaults()); err != nil || rec.ID != 1 || rec.Priority != 0 {
+		t.Fatalf("expected decoded record, got %+v (%v)", rec, err)
+	}
+}
+
+func TestDefaultTagErrors(t *testing.T) {
+	invalid := []any{
+		struct {
+			N uint8 `bytecast:"default=256"`
+		}{},
+		struct {
+			N int8 `bytecast:"default=x"`
+		}{},
+		struct {
+			N uint16 `bytecast:"width=1,default=300"`
+		}{},
+		struct {
+			B [2]byte `bytecast:"default=1"`
+		}{},
+		struct {
+			S string `bytecast:"width=3,default=long"`
+		}{},
+		struct {
+			P *uint16 `bytecast:"default=5"`
+		}{},
+		struct {
+			P [2]*uint16 `bytecast:"default=5"`
+		}{},
+	}
+	for _, v := range invalid {
+		if _, err := SchemaOf(v); err == nil {
+			t.Errorf("SchemaOf(%T): expected error", v)
+		}
+	}
+}
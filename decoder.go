@@ -0,0 +1,176 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// Decoder decodes typed values at explicit offsets of underlying io.ReaderAt (e.g. *os.File),
+// so headers of large files can be inspected without reading them sequentially:
+//
+//	dec := NewDecoder(file)
+//	createdAt, err := dec.Int64At(16)
+//	flags, err := dec.Uint24At(24)
+//
+// Each call performs a single ReadAt, so Decoder is safe for concurrent use
+// as long as underlying ReaderAt is.
+type Decoder struct {
+	r io.ReaderAt
+}
+
+func NewDecoder(r io.ReaderAt) *Decoder {
+	return &Decoder{r: r}
+}
+
+// BytesAt reads exactly n bytes starting at offset off.
+func (d *Decoder) BytesAt(off int64, n int) ([]byte, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("negative length %d", n)
+	}
+
+	b := make([]byte, n)
+
+	read, err := d.r.ReadAt(b, off)
+	if read == n {
+		// ReaderAt may return io.EOF together with the last bytes of input
+		return b, nil
+	}
+
+	if err == nil || errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return nil, fmt.Errorf("failed to read %d bytes at offset %d: %w", n, off, err)
+}
+
+func (d *Decoder) Int8At(off int64) (int8, error) {
+	b, err := d.BytesAt(off, 1)
+	if err != nil {
+		return 0, err
+	}
+	return Int8From1Byte([1]byte(b)), nil
+}
+
+func (d *Decoder) Uint8At(off int64) (uint8, error) {
+	b, err := d.BytesAt(off, 1)
+	if err != nil {
+		return 0, err
+	}
+	return Uint8From1Byte([1]byte(b)), nil
+}
+
+func (d *Decoder) Int16At(off int64) (int16, error) {
+	b, err := d.BytesAt(off, 2)
+	if err != nil {
+		return 0, err
+	}
+	return Int16From2Bytes([2]byte(b)), nil
+}
+
+func (d *Decoder) Uint16At(off int64) (uint16, error) {
+	b, err := d.BytesAt(off, 2)
+	if err != nil {
+		return 0, err
+	}
+	return Uint16From2Bytes([2]byte(b)), nil
+}
+
+func (d *Decoder) Int24At(off int64) (int32, error) {
+	v, err := d.IntXXAt(off, 24)
+	return int32(v), err
+}
+
+func (d *Decoder) Uint24At(off int64) (uint32, error) {
+	v, err := d.UintXXAt(off, 24)
+	return uint32(v), err
+}
+
+func (d *Decoder) Int32At(off int64) (int32, error) {
+	b, err := d.BytesAt(off, 4)
+	if err != nil {
+		return 0, err
+	}
+	return Int32From4Bytes([4]byte(b)), nil
+}
+
+func (d *Decoder) Uint32At(off int64) (uint32, error) {
+	b, err := d.BytesAt(off, 4)
+	if err != nil {
+		return 0, err
+	}
+	return Uint32From4Bytes([4]byte(b)), nil
+}
+
+func (d *Decoder) Int64At(off int64) (int64, error) {
+	b, err := d.BytesAt(off, 8)
+	if err != nil {
+		return 0, err
+	}
+	return Int64From8Bytes([8]byte(b)), nil
+}
+
+func (d *Decoder) Uint64At(off int64) (uint64, error) {
+	b, err := d.BytesAt(off, 8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// IntXXAt reads ceil(xx / 8) bytes at offset off and interprets them as xx-bit signed value.
+func (d *Decoder) IntXXAt(off int64, xx int) (int64, error) {
+	if xx <= 0 || xx > 64 {
+		return 0, fmt.Errorf("unsupported bit size %d, must be 1..64", xx)
+	}
+
+	b, err := d.BytesAt(off, (xx+7)/8)
+	if err != nil {
+		return 0, err
+	}
+	return IntXXFromBytes(b, xx)
+}
+
+// UintXXAt reads ceil(xx / 8) bytes at offset off and interprets them as xx-bit unsigned value.
+func (d *Decoder) UintXXAt(off int64, xx int) (uint64, error) {
+	if xx <= 0 || xx > 64 {
+		return 0, fmt.Errorf("unsupported bit size %d, must be 1..64", xx)
+	}
+
+	b, err := d.BytesAt(off, (xx+7)/8)
+	if err != nil {
+		return 0, err
+	}
+	return UintXXFromBytes(b, xx)
+}
+
+// BigIntXXXAt reads ceil(xxx / 8) bytes at offset off and interprets them as xxx-bit signed value.
+func (d *Decoder) BigIntXXXAt(off int64, xxx int) (*big.Int, error) {
+	if xxx <= 64 {
+		return nil, fmt.Errorf("too small bit size %d, for standard int up to int64 use \"IntXXAt\" method", xxx)
+	}
+
+	b, err := d.BytesAt(off, (xxx+7)/8)
+	if err != nil {
+		return nil, err
+	}
+	return BigIntXXXFromBytes(b, xxx)
+}
+
+func (d *Decoder) BoolAt(off int64) (bool, error) {
+	b, err := d.BytesAt(off, 1)
+	if err != nil {
+		return false, err
+	}
+	return BoolFrom1Byte([1]byte(b)), nil
+}
+
+func (d *Decoder) String256At(off int64) (string, error) {
+	b, err := d.BytesAt(off, 256)
+	if err != nil {
+		return "", err
+	}
+	return StringFrom256Bytes([256]byte(b)), nil
+}
@@ -0,0 +1,92 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+)
+
+func TestDecoderAt(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.PutInt8(-1)                   // 0
+	enc.PutUint8(200)                 // 1
+	enc.PutInt16(-2)                  // 2
+	enc.PutUint16(0xfffe)             // 4
+	enc.PutIntXX(-193630, 24, 3)      // 6
+	enc.PutUintXX(16777215, 24, 3)    // 9
+	enc.PutInt32(-3)                  // 12
+	enc.PutUint32(0xfffffffd)         // 16
+	enc.PutInt64(-1234567890123)      // 20
+	enc.PutUint64(1<<63 + 1)          // 28
+	enc.PutBool(true)                 // 36
+	enc.PutBigInt(big.NewInt(-1), 16) // 37, int128
+	enc.PutString256("hello world")   // 53
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	dec := NewDecoder(bytes.NewReader(buf.Bytes()))
+
+	check := func(name string, got any, err error, want any) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+		if got != want {
+			t.Fatalf("%s = %v; want %v", name, got, want)
+		}
+	}
+
+	v8, err := dec.Int8At(0)
+	check("Int8At", v8, err, int8(-1))
+	u8, err := dec.Uint8At(1)
+	check("Uint8At", u8, err, uint8(200))
+	v16, err := dec.Int16At(2)
+	check("Int16At", v16, err, int16(-2))
+	u16, err := dec.Uint16At(4)
+	check("Uint16At", u16, err, uint16(0xfffe))
+	v24, err := dec.Int24At(6)
+	check("Int24At", v24, err, int32(-193630))
+	u24, err := dec.Uint24At(9)
+	check("Uint24At", u24, err, uint32(16777215))
+	v32, err := dec.Int32At(12)
+	check("Int32At", v32, err, int32(-3))
+	u32, err := dec.Uint32At(16)
+	check("Uint32At", u32, err, uint32(0xfffffffd))
+	v64, err := dec.Int64At(20)
+	check("Int64At", v64, err, int64(-1234567890123))
+	u64, err := dec.Uint64At(28)
+	check("Uint64At", u64, err, uint64(1<<63+1))
+	b, err := dec.BoolAt(36)
+	check("BoolAt", b, err, true)
+	big128, err := dec.BigIntXXXAt(37, 128)
+	check("BigIntXXXAt", big128.String(), err, "-1")
+	s, err := dec.String256At(53)
+	check("String256At", s, err, "hello world")
+}
+
+func TestDecoderAtOutOfRange(t *testing.T) {
+	data, _ := hex.DecodeString("0102030405")
+	dec := NewDecoder(bytes.NewReader(data))
+
+	if _, err := dec.Int32At(2); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	if _, err := dec.Uint8At(5); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	// reading the very last bytes is fine even if ReaderAt reports io.EOF
+	v, err := dec.Uint16At(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 0x0405 {
+		t.Fatalf("expected 0x0405 got 0x%x", v)
+	}
+}
@@ -0,0 +1,68 @@
+package bytecast
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// FieldDiff describes one field that differs between two encoded records.
+type FieldDiff struct {
+	Field FieldLayout
+	A, B  string // decoded values (as in Dump), "<missing>" / "<truncated>" if data is too short
+}
+
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s at offset %d: %s != %s", d.Field.Name, d.Field.Offset, d.A, d.B)
+}
+
+// Diff compares two records field by field using schema and returns fields whose bytes differ,
+// in layout order. Trailing bytes after the last field are compared as a pseudo-field "<trailing>".
+// Equal records yield empty result.
+func Diff(a, b []byte, schema *Schema) []FieldDiff {
+	var diffs []FieldDiff
+
+	for i := range schema.fields {
+		f := &schema.fields[i]
+
+		fa, fb := fieldBytes(a, f), fieldBytes(b, f)
+		if bytes.Equal(fa, fb) {
+			continue
+		}
+
+		diffs = append(diffs, FieldDiff{Field: f.FieldLayout, A: diffValue(f, fa), B: diffValue(f, fb)})
+	}
+
+	ta, tb := trailingBytes(a, schema.size), trailingBytes(b, schema.size)
+	if !bytes.Equal(ta, tb) {
+		trailing := FieldLayout{Name: "<trailing>", Offset: schema.size, Width: max(len(ta), len(tb))}
+		diffs = append(diffs, FieldDiff{Field: trailing, A: fmt.Sprintf("%x", ta), B: fmt.Sprintf("%x", tb)})
+	}
+
+	return diffs
+}
+
+// fieldBytes returns bytes of field f in data, shorter than f.Width if data is truncated,
+// nil if field starts after the end of data.
+func fieldBytes(data []byte, f *schemaField) []byte {
+	if f.Offset >= len(data) {
+		return nil
+	}
+	return data[f.Offset:min(f.Offset+f.Width, len(data))]
+}
+
+func trailingBytes(data []byte, size int) []byte {
+	if len(data) <= size {
+		return nil
+	}
+	return data[size:]
+}
+
+func diffValue(f *schemaField, b []byte) string {
+	switch {
+	case b == nil:
+		return "<missing>"
+	case len(b) < f.Width:
+		return "<truncated>"
+	}
+	return dumpValue(f, b)
+}
@@ -0,0 +1,50 @@
+package bytecast
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	s, err := SchemaOf(dumpTestRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := Marshal(dumpTestRecord{Version: 1, Delta: -1, Ok: true})
+	b, _ := Marshal(dumpTestRecord{Version: 1, Delta: 5, Ok: false})
+
+	if diffs := Diff(a, a, s); len(diffs) != 0 {
+		t.Fatalf("expected no differences, got %v", diffs)
+	}
+
+	diffs := Diff(a, b, s)
+	if len(diffs) != 2 {
+		t.Fatalf("expected 2 differences, got %v", diffs)
+	}
+
+	if got := diffs[0].String(); got != "Delta at offset 1: -1 != 5" {
+		t.Fatalf("unexpected diff %q", got)
+	}
+	if got := diffs[1].String(); got != "Ok at offset 23: true != false" {
+		t.Fatalf("unexpected diff %q", got)
+	}
+}
+
+func TestDiffLengthMismatch(t *testing.T) {
+	s, err := SchemaOf(dumpTestRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := Marshal(dumpTestRecord{Ok: true})
+
+	diffs := Diff(a, a[:3], s)
+	if len(diffs) != 2 || diffs[0].Field.Name != "Tag" || diffs[0].B != "<missing>" || diffs[1].Field.Name != "Ok" {
+		t.Fatalf("unexpected diffs %v", diffs)
+	}
+
+	diffs = Diff(a, append(a, 0xee), s)
+	if len(diffs) != 1 || diffs[0].Field.Name != "<trailing>" || diffs[0].B != "ee" {
+		t.Fatalf("unexpected diffs %v", diffs)
+	}
+}
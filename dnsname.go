@@ -0,0 +1,109 @@
+package bytecast
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDNSCompressionLoop is returned by DecodeDNSName for compression pointers that do not point backwards,
+// which is the only way to build a pointer loop.
+var ErrDNSCompressionLoop = errors.New("dns name compression pointer does not point backwards")
+
+// EncodeDNSName
+//
+//	Encodes domain name in DNS wire format (RFC 1035 section 3.1): sequence of length-prefixed labels
+//	terminated by zero byte, "www.example.com" → 3 www 7 example 3 com 0.
+//
+//	Trailing dot is optional, "" and "." encode root name. Labels must be 1..63 bytes and whole encoded
+//	name at most 255 bytes. Escapes ("\.") are not supported, names are written without compression.
+func EncodeDNSName(name string) ([]byte, error) {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		return []byte{0}, nil
+	}
+
+	out := make([]byte, 0, len(name)+2)
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return nil, fmt.Errorf("invalid label %q in dns name %q, must be 1..63 bytes", label, name)
+		}
+		out = append(out, byte(len(label)))
+		out = append(out, label...)
+	}
+	out = append(out, 0)
+
+	if len(out) > 255 {
+		return nil, fmt.Errorf("dns name %q is %d bytes encoded, max 255", name, len(out))
+	}
+
+	return out, nil
+}
+
+// DecodeDNSName
+//
+//	Decodes domain name starting at msg[off], where msg is a whole DNS message, and returns it with trailing dot
+//	("www.example.com.", root is ".") together with offset right after the name in msg.
+//
+//	Compression pointers (two bytes with top bits 11, RFC 1035 section 4.1.4) are followed, but only backwards,
+//	otherwise ErrDNSCompressionLoop is returned. Reserved label types 01 and 10 are rejected.
+func DecodeDNSName(msg []byte, off int) (string, int, error) {
+	if off < 0 || off >= len(msg) {
+		return "", 0, fmt.Errorf("dns name offset %d is out of message of %d bytes", off, len(msg))
+	}
+
+	var sb strings.Builder
+	next := -1 // offset after the name in original position, set by the first pointer
+	size := 0
+
+	for {
+		if off >= len(msg) {
+			return "", 0, fmt.Errorf("truncated dns name at offset %d", off)
+		}
+
+		l := int(msg[off])
+		switch l & 0xC0 {
+		case 0x00:
+		case 0xC0:
+			if off+1 >= len(msg) {
+				return "", 0, fmt.Errorf("truncated dns compression pointer at offset %d", off)
+			}
+			ptr := (l&0x3F)<<8 | int(msg[off+1])
+			if ptr >= off {
+				return "", 0, fmt.Errorf("%w: pointer at offset %d to %d", ErrDNSCompressionLoop, off, ptr)
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			off = ptr
+			continue
+		default:
+			return "", 0, fmt.Errorf("unsupported dns label type 0x%02x at offset %d", l&0xC0, off)
+		}
+
+		if l == 0 {
+			if next < 0 {
+				next = off + 1
+			}
+			break
+		}
+
+		if off+1+l > len(msg) {
+			return "", 0, fmt.Errorf("dns label at offset %d declares %d bytes, but only %d left", off, l, len(msg)-off-1)
+		}
+
+		size += 1 + l
+		if size+1 > 255 {
+			return "", 0, fmt.Errorf("dns name exceeds 255 bytes")
+		}
+
+		sb.Write(msg[off+1 : off+1+l])
+		sb.WriteByte('.')
+		off += 1 + l
+	}
+
+	if sb.Len() == 0 {
+		return ".", next, nil
+	}
+	return sb.String(), next, nil
+}
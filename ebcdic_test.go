@@ -0,0 +1,64 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestEBCDIC(t *testing.T) {
+	tests := []struct {
+		s    string
+		cp   EBCDICCodePage
+		want string
+	}{
+		{"Hello, World! 123", CP037, "c8859393966b40e6969993845a40f1f2f3"},
+		// code pages differ in punctuation placement
+		{"[]!|^", CP037, "babb5a4fb0"},
+		{"[]!|^", CP500, "4a5a4fbb5f"},
+		{"Grüße", CP500, "c799dc5985"},
+		{"", CP037, ""},
+	}
+
+	for _, tt := range tests {
+		got, err := EncodeEBCDIC(tt.s, tt.cp)
+		if err != nil || hex.EncodeToString(got) != tt.want {
+			t.Fatalf("EncodeEBCDIC(%q, %d) = %x (%v); want %s", tt.s, tt.cp, got, err, tt.want)
+		}
+
+		back, err := DecodeEBCDIC(got, tt.cp)
+		if err != nil || back != tt.s {
+			t.Fatalf("DecodeEBCDIC(%s) = %q (%v); want %q", tt.want, back, err, tt.s)
+		}
+	}
+}
+
+func TestEBCDICAllBytesRoundTrip(t *testing.T) {
+	all := make([]byte, 256)
+	for i := range all {
+		all[i] = byte(i)
+	}
+
+	for _, cp := range []EBCDICCodePage{CP037, CP500} {
+		s, err := DecodeEBCDIC(all, cp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		back, err := EncodeEBCDIC(s, cp)
+		if err != nil || hex.EncodeToString(back) != hex.EncodeToString(all) {
+			t.Fatalf("code page %d: round-trip of all bytes failed (%v)", cp, err)
+		}
+	}
+}
+
+func TestEBCDICErrors(t *testing.T) {
+	if _, err := EncodeEBCDIC("€", CP037); err == nil {
+		t.Fatal("expected error for rune outside of Latin-1")
+	}
+	if _, err := EncodeEBCDIC("A", EBCDICCodePage(0)); err == nil {
+		t.Fatal("expected error for unknown code page")
+	}
+	if _, err := DecodeEBCDIC([]byte{0xc1}, EBCDICCodePage(1140)); err == nil {
+		t.Fatal("expected error for unknown code page")
+	}
+}
@@ -0,0 +1,167 @@
+package bytecast
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math/big"
+)
+
+// Encoder batches many Put calls into internal buffer and writes them to underlying io.Writer
+// only on Flush, so encoding a record field by field costs one write (one syscall for sockets).
+//
+// Encoder remembers the first error (either encoding error from Put or write error from Flush),
+// after that all Put calls are ignored and Flush / Err return that error:
+//
+//	enc := NewEncoder(conn)
+//	enc.PutUint16(version)
+//	enc.PutIntXX(delta, 24, 3)
+//	enc.PutString256(name)
+//	if err := enc.Flush(); err != nil { ... }
+type Encoder struct {
+	w   io.Writer
+	buf []byte
+	err error
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+func (e *Encoder) PutInt8(v int8) {
+	b := Int8To1Byte(v)
+	e.PutBytes(b[:])
+}
+
+func (e *Encoder) PutUint8(v uint8) {
+	b := Uint8To1Byte(v)
+	e.PutBytes(b[:])
+}
+
+func (e *Encoder) PutInt16(v int16) {
+	b := Int16To2Bytes(v)
+	e.PutBytes(b[:])
+}
+
+func (e *Encoder) PutUint16(v uint16) {
+	b := Uint16To2Bytes(v)
+	e.PutBytes(b[:])
+}
+
+func (e *Encoder) PutInt32(v int32) {
+	b := Int32To4Bytes(v)
+	e.PutBytes(b[:])
+}
+
+func (e *Encoder) PutUint32(v uint32) {
+	b := Uint32To4Bytes(v)
+	e.PutBytes(b[:])
+}
+
+func (e *Encoder) PutInt64(v int64) {
+	b := Int64To8Bytes(v)
+	e.PutBytes(b[:])
+}
+
+func (e *Encoder) PutUint64(v uint64) {
+	if e.err != nil {
+		return
+	}
+	e.buf = binary.BigEndian.AppendUint64(e.buf, v)
+}
+
+// PutIntXX encodes value as xx-bit signed integer expanded to width bytes, see IntXXToBytesAndExpandWidth.
+func (e *Encoder) PutIntXX(v int64, xx int, width int) {
+	e.put(IntXXToBytesAndExpandWidth(v, xx, width))
+}
+
+// PutUintXX encodes value as xx-bit unsigned integer expanded to width bytes, see UintXXToBytesAndExpandWidth.
+func (e *Encoder) PutUintXX(v uint64, xx int, width int) {
+	e.put(UintXXToBytesAndExpandWidth(v, xx, width))
+}
+
+func (e *Encoder) PutBigInt(v *big.Int, width int) {
+	e.put(BigIntToBytesAndExpandWidth(v, width))
+}
+
+func (e *Encoder) PutBool(v bool) {
+	b := BoolTo1Byte(v)
+	e.PutBytes(b[:])
+}
+
+func (e *Encoder) PutString256(s string) {
+	if e.err != nil {
+		return
+	}
+	e.buf, e.err = AppendString256(e.buf, s)
+}
+
+// PutBytes appends raw bytes as is.
+func (e *Encoder) PutBytes(b []byte) {
+	if e.err != nil {
+		return
+	}
+	e.buf = append(e.buf, b...)
+}
+
+// Buffered returns number of bytes waiting for Flush.
+func (e *Encoder) Buffered() int {
+	return len(e.buf)
+}
+
+// Err returns the first error occurred during encoding or writing.
+func (e *Encoder) Err() error {
+	return e.err
+}
+
+// Flush writes all buffered bytes to underlying writer.
+func (e *Encoder) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	if len(e.buf) == 0 {
+		return nil
+	}
+
+	n, err := e.w.Write(e.buf)
+	if err == nil && n < len(e.buf) {
+		err = io.ErrShortWrite
+	}
+
+	if err != nil {
+		e.err = err
+		return err
+	}
+
+	e.buf = e.buf[:0]
+	return nil
+}
+
+// FlushContext is Flush which can be cancelled by ctx, see ReadFrameContext.
+// If ctx is done before writing, buffered bytes are kept and the Encoder stays usable;
+// if the write is interrupted, ctx.Err() becomes the Encoder error.
+func (e *Encoder) FlushContext(ctx context.Context) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	err := withWriteContext(ctx, e.w, e.Flush)
+	if err != nil && e.err != nil {
+		e.err = err
+	}
+	return err
+}
+
+func (e *Encoder) put(b []byte, err error) {
+	if e.err != nil {
+		return
+	}
+
+	if err != nil {
+		e.err = err
+		return
+	}
+
+	e.buf = append(e.buf, b...)
+}
@@ -0,0 +1,116 @@
+package bytecast
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// countingWriter counts Write calls and optionally fails them.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+	err    error
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestEncoderFlush(t *testing.T) {
+	w := &countingWriter{}
+	enc := NewEncoder(w)
+
+	enc.PutInt8(-1)
+	enc.PutUint8(2)
+	enc.PutInt16(-2)
+	enc.PutUint16(0x0304)
+	enc.PutInt32(-3)
+	enc.PutUint32(0x05060708)
+	enc.PutInt64(-4)
+	enc.PutUint64(0x090a0b0c0d0e0f10)
+	enc.PutIntXX(-1, 24, 3)
+	enc.PutUintXX(0xabcdef, 24, 4)
+	enc.PutBigInt(big.NewInt(-2), 5)
+	enc.PutBool(true)
+	enc.PutBytes([]byte{0xde, 0xad})
+
+	if w.writes != 0 {
+		t.Fatalf("expected no writes before Flush, got %d", w.writes)
+	}
+
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.writes != 1 {
+		t.Fatalf("expected exactly 1 write, got %d", w.writes)
+	}
+
+	want := "ff" + "02" + "fffe" + "0304" + "fffffffd" + "05060708" + "fffffffffffffffc" + "090a0b0c0d0e0f10" +
+		"ffffff" + "00abcdef" + "fffffffffe" + "01" + "dead"
+	if got := fmt.Sprintf("%x", w.Bytes()); got != want {
+		t.Fatalf("expected %s got %s", want, got)
+	}
+
+	if enc.Buffered() != 0 {
+		t.Fatalf("expected empty buffer after Flush, got %d bytes", enc.Buffered())
+	}
+
+	// empty flush does not touch writer
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if w.writes != 1 {
+		t.Fatalf("expected no write for empty buffer, got %d writes", w.writes)
+	}
+}
+
+func TestEncoderKeepsFirstError(t *testing.T) {
+	w := &countingWriter{}
+	enc := NewEncoder(w)
+
+	enc.PutUint8(1)
+	enc.PutIntXX(1<<30, 24, 3) // does not fit
+	enc.PutString256(strings.Repeat("a", 300))
+	enc.PutUint8(2)
+
+	firstErr := enc.Err()
+	if firstErr == nil {
+		t.Fatal("expected error")
+	}
+
+	if !strings.Contains(firstErr.Error(), "int24") {
+		t.Fatalf("expected the first (int24) error to be kept, got %v", firstErr)
+	}
+
+	if err := enc.Flush(); err != firstErr {
+		t.Fatalf("expected Flush to return first error, got %v", err)
+	}
+
+	if w.writes != 0 {
+		t.Fatalf("expected no writes after error, got %d", w.writes)
+	}
+}
+
+func TestEncoderWriteError(t *testing.T) {
+	writeErr := errors.New("connection reset")
+	enc := NewEncoder(&countingWriter{err: writeErr})
+
+	enc.PutUint32(1)
+	if err := enc.Flush(); !errors.Is(err, writeErr) {
+		t.Fatalf("expected write error, got %v", err)
+	}
+
+	enc.PutUint32(2)
+	if !errors.Is(enc.Err(), writeErr) {
+		t.Fatalf("expected write error to be kept, got %v", enc.Err())
+	}
+}
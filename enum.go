@@ -0,0 +1,135 @@
+package bytecast
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// UnknownEnumError is returned when value is not one of the declared enum constants.
+type UnknownEnumError struct {
+	Enum  string // enum name given to NewEnum
+	Value uint64
+}
+
+func (e *UnknownEnumError) Error() string {
+	return fmt.Sprintf("unknown %s value %d", e.Enum, e.Value)
+}
+
+// Enum maps named protocol constants to fixed-width unsigned integer on the wire
+// and validates them on both encode and decode:
+//
+//	type Color uint8
+//	const (Red Color = 1; Green Color = 2)
+//	colors, _ := NewEnum("Color", 1, map[Color]string{Red: "red", Green: "green"})
+//	b, _ := colors.Encode(Green) // 0x02
+//	c, err := colors.Decode([]byte{0x07}) // *UnknownEnumError
+type Enum[T ~uint8 | ~uint16 | ~uint32 | ~uint64] struct {
+	name     string
+	width    int
+	names    map[T]string
+	values   map[string]T
+	fallback *T
+}
+
+type EnumOption[T ~uint8 | ~uint16 | ~uint32 | ~uint64] func(*Enum[T])
+
+// WithEnumFallback makes Decode return fallback (e.g. "Unknown" constant) instead of error for unknown values.
+// Fallback itself does not have to be a declared constant.
+func WithEnumFallback[T ~uint8 | ~uint16 | ~uint32 | ~uint64](fallback T) EnumOption[T] {
+	return func(e *Enum[T]) {
+		e.fallback = &fallback
+	}
+}
+
+// NewEnum creates enum of width bytes (1..8) with given constants and their names,
+// every constant must fit in width bytes and names must be unique.
+func NewEnum[T ~uint8 | ~uint16 | ~uint32 | ~uint64](name string, width int, names map[T]string, opts ...EnumOption[T]) (*Enum[T], error) {
+	if width < 1 || width > 8 {
+		return nil, fmt.Errorf("unsupported enum width %d, must be 1..8 bytes", width)
+	}
+
+	e := &Enum[T]{name: name, width: width, names: make(map[T]string, len(names)), values: make(map[string]T, len(names))}
+
+	for v, n := range names {
+		if bits.Len64(uint64(v)) > 8*width {
+			return nil, fmt.Errorf("%s constant %s = %d does not fit in %d bytes", name, n, v, width)
+		}
+		if _, ok := e.values[n]; ok {
+			return nil, fmt.Errorf("%s constant name %q is used more than once", name, n)
+		}
+		e.names[v] = n
+		e.values[n] = v
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e, nil
+}
+
+// Width returns encoded width in bytes.
+func (e *Enum[T]) Width() int {
+	return e.width
+}
+
+// Valid reports whether v is one of declared constants.
+func (e *Enum[T]) Valid(v T) bool {
+	_, ok := e.names[v]
+	return ok
+}
+
+// Name returns name of the constant, or empty string for unknown value.
+func (e *Enum[T]) Name(v T) string {
+	return e.names[v]
+}
+
+// Parse returns constant by its name.
+func (e *Enum[T]) Parse(name string) (T, error) {
+	v, ok := e.values[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown %s name %q", e.name, name)
+	}
+	return v, nil
+}
+
+// Encode encodes declared constant as big-endian integer of enum width, unknown value yields *UnknownEnumError.
+func (e *Enum[T]) Encode(v T) ([]byte, error) {
+	return e.Append(nil, v)
+}
+
+// Append appends encoding of v (see Encode) to dst. On error dst is returned unchanged.
+func (e *Enum[T]) Append(dst []byte, v T) ([]byte, error) {
+	if !e.Valid(v) {
+		return dst, &UnknownEnumError{Enum: e.name, Value: uint64(v)}
+	}
+	for i := e.width - 1; i >= 0; i-- {
+		dst = append(dst, byte(uint64(v)>>(8*i)))
+	}
+	return dst, nil
+}
+
+// Decode decodes exactly Width() bytes. Unknown value yields *UnknownEnumError,
+// or fallback if enum was created WithEnumFallback.
+func (e *Enum[T]) Decode(b []byte) (T, error) {
+	if len(b) != e.width {
+		return 0, fmt.Errorf("expected exactly %d bytes for %s, but got %d bytes", e.width, e.name, len(b))
+	}
+
+	u, err := UintXXFromBytes(b, 8*e.width)
+	if err != nil {
+		return 0, err
+	}
+
+	// value wider than T (e.g. 2-byte enum of uint8 type) can not be a declared constant
+	v := T(u)
+	if uint64(v) == u && e.Valid(v) {
+		return v, nil
+	}
+
+	if e.fallback != nil {
+		return *e.fallback, nil
+	}
+
+	return 0, &UnknownEnumError{Enum: e.name, Value: u}
+}
@@ -0,0 +1,79 @@
+package bytecast
+
+import (
+	"errors"
+	"testing"
+)
+
+type enumTestColor uint8
+
+const (
+	enumTestUnknown enumTestColor = 0
+	enumTestRed     enumTestColor = 1
+	enumTestGreen   enumTestColor = 2
+)
+
+func TestEnum(t *testing.T) {
+	colors, err := NewEnum("Color", 2, map[enumTestColor]string{enumTestRed: "red", enumTestGreen: "green"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := colors.Encode(enumTestGreen)
+	if err != nil || len(b) != 2 || b[0] != 0 || b[1] != 2 {
+		t.Fatalf("expected 0002 got %x (%v)", b, err)
+	}
+
+	c, err := colors.Decode(b)
+	if err != nil || c != enumTestGreen || colors.Name(c) != "green" {
+		t.Fatalf("expected green, got %d (%v)", c, err)
+	}
+
+	var unknown *UnknownEnumError
+	if _, err := colors.Encode(enumTestColor(9)); !errors.As(err, &unknown) || unknown.Value != 9 {
+		t.Fatalf("expected *UnknownEnumError for 9, got %v", err)
+	}
+
+	if _, err := colors.Decode([]byte{0x01, 0x01}); !errors.As(err, &unknown) || unknown.Value != 0x0101 {
+		t.Fatalf("expected *UnknownEnumError for 0x0101, got %v", err)
+	}
+
+	if _, err := colors.Decode([]byte{0x01}); err == nil {
+		t.Fatal("expected error for wrong width")
+	}
+
+	if v, err := colors.Parse("red"); err != nil || v != enumTestRed {
+		t.Fatalf("Parse(red) = %d (%v)", v, err)
+	}
+	if _, err := colors.Parse("blue"); err == nil {
+		t.Fatal("expected error for unknown name")
+	}
+}
+
+func TestEnumFallback(t *testing.T) {
+	colors, err := NewEnum("Color", 1, map[enumTestColor]string{enumTestRed: "red"}, WithEnumFallback(enumTestUnknown))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c, err := colors.Decode([]byte{0x7f}); err != nil || c != enumTestUnknown {
+		t.Fatalf("expected fallback, got %d (%v)", c, err)
+	}
+
+	// fallback is for decoding only
+	if _, err := colors.Encode(enumTestColor(0x7f)); err == nil {
+		t.Fatal("expected error encoding unknown value")
+	}
+}
+
+func TestNewEnumErrors(t *testing.T) {
+	if _, err := NewEnum("Big", 1, map[uint16]string{256: "too big"}); err == nil {
+		t.Fatal("expected error for constant not fitting width")
+	}
+	if _, err := NewEnum("Dup", 1, map[uint8]string{1: "x", 2: "x"}); err == nil {
+		t.Fatal("expected error for duplicated name")
+	}
+	if _, err := NewEnum("Wide", 9, map[uint8]string{}); err == nil {
+		t.Fatal("expected error for width 9")
+	}
+}
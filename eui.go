@@ -0,0 +1,31 @@
+package bytecast
+
+import "fmt"
+
+// MACToEUI64 converts 6-byte EUI-48 (MAC address) to 8-byte EUI-64 by inserting 0xFF 0xFE
+// between OUI and device identifier: 00:1A:2B:3C:4D:5E → 00:1A:2B:FF:FE:3C:4D:5E.
+func MACToEUI64(mac [6]byte) [8]byte {
+	return [8]byte{mac[0], mac[1], mac[2], 0xFF, 0xFE, mac[3], mac[4], mac[5]}
+}
+
+// EUI64ToMAC is inverse of MACToEUI64, returns error if EUI-64 was not derived from EUI-48 (no 0xFF 0xFE in the middle).
+func EUI64ToMAC(eui [8]byte) ([6]byte, error) {
+	if eui[3] != 0xFF || eui[4] != 0xFE {
+		return [6]byte{}, fmt.Errorf("EUI-64 % X is not derived from EUI-48, expected FF FE in bytes 3-4", eui[:])
+	}
+	return [6]byte{eui[0], eui[1], eui[2], eui[5], eui[6], eui[7]}, nil
+}
+
+// MACToModifiedEUI64 converts MAC address to modified EUI-64 used as IPv6 interface identifier (RFC 4291 appendix A):
+// EUI-64 with universal/local bit (0x02 of the first byte) inverted, 00:1A:2B:3C:4D:5E → 02:1A:2B:FF:FE:3C:4D:5E.
+func MACToModifiedEUI64(mac [6]byte) [8]byte {
+	eui := MACToEUI64(mac)
+	eui[0] ^= 0x02
+	return eui
+}
+
+// ModifiedEUI64ToMAC is inverse of MACToModifiedEUI64, e.g. to recover MAC from SLAAC IPv6 address (last 8 bytes).
+func ModifiedEUI64ToMAC(id [8]byte) ([6]byte, error) {
+	id[0] ^= 0x02
+	return EUI64ToMAC(id)
+}
@@ -0,0 +1,56 @@
+package bytecast
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestEUI64(t *testing.T) {
+	cases := []struct {
+		mac      [6]byte
+		eui      [8]byte
+		modified [8]byte
+	}{
+		{
+			[6]byte{0x00, 0x1A, 0x2B, 0x3C, 0x4D, 0x5E},
+			[8]byte{0x00, 0x1A, 0x2B, 0xFF, 0xFE, 0x3C, 0x4D, 0x5E},
+			[8]byte{0x02, 0x1A, 0x2B, 0xFF, 0xFE, 0x3C, 0x4D, 0x5E},
+		},
+		{
+			// locally administered MAC gets U/L bit cleared
+			[6]byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			[8]byte{0x02, 0x00, 0x00, 0xFF, 0xFE, 0x00, 0x00, 0x01},
+			[8]byte{0x00, 0x00, 0x00, 0xFF, 0xFE, 0x00, 0x00, 0x01},
+		},
+	}
+	for _, c := range cases {
+		if got := MACToEUI64(c.mac); got != c.eui {
+			t.Errorf("MACToEUI64(% X) = % X, expected % X", c.mac, got, c.eui)
+		}
+		if got := MACToModifiedEUI64(c.mac); got != c.modified {
+			t.Errorf("MACToModifiedEUI64(% X) = % X, expected % X", c.mac, got, c.modified)
+		}
+		if got, err := EUI64ToMAC(c.eui); err != nil || got != c.mac {
+			t.Errorf("EUI64ToMAC(% X) = % X (%v), expected % X", c.eui, got, err, c.mac)
+		}
+		if got, err := ModifiedEUI64ToMAC(c.modified); err != nil || got != c.mac {
+			t.Errorf("ModifiedEUI64ToMAC(% X) = % X (%v), expected % X", c.modified, got, err, c.mac)
+		}
+	}
+
+	if _, err := EUI64ToMAC([8]byte{0, 1, 2, 3, 4, 5, 6, 7}); err == nil {
+		t.Error("expected error for EUI-64 without FF FE")
+	}
+}
+
+func TestModifiedEUI64FromSLAAC(t *testing.T) {
+	ip := netip.MustParseAddr("fe80::21a:2bff:fe3c:4d5e").As16()
+
+	mac, err := ModifiedEUI64ToMAC([8]byte(ip[8:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mac != [6]byte{0x00, 0x1A, 0x2B, 0x3C, 0x4D, 0x5E} {
+		t.Errorf("unexpected MAC % X", mac)
+	}
+}
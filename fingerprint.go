@@ -0,0 +1,55 @@
+package bytecast
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Fingerprint returns SHA-256 of the wire layout of struct type of v (see SchemaOf for accepted v),
+// so producer and consumer can compare it at handshake time instead of discovering mismatch on decode.
+// See Schema.Fingerprint for what is hashed.
+func Fingerprint(v any) ([32]byte, error) {
+	s, err := SchemaOf(v)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return s.Fingerprint(), nil
+}
+
+// Fingerprint64 is Fingerprint truncated to first 8 bytes, for compact headers.
+func Fingerprint64(v any) (uint64, error) {
+	h, err := Fingerprint(v)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(h[:8]), nil
+}
+
+// Fingerprint
+//
+//	Returns SHA-256 over ordered list of fields with their names, offsets, widths, kinds and byte order,
+//	and the record size. Go type name and field types beyond wire kind are not hashed, so renaming a type
+//	or switching int32 field to its named alias keeps the fingerprint, while renaming, reordering or
+//	resizing a field changes it. Default values (tag option default) are not part of the layout,
+//	`bytecastspec` of float fields is, as it defines meaning of the bytes.
+func (s *Schema) Fingerprint() [32]byte {
+	buf := binary.BigEndian.AppendUint64(nil, uint64(s.size))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(s.fields)))
+
+	for _, f := range s.fields {
+		buf = appendUvarintString(buf, f.Name)
+		buf = binary.BigEndian.AppendUint64(buf, uint64(f.Offset))
+		buf = binary.BigEndian.AppendUint64(buf, uint64(f.Width))
+		buf = append(buf, byte(f.Kind))
+		if f.LittleEndian {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+		if f.spec != "" {
+			buf = appendUvarintString(buf, f.spec)
+		}
+	}
+
+	return sha256.Sum256(buf)
+}
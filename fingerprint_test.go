@@ -0,0 +1,83 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+type fingerprintTestV1 struct {
+	ID    uint32
+	Flags uint8
+}
+
+type fingerprintTestRenamed struct {
+	ID    uint32
+	Flags uint8
+}
+
+type fingerprintTestWider struct {
+	ID    uint32
+	Flags uint16
+}
+
+type fingerprintTestReordered struct {
+	Flags uint8
+	ID    uint32
+}
+
+type fingerprintTestFieldName struct {
+	Key   uint32
+	Flags uint8
+}
+
+type fingerprintTestLE struct {
+	ID    uint32 `bytecast:"le"`
+	Flags uint8
+}
+
+type fingerprintTestSigned struct {
+	ID    int32
+	Flags uint8
+}
+
+func TestFingerprint(t *testing.T) {
+	base, err := Fingerprint(fingerprintTestV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if h, _ := Fingerprint(&fingerprintTestV1{}); h != base {
+		t.Error("expected pointer and value to have the same fingerprint")
+	}
+	if h, _ := Fingerprint(reflect.TypeFor[fingerprintTestRenamed]()); h != base {
+		t.Error("expected renamed type with the same layout to have the same fingerprint")
+	}
+
+	s, _ := SchemaOf(fingerprintTestV1{})
+	if s.Fingerprint() != base {
+		t.Error("expected Schema.Fingerprint to match Fingerprint")
+	}
+
+	for _, v := range []any{fingerprintTestWider{}, fingerprintTestReordered{}, fingerprintTestFieldName{}, fingerprintTestLE{}, fingerprintTestSigned{}} {
+		h, err := Fingerprint(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h == base {
+			t.Errorf("expected %T to have different fingerprint", v)
+		}
+	}
+
+	h64, err := Fingerprint64(fingerprintTestV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h64 != binary.BigEndian.Uint64(base[:8]) {
+		t.Errorf("expected Fingerprint64 to be first 8 bytes of Fingerprint, got %x", h64)
+	}
+
+	if _, err := Fingerprint(42); err == nil {
+		t.Error("expected error for non-struct type")
+	}
+}
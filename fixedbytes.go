@@ -0,0 +1,156 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Fixed-size byte types for hashes and IDs: Bytes8, Bytes16, Bytes20, Bytes32 (see bytes32.go), Bytes64.
+//
+// Go has no generics over array length, so every type (Bytes32 included) is a thin wrapper
+// over the shared slice helpers below - all sizes have the same API and behave exactly the same:
+//
+//	BytesNFromSlice / BytesNFromHex / BytesNFromBigInt / BytesNFromInt64 - constructors
+//	Hex, String, Bytes, BigInt (signed), BigUint, Int64, IsZero, Equal, Compare - methods
+
+type Bytes8 [8]byte
+type Bytes16 [16]byte
+type Bytes20 [20]byte
+type Bytes64 [64]byte
+
+func Bytes8FromSlice(b []byte) (out Bytes8, err error) { return out, fixedFromSlice(out[:], b) }
+func Bytes8FromHex(s string) (out Bytes8, err error)   { return out, fixedFromHex(out[:], s) }
+func Bytes8FromBigInt(x *big.Int) (out Bytes8, err error) {
+	return out, fixedFromBigInt(out[:], x)
+}
+func Bytes8FromInt64(v int64) (out Bytes8) {
+	fixedFromInt64(out[:], v)
+	return out
+}
+
+func (b Bytes8) Hex() string           { return fixedHex(b[:]) }
+func (b Bytes8) String() string        { return fixedHex(b[:]) }
+func (b Bytes8) Bytes() []byte         { return bytes.Clone(b[:]) }
+func (b Bytes8) BigInt() *big.Int      { return BigIntFromBytes(b[:]) }
+func (b Bytes8) BigUint() *big.Int     { return new(big.Int).SetBytes(b[:]) }
+func (b Bytes8) Int64() (int64, error) { return fixedInt64(b[:]) }
+func (b Bytes8) IsZero() bool          { return b == Bytes8{} }
+func (b Bytes8) Equal(o Bytes8) bool   { return b == o }
+func (b Bytes8) Compare(o Bytes8) int  { return bytes.Compare(b[:], o[:]) }
+
+func Bytes16FromSlice(b []byte) (out Bytes16, err error) { return out, fixedFromSlice(out[:], b) }
+func Bytes16FromHex(s string) (out Bytes16, err error)   { return out, fixedFromHex(out[:], s) }
+func Bytes16FromBigInt(x *big.Int) (out Bytes16, err error) {
+	return out, fixedFromBigInt(out[:], x)
+}
+func Bytes16FromInt64(v int64) (out Bytes16) {
+	fixedFromInt64(out[:], v)
+	return out
+}
+
+func (b Bytes16) Hex() string           { return fixedHex(b[:]) }
+func (b Bytes16) String() string        { return fixedHex(b[:]) }
+func (b Bytes16) Bytes() []byte         { return bytes.Clone(b[:]) }
+func (b Bytes16) BigInt() *big.Int      { return BigIntFromBytes(b[:]) }
+func (b Bytes16) BigUint() *big.Int     { return new(big.Int).SetBytes(b[:]) }
+func (b Bytes16) Int64() (int64, error) { return fixedInt64(b[:]) }
+func (b Bytes16) IsZero() bool          { return b == Bytes16{} }
+func (b Bytes16) Equal(o Bytes16) bool  { return b == o }
+func (b Bytes16) Compare(o Bytes16) int { return bytes.Compare(b[:], o[:]) }
+
+func Bytes20FromSlice(b []byte) (out Bytes20, err error) { return out, fixedFromSlice(out[:], b) }
+func Bytes20FromHex(s string) (out Bytes20, err error)   { return out, fixedFromHex(out[:], s) }
+func Bytes20FromBigInt(x *big.Int) (out Bytes20, err error) {
+	return out, fixedFromBigInt(out[:], x)
+}
+func Bytes20FromInt64(v int64) (out Bytes20) {
+	fixedFromInt64(out[:], v)
+	return out
+}
+
+func (b Bytes20) Hex() string           { return fixedHex(b[:]) }
+func (b Bytes20) String() string        { return fixedHex(b[:]) }
+func (b Bytes20) Bytes() []byte         { return bytes.Clone(b[:]) }
+func (b Bytes20) BigInt() *big.Int      { return BigIntFromBytes(b[:]) }
+func (b Bytes20) BigUint() *big.Int     { return new(big.Int).SetBytes(b[:]) }
+func (b Bytes20) Int64() (int64, error) { return fixedInt64(b[:]) }
+func (b Bytes20) IsZero() bool          { return b == Bytes20{} }
+func (b Bytes20) Equal(o Bytes20) bool  { return b == o }
+func (b Bytes20) Compare(o Bytes20) int { return bytes.Compare(b[:], o[:]) }
+
+func Bytes64FromSlice(b []byte) (out Bytes64, err error) { return out, fixedFromSlice(out[:], b) }
+func Bytes64FromHex(s string) (out Bytes64, err error)   { return out, fixedFromHex(out[:], s) }
+func Bytes64FromBigInt(x *big.Int) (out Bytes64, err error) {
+	return out, fixedFromBigInt(out[:], x)
+}
+func Bytes64FromInt64(v int64) (out Bytes64) {
+	fixedFromInt64(out[:], v)
+	return out
+}
+
+func (b Bytes64) Hex() string           { return fixedHex(b[:]) }
+func (b Bytes64) String() string        { return fixedHex(b[:]) }
+func (b Bytes64) Bytes() []byte         { return bytes.Clone(b[:]) }
+func (b Bytes64) BigInt() *big.Int      { return BigIntFromBytes(b[:]) }
+func (b Bytes64) BigUint() *big.Int     { return new(big.Int).SetBytes(b[:]) }
+func (b Bytes64) Int64() (int64, error) { return fixedInt64(b[:]) }
+func (b Bytes64) IsZero() bool          { return b == Bytes64{} }
+func (b Bytes64) Equal(o Bytes64) bool  { return b == o }
+func (b Bytes64) Compare(o Bytes64) int { return bytes.Compare(b[:], o[:]) }
+
+func fixedFromSlice(dst, src []byte) error {
+	if len(src) != len(dst) {
+		return fmt.Errorf("expected exactly %d bytes, but got %d bytes", len(dst), len(src))
+	}
+	copy(dst, src)
+	return nil
+}
+
+// fixedFromHex decodes exactly 2*len(dst) hex digits, optionally prefixed with "0x".
+func fixedFromHex(dst []byte, s string) error {
+	raw := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+
+	if len(raw) != 2*len(dst) {
+		return fmt.Errorf("expected %d hex digits, but got %d in %q", 2*len(dst), len(raw), s)
+	}
+
+	if _, err := hex.Decode(dst, []byte(raw)); err != nil {
+		clear(dst)
+		return fmt.Errorf("invalid hex %q: %w", s, err)
+	}
+
+	return nil
+}
+
+// fixedFromBigInt stores x as two's complement value of len(dst) bytes,
+// x must be in [-2^(8n-1), 2^(8n)-1] (negative values are sign-extended, non-negative ones zero-padded).
+func fixedFromBigInt(dst []byte, x *big.Int) error {
+	b, err := BigIntToBytesAndExpandWidth(x, len(dst))
+	if err != nil {
+		return err
+	}
+	copy(dst, b) // always exactly len(dst) bytes
+	return nil
+}
+
+// fixedFromInt64 stores v sign-extended to len(dst) bytes, all fixed-size types are at least 8 bytes wide.
+func fixedFromInt64(dst []byte, v int64) {
+	b, _ := Int64ToBytesAndExpandWidth(v, len(dst))
+	copy(dst, b)
+}
+
+// fixedInt64 interprets b as signed value and returns error if it does not fit in int64.
+func fixedInt64(b []byte) (int64, error) {
+	x := BigIntFromBytes(b)
+	if !x.IsInt64() {
+		return 0, fmt.Errorf("value %s does not fit in int64", x)
+	}
+	return x.Int64(), nil
+}
+
+func fixedHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
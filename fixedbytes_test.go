@@ -0,0 +1,150 @@
+package bytecast
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestFixedBytesHex(t *testing.T) {
+	b8, err := Bytes8FromHex("0x00000000000000ff")
+	if err != nil || b8.Hex() != "0x00000000000000ff" || b8.BigUint().Int64() != 255 {
+		t.Fatalf("Bytes8 round-trip failed: %s (%v)", b8, err)
+	}
+
+	b16, err := Bytes16FromHex(strings.Repeat("ff", 16))
+	if err != nil || b16.BigInt().Int64() != -1 {
+		t.Fatalf("Bytes16 expected -1, got %s (%v)", b16.BigInt(), err)
+	}
+
+	b20, err := Bytes20FromHex("0x" + strings.Repeat("01", 20))
+	if err != nil || b20.IsZero() {
+		t.Fatalf("Bytes20 parse failed: %s (%v)", b20, err)
+	}
+
+	b64, err := Bytes64FromHex(strings.Repeat("00", 64))
+	if err != nil || !b64.IsZero() {
+		t.Fatalf("Bytes64 parse failed: %s (%v)", b64, err)
+	}
+
+	invalid := []func() error{
+		func() error { _, err := Bytes8FromHex("0x00"); return err },
+		func() error { _, err := Bytes16FromHex(strings.Repeat("zz", 16)); return err },
+		func() error { _, err := Bytes20FromHex(strings.Repeat("00", 32)); return err },
+		func() error { _, err := Bytes64FromHex(""); return err },
+	}
+	for i, f := range invalid {
+		if f() == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
+
+func TestFixedBytesBigInt(t *testing.T) {
+	x := big.NewInt(-2)
+
+	b8, err := Bytes8FromBigInt(x)
+	if err != nil || b8.Hex() != "0xfffffffffffffffe" || b8.BigInt().Cmp(x) != 0 {
+		t.Fatalf("Bytes8FromBigInt(-2) = %s (%v)", b8, err)
+	}
+
+	b64, err := Bytes64FromBigInt(x)
+	if err != nil || b64.BigInt().Cmp(x) != 0 {
+		t.Fatalf("Bytes64FromBigInt(-2) = %s (%v)", b64, err)
+	}
+
+	if _, err := Bytes8FromBigInt(new(big.Int).Lsh(big.NewInt(1), 64)); err == nil {
+		t.Fatal("expected error for 2^64 in 8 bytes")
+	}
+
+	minInt64 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 63))
+	b8, err = Bytes8FromBigInt(minInt64)
+	if err != nil || b8.Hex() != "0x8000000000000000" {
+		t.Fatalf("Bytes8FromBigInt(-2^63) = %s (%v)", b8, err)
+	}
+	if b8, err := Bytes8FromBigInt(new(big.Int).Sub(minInt64, big.NewInt(1))); err == nil {
+		t.Fatalf("expected error for -(2^63+1) in 8 bytes, got %s", b8)
+	}
+
+	below := new(big.Int).Sub(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255)), big.NewInt(5))
+	if b32, err := Bytes32FromBigInt(below); err == nil {
+		t.Fatalf("expected error for -(2^255)-5 in 32 bytes, got %s", b32)
+	}
+}
+
+func TestFixedBytesSliceAndCompare(t *testing.T) {
+	a, err := Bytes20FromSlice(make([]byte, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Bytes20FromSlice(make([]byte, 19)); err == nil {
+		t.Fatal("expected error for 19 bytes")
+	}
+
+	b := a
+	b[19] = 1
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Fatal("Compare mismatch")
+	}
+
+	raw := b.Bytes()
+	raw[0] = 0xff
+	if b[0] != 0 {
+		t.Fatal("Bytes must return a copy")
+	}
+}
+
+// fixedBytes lists the method set shared by all fixed-size types, so a size missing a method fails to compile.
+type fixedBytes[T any] interface {
+	Hex() string
+	String() string
+	Bytes() []byte
+	BigInt() *big.Int
+	BigUint() *big.Int
+	Int64() (int64, error)
+	IsZero() bool
+	Equal(T) bool
+	Compare(T) int
+}
+
+var (
+	_ fixedBytes[Bytes8]  = Bytes8{}
+	_ fixedBytes[Bytes16] = Bytes16{}
+	_ fixedBytes[Bytes20] = Bytes20{}
+	_ fixedBytes[Bytes32] = Bytes32{}
+	_ fixedBytes[Bytes64] = Bytes64{}
+)
+
+func TestFixedBytesInt64(t *testing.T) {
+	cases := []struct {
+		name string
+		b    fixedBytes[Bytes16]
+		v    int64
+	}{
+		{"min", Bytes16FromInt64(math.MinInt64), math.MinInt64},
+		{"-1", Bytes16FromInt64(-1), -1},
+		{"max", Bytes16FromInt64(math.MaxInt64), math.MaxInt64},
+	}
+	for _, c := range cases {
+		if v, err := c.b.Int64(); err != nil || v != c.v {
+			t.Errorf("%s: expected %d, got %d (%v)", c.name, c.v, v, err)
+		}
+	}
+
+	if b := Bytes8FromInt64(-2); b.Hex() != "0xfffffffffffffffe" || !b.Equal(Bytes8{0: 0xff, 1: 0xff, 2: 0xff, 3: 0xff, 4: 0xff, 5: 0xff, 6: 0xff, 7: 0xfe}) {
+		t.Errorf("Bytes8FromInt64(-2) = %s", b)
+	}
+	if v, err := Bytes20FromInt64(-7).Int64(); err != nil || v != -7 {
+		t.Errorf("Bytes20 round-trip: got %d (%v)", v, err)
+	}
+	if v, err := Bytes64FromInt64(7).Int64(); err != nil || v != 7 {
+		t.Errorf("Bytes64 round-trip: got %d (%v)", v, err)
+	}
+
+	big64, _ := Bytes64FromBigInt(new(big.Int).Lsh(big.NewInt(1), 63))
+	if _, err := big64.Int64(); err == nil {
+		t.Error("expected error for 2^63")
+	}
+}
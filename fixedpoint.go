@@ -0,0 +1,68 @@
+package bytecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// FixedPointToBytes
+//
+//	Converts float64 value to signed Qm.n fixed-point representation and expands it to specified width.
+//	m is a number of integer bits INCLUDING sign bit, n is a number of fractional bits,
+//	so Q16.16 occupies 32 bits and Q1.31 occupies 32 bits as well.
+//
+//	Rounding: value is scaled by 2^n and rounded to the nearest integer, halfway cases away from zero
+//	(math.Round semantics), e.g. in Q8.0 2.5 → 3 and -2.5 → -3.
+//
+//	NOTE:
+//	m, n => bits
+//	width => bytes
+func FixedPointToBytes(value float64, m int, n int, width int) ([]byte, error) {
+	if err := validateQFormat(m, n); err != nil {
+		return nil, err
+	}
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("value %v cannot be represented in Q%d.%d format", value, m, n)
+	}
+
+	scaled := math.Round(math.Ldexp(value, n))
+
+	// float64 -> int64 conversion is undefined outside int64 range, so check it before conversion
+	if scaled < math.MinInt64 || scaled >= math.MaxInt64 {
+		return nil, fmt.Errorf("value %v does not fit in Q%d.%d format", value, m, n)
+	}
+
+	out, err := IntXXToBytesAndExpandWidth(int64(scaled), m+n, width)
+	if err != nil {
+		return nil, fmt.Errorf("value %v does not fit in Q%d.%d format: %w", value, m, n, err)
+	}
+
+	return out, nil
+}
+
+// FixedPointFromBytes
+//
+//	Takes "bytes" bytes from input, interprets them as signed Qm.n fixed-point value and converts it to float64.
+//
+//	Conversion is exact as long as m+n <= 53 (float64 mantissa size),
+//	wider values are rounded to the nearest representable float64.
+func FixedPointFromBytes(bytes []byte, m int, n int) (float64, error) {
+	if err := validateQFormat(m, n); err != nil {
+		return 0, err
+	}
+
+	raw, err := IntXXFromBytes(bytes, m+n)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Ldexp(float64(raw), -n), nil
+}
+
+func validateQFormat(m int, n int) error {
+	if m < 1 || n < 0 || m+n > 64 {
+		return fmt.Errorf("unsupported Q%d.%d format, need m >= 1, n >= 0 and m+n <= 64", m, n)
+	}
+	return nil
+}
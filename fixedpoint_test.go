@@ -0,0 +1,87 @@
+package bytecast
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestFixedPointToBytes(t *testing.T) {
+	tests := []struct {
+		value float64
+		m     int
+		n     int
+		want  string // hex representation of 4 bytes
+	}{
+		{0, 16, 16, "00000000"},
+		{1.5, 16, 16, "00018000"},
+		{-1.5, 16, 16, "fffe8000"},
+		{32767.99998474121, 16, 16, "7fffffff"},
+		{-32768, 16, 16, "80000000"},
+
+		{0.5, 1, 31, "40000000"},
+		{-1, 1, 31, "80000000"},
+		{-0.25, 1, 31, "e0000000"},
+
+		// rounding: halfway cases away from zero
+		{2.5, 8, 0, "00000003"},
+		{-2.5, 8, 0, "fffffffd"},
+		{0.3, 8, 8, "0000004d"}, // 0.3 * 256 = 76.8 → 77
+	}
+
+	for _, tt := range tests {
+		out, err := FixedPointToBytes(tt.value, tt.m, tt.n, 4)
+		if err != nil {
+			t.Errorf("FixedPointToBytes(%v, Q%d.%d) returned error: %v", tt.value, tt.m, tt.n, err)
+			continue
+		}
+
+		got := fmt.Sprintf("%x", out)
+		if got != tt.want {
+			t.Errorf("FixedPointToBytes(%v, Q%d.%d) = %s; want %s", tt.value, tt.m, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFixedPointToBytesErrors(t *testing.T) {
+	tests := []struct {
+		value float64
+		m     int
+		n     int
+	}{
+		{1, 1, 31}, // Q1.31 max is 1 - 2^-31
+		{32768, 16, 16},
+		{math.NaN(), 16, 16},
+		{math.Inf(1), 16, 16},
+		{1e300, 32, 32},
+		{1, 0, 8},   // no sign bit
+		{1, 32, 33}, // more than 64 bits
+	}
+
+	for _, tt := range tests {
+		_, err := FixedPointToBytes(tt.value, tt.m, tt.n, 8)
+		if err == nil {
+			t.Errorf("FixedPointToBytes(%v, Q%d.%d): expected error", tt.value, tt.m, tt.n)
+		}
+	}
+}
+
+func TestFixedPointRoundTrip(t *testing.T) {
+	cases := []float64{0, 1, -1, 0.5, -0.5, 123.456787109375, -32768, 1.0 / 65536}
+
+	for _, v := range cases {
+		b, err := FixedPointToBytes(v, 16, 16, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := FixedPointFromBytes(b, 16, 16)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != v {
+			t.Fatalf("expected %v got %v", v, got)
+		}
+	}
+}
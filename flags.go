@@ -0,0 +1,221 @@
+package bytecast
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// FlagSet declares named bits of a fixed-width protocol flags field:
+//
+//	perms, _ := NewFlagSet("Perm", 1, map[uint]string{0: "read", 1: "write", 7: "admin"})
+//	f := perms.Empty()
+//	_ = f.Set("read")
+//	_ = f.Set("admin")
+//	f.String()   // "read|admin"
+//	f.ToBytes()  // 0x81
+//
+// Bit 0 is the least significant bit of the big-endian field.
+type FlagSet struct {
+	name         string
+	width        int
+	names        map[uint]string
+	bits         map[string]uint
+	declared     uint64
+	allowUnknown bool
+}
+
+type FlagSetOption func(*FlagSet)
+
+// WithUnknownFlags makes FromBytes keep undeclared bits instead of returning error,
+// for forward compatibility with newer peers.
+func WithUnknownFlags() FlagSetOption {
+	return func(fs *FlagSet) {
+		fs.allowUnknown = true
+	}
+}
+
+// NewFlagSet creates flag set of width bytes (1..8) with given bit positions and their names,
+// every bit must fit in width bytes and names must be unique and must not contain "|".
+func NewFlagSet(name string, width int, names map[uint]string, opts ...FlagSetOption) (*FlagSet, error) {
+	if width < 1 || width > 8 {
+		return nil, fmt.Errorf("unsupported flag set width %d, must be 1..8 bytes", width)
+	}
+
+	fs := &FlagSet{name: name, width: width, names: make(map[uint]string, len(names)), bits: make(map[string]uint, len(names))}
+
+	for bit, n := range names {
+		if bit >= uint(8*width) {
+			return nil, fmt.Errorf("%s flag %s at bit %d does not fit in %d bytes", name, n, bit, width)
+		}
+		if n == "" || strings.Contains(n, "|") {
+			return nil, fmt.Errorf("%s flag name %q at bit %d is invalid", name, n, bit)
+		}
+		if _, ok := fs.bits[n]; ok {
+			return nil, fmt.Errorf("%s flag name %q is used more than once", name, n)
+		}
+		fs.names[bit] = n
+		fs.bits[n] = bit
+		fs.declared |= 1 << bit
+	}
+
+	for _, opt := range opts {
+		opt(fs)
+	}
+
+	return fs, nil
+}
+
+// Width returns encoded width in bytes.
+func (fs *FlagSet) Width() int {
+	return fs.width
+}
+
+// Empty returns flags value with no bits set.
+func (fs *FlagSet) Empty() Flags {
+	return Flags{set: fs}
+}
+
+// Of returns flags value with named flags set.
+func (fs *FlagSet) Of(names ...string) (Flags, error) {
+	f := fs.Empty()
+	for _, n := range names {
+		if err := f.Set(n); err != nil {
+			return Flags{}, err
+		}
+	}
+	return f, nil
+}
+
+// Parse parses "|"-separated flag names as produced by Flags.String, "0" or "" is empty set.
+// Undeclared bits written as hex ("0x10") are accepted only WithUnknownFlags.
+func (fs *FlagSet) Parse(s string) (Flags, error) {
+	f := fs.Empty()
+	if s == "" || s == "0" {
+		return f, nil
+	}
+
+	for _, part := range strings.Split(s, "|") {
+		if strings.HasPrefix(part, "0x") {
+			var v uint64
+			if _, err := fmt.Sscanf(part, "0x%x", &v); err != nil {
+				return Flags{}, fmt.Errorf("invalid %s flags %q", fs.name, part)
+			}
+			f.v |= v
+			continue
+		}
+		if err := f.Set(part); err != nil {
+			return Flags{}, err
+		}
+	}
+
+	return fs.check(f)
+}
+
+// FromBytes decodes exactly Width() bytes. Undeclared bits are an error unless flag set was created WithUnknownFlags.
+func (fs *FlagSet) FromBytes(b []byte) (Flags, error) {
+	if len(b) != fs.width {
+		return Flags{}, fmt.Errorf("expected exactly %d bytes for %s, but got %d bytes", fs.width, fs.name, len(b))
+	}
+
+	v, err := UintXXFromBytes(b, 8*fs.width)
+	if err != nil {
+		return Flags{}, err
+	}
+
+	return fs.check(Flags{set: fs, v: v})
+}
+
+func (fs *FlagSet) check(f Flags) (Flags, error) {
+	if bits.Len64(f.v) > 8*fs.width {
+		return Flags{}, fmt.Errorf("%s flags 0x%x do not fit in %d bytes", fs.name, f.v, fs.width)
+	}
+	if unknown := f.v &^ fs.declared; unknown != 0 && !fs.allowUnknown {
+		return Flags{}, fmt.Errorf("undeclared %s flags 0x%x", fs.name, unknown)
+	}
+	return f, nil
+}
+
+// Flags is a value of FlagSet. Zero value has no flag set and is not bound to any flag set,
+// use FlagSet.Empty instead.
+type Flags struct {
+	set *FlagSet
+	v   uint64
+}
+
+// Set sets named flag.
+func (f *Flags) Set(name string) error {
+	bit, err := f.bit(name)
+	if err != nil {
+		return err
+	}
+	f.v |= 1 << bit
+	return nil
+}
+
+// Clear clears named flag.
+func (f *Flags) Clear(name string) error {
+	bit, err := f.bit(name)
+	if err != nil {
+		return err
+	}
+	f.v &^= 1 << bit
+	return nil
+}
+
+// Has reports whether named flag is set, unknown names report false.
+func (f Flags) Has(name string) bool {
+	bit, err := f.bit(name)
+	return err == nil && f.v&(1<<bit) != 0
+}
+
+// Uint64 returns raw bits.
+func (f Flags) Uint64() uint64 {
+	return f.v
+}
+
+// String returns names of set flags from the lowest bit joined by "|", undeclared bits as one hex value,
+// "0" for empty set.
+func (f Flags) String() string {
+	if f.v == 0 {
+		return "0"
+	}
+
+	var parts []string
+	unknown := f.v
+	for bit := uint(0); bit < 64; bit++ {
+		if f.v&(1<<bit) == 0 || f.set == nil {
+			continue
+		}
+		if n, ok := f.set.names[bit]; ok {
+			parts = append(parts, n)
+			unknown &^= 1 << bit
+		}
+	}
+	if unknown != 0 {
+		parts = append(parts, fmt.Sprintf("0x%x", unknown))
+	}
+
+	return strings.Join(parts, "|")
+}
+
+// ToBytes encodes flags as big-endian field of flag set width.
+func (f Flags) ToBytes() []byte {
+	width := 8
+	if f.set != nil {
+		width = f.set.width
+	}
+	out, _ := UintXXToBytesAndExpandWidth(f.v, 8*width, width)
+	return out
+}
+
+func (f Flags) bit(name string) (uint, error) {
+	if f.set == nil {
+		return 0, fmt.Errorf("flags are not bound to a flag set")
+	}
+	bit, ok := f.set.bits[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown %s flag %q", f.set.name, name)
+	}
+	return bit, nil
+}
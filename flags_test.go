@@ -0,0 +1,108 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func newFlagsTestSet(t *testing.T, opts ...FlagSetOption) *FlagSet {
+	t.Helper()
+	fs, err := NewFlagSet("TCP", 2, map[uint]string{0: "FIN", 1: "SYN", 2: "RST", 3: "PSH", 4: "ACK", 8: "NS"}, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func TestFlags(t *testing.T) {
+	fs := newFlagsTestSet(t)
+
+	cases := []struct {
+		names []string
+		hex   string
+		str   string
+	}{
+		{nil, "0000", "0"},
+		{[]string{"SYN"}, "0002", "SYN"},
+		{[]string{"ACK", "SYN"}, "0012", "SYN|ACK"},
+		{[]string{"NS", "FIN"}, "0101", "FIN|NS"},
+	}
+	for _, c := range cases {
+		f, err := fs.Of(c.names...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(f.ToBytes()); got != c.hex {
+			t.Errorf("%v: ToBytes() = %s, expected %s", c.names, got, c.hex)
+		}
+		if got := f.String(); got != c.str {
+			t.Errorf("%v: String() = %s, expected %s", c.names, got, c.str)
+		}
+
+		back, err := fs.FromBytes(f.ToBytes())
+		if err != nil || back != f {
+			t.Errorf("%v: FromBytes round trip gave %v (%v)", c.names, back, err)
+		}
+		parsed, err := fs.Parse(c.str)
+		if err != nil || parsed != f {
+			t.Errorf("%v: Parse(%q) gave %v (%v)", c.names, c.str, parsed, err)
+		}
+	}
+
+	f := fs.Empty()
+	_ = f.Set("RST")
+	_ = f.Set("PSH")
+	if !f.Has("RST") || f.Has("SYN") || f.Has("bogus") {
+		t.Errorf("unexpected Has results for %s", f)
+	}
+	_ = f.Clear("RST")
+	if f.Has("RST") || f.Uint64() != 0x08 {
+		t.Errorf("expected only PSH after Clear, got %s", f)
+	}
+	if err := f.Set("URG"); err == nil {
+		t.Error("expected error for unknown flag name")
+	}
+}
+
+func TestFlagsUnknownBits(t *testing.T) {
+	if _, err := newFlagsTestSet(t).FromBytes([]byte{0x80, 0x02}); err == nil {
+		t.Error("expected error for undeclared bit")
+	}
+
+	fs := newFlagsTestSet(t, WithUnknownFlags())
+	f, err := fs.FromBytes([]byte{0x80, 0x02})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.String(); got != "SYN|0x8000" {
+		t.Errorf("String() = %s, expected SYN|0x8000", got)
+	}
+	if back, err := fs.Parse(f.String()); err != nil || back != f {
+		t.Errorf("Parse round trip gave %v (%v)", back, err)
+	}
+	if _, err := fs.Parse("0x10000"); err == nil {
+		t.Error("expected error for bits not fitting width")
+	}
+}
+
+func TestNewFlagSetErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		width int
+		names map[uint]string
+	}{
+		{"zero width", 0, nil},
+		{"bit out of width", 1, map[uint]string{8: "X"}},
+		{"duplicate name", 1, map[uint]string{0: "X", 1: "X"}},
+		{"separator in name", 1, map[uint]string{0: "A|B"}},
+		{"empty name", 1, map[uint]string{0: ""}},
+	}
+	for _, c := range cases {
+		if _, err := NewFlagSet("Test", c.width, c.names); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+	}
+	if _, err := newFlagsTestSet(t).FromBytes([]byte{1}); err == nil {
+		t.Error("expected error for wrong input length")
+	}
+}
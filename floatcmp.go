@@ -0,0 +1,104 @@
+package bytecast
+
+import (
+	"math"
+)
+
+// Canonical NaN bit patterns used by CanonicalFloat64 / CanonicalFloat32 (quiet NaN, zero payload).
+const (
+	CanonicalNaN64 uint64 = 0x7ff8000000000000
+	CanonicalNaN32 uint32 = 0x7fc00000
+)
+
+// Float64BitsEqual reports whether a and b have identical IEEE 754 bit patterns. Unlike ==,
+// it tells 0.0 from -0.0 and treats NaN with the same payload as equal to itself,
+// which is what round-trip tests of encoded floats need.
+func Float64BitsEqual(a, b float64) bool {
+	return math.Float64bits(a) == math.Float64bits(b)
+}
+
+// Float32BitsEqual is Float64BitsEqual for float32.
+func Float32BitsEqual(a, b float32) bool {
+	return math.Float32bits(a) == math.Float32bits(b)
+}
+
+// Float64ULPDistance returns number of representable float64 values between a and b
+// (units in the last place), 0 for equal values. 0.0 and -0.0 are 0 ULPs apart.
+// If either value is NaN, the distance is math.MaxUint64.
+func Float64ULPDistance(a, b float64) uint64 {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.MaxUint64
+	}
+
+	x, y := orderedFloat64Bits(a), orderedFloat64Bits(b)
+	if x > y {
+		return uint64(x - y)
+	}
+	return uint64(y - x)
+}
+
+// Float32ULPDistance is Float64ULPDistance for float32, NaN yields math.MaxUint32.
+func Float32ULPDistance(a, b float32) uint32 {
+	if a != a || b != b {
+		return math.MaxUint32
+	}
+
+	x, y := orderedFloat32Bits(a), orderedFloat32Bits(b)
+	if x > y {
+		return uint32(x - y)
+	}
+	return uint32(y - x)
+}
+
+// Float64WithinULP reports whether a and b are at most maxULP representable values apart.
+// NaN is never within any distance, not even of itself.
+func Float64WithinULP(a, b float64, maxULP uint64) bool {
+	return !math.IsNaN(a) && !math.IsNaN(b) && Float64ULPDistance(a, b) <= maxULP
+}
+
+// Float32WithinULP is Float64WithinULP for float32.
+func Float32WithinULP(a, b float32, maxULP uint32) bool {
+	return a == a && b == b && Float32ULPDistance(a, b) <= maxULP
+}
+
+// CanonicalFloat64 maps -0.0 to 0.0 and every NaN (any sign and payload) to the NaN with
+// CanonicalNaN64 bits, other values are returned as is. Canonicalize values before encoding
+// when equal numbers must produce equal bytes (hashing, signing, deduplication).
+func CanonicalFloat64(x float64) float64 {
+	switch {
+	case x == 0:
+		return 0
+	case math.IsNaN(x):
+		return math.Float64frombits(CanonicalNaN64)
+	}
+	return x
+}
+
+// CanonicalFloat32 is CanonicalFloat64 for float32.
+func CanonicalFloat32(x float32) float32 {
+	switch {
+	case x == 0:
+		return 0
+	case x != x:
+		return math.Float32frombits(CanonicalNaN32)
+	}
+	return x
+}
+
+// orderedFloat64Bits maps float64 bits to int64 so that integer order matches float order
+// and adjacent floats map to adjacent integers; -0.0 and 0.0 both map to 0.
+func orderedFloat64Bits(x float64) int64 {
+	b := int64(math.Float64bits(x))
+	if b < 0 {
+		return math.MinInt64 - b
+	}
+	return b
+}
+
+func orderedFloat32Bits(x float32) int64 {
+	b := int32(math.Float32bits(x))
+	if b < 0 {
+		return int64(math.MinInt32) - int64(b)
+	}
+	return int64(b)
+}
@@ -0,0 +1,93 @@
+package bytecast
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloatBitsEqual(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	nan := math.NaN()
+
+	cases := []struct {
+		a, b float64
+		want bool
+	}{
+		{1.5, 1.5, true},
+		{0, negZero, false},
+		{nan, nan, true},
+		{nan, math.Float64frombits(CanonicalNaN64), false},
+		{1, math.Nextafter(1, 2), false},
+	}
+	for _, c := range cases {
+		if got := Float64BitsEqual(c.a, c.b); got != c.want {
+			t.Errorf("Float64BitsEqual(%v, %v) = %v, expected %v", c.a, c.b, got, c.want)
+		}
+	}
+
+	if Float32BitsEqual(0, float32(negZero)) || !Float32BitsEqual(float32(nan), float32(nan)) || Float32BitsEqual(1, math.Nextafter32(1, 2)) {
+		t.Error("unexpected Float32BitsEqual result")
+	}
+}
+
+func TestFloatULPDistance(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	smallest := math.SmallestNonzeroFloat64
+
+	cases := []struct {
+		a, b float64
+		want uint64
+	}{
+		{1, 1, 0},
+		{0, negZero, 0},
+		{1, math.Nextafter(1, 2), 1},
+		{math.Nextafter(1, 0), math.Nextafter(1, 2), 2},
+		{-smallest, smallest, 2},
+		{math.MaxFloat64, math.Inf(1), 1},
+		{math.NaN(), 1, math.MaxUint64},
+	}
+	for _, c := range cases {
+		if got := Float64ULPDistance(c.a, c.b); got != c.want {
+			t.Errorf("Float64ULPDistance(%v, %v) = %d, expected %d", c.a, c.b, got, c.want)
+		}
+		if got := Float64ULPDistance(c.b, c.a); got != c.want {
+			t.Errorf("Float64ULPDistance(%v, %v) = %d, expected %d", c.b, c.a, got, c.want)
+		}
+	}
+
+	if d := Float32ULPDistance(-math.SmallestNonzeroFloat32, math.SmallestNonzeroFloat32); d != 2 {
+		t.Errorf("expected 2 ULPs across zero, got %d", d)
+	}
+	if d := Float32ULPDistance(1, math.Nextafter32(1, 2)); d != 1 {
+		t.Errorf("expected 1 ULP, got %d", d)
+	}
+
+	if !Float64WithinULP(0.1+0.2, 0.3, 1) || Float64WithinULP(1, 1.0001, 4) || Float64WithinULP(math.NaN(), math.NaN(), 10) {
+		t.Error("unexpected Float64WithinULP result")
+	}
+	if !Float32WithinULP(float32(0.1)+float32(0.2), 0.3, 1) || Float32WithinULP(float32(math.NaN()), 0, 1) {
+		t.Error("unexpected Float32WithinULP result")
+	}
+}
+
+func TestCanonicalFloat(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	payloadNaN := math.Float64frombits(0xfff0000000000123)
+
+	if got := math.Float64bits(CanonicalFloat64(negZero)); got != 0 {
+		t.Errorf("expected +0, got %#x", got)
+	}
+	if got := math.Float64bits(CanonicalFloat64(payloadNaN)); got != CanonicalNaN64 {
+		t.Errorf("expected canonical NaN, got %#x", got)
+	}
+	if got := CanonicalFloat64(-1.25); got != -1.25 {
+		t.Errorf("expected value unchanged, got %v", got)
+	}
+
+	if got := math.Float32bits(CanonicalFloat32(float32(negZero))); got != 0 {
+		t.Errorf("expected +0, got %#x", got)
+	}
+	if got := math.Float32bits(CanonicalFloat32(math.Float32frombits(0xffc00001))); got != CanonicalNaN32 {
+		t.Errorf("expected canonical NaN, got %#x", got)
+	}
+}
@@ -0,0 +1,66 @@
+package bytecast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNonFiniteFloat is returned by float encoders configured with WithFiniteOnly for ±Inf and NaN.
+var ErrNonFiniteFloat = errors.New("non-finite float")
+
+// FloatOption controls how float encoders (AppendSortableFloat, TupleKey, ModbusFloat32ToRegisters)
+// treat NaN and infinities. Without options values are encoded bit-exact.
+type FloatOption func(*floatPolicy)
+
+type floatPolicy struct {
+	canonicalNaN bool
+	finiteOnly   bool
+}
+
+// WithCanonicalNaN replaces every NaN (any sign and payload) with the quiet NaN of CanonicalNaN64 /
+// CanonicalNaN32 bits before encoding, so hashes over encoded floats do not depend on NaN origin.
+func WithCanonicalNaN() FloatOption {
+	return func(p *floatPolicy) {
+		p.canonicalNaN = true
+	}
+}
+
+// WithFiniteOnly makes encoding of ±Inf and NaN fail with ErrNonFiniteFloat.
+func WithFiniteOnly() FloatOption {
+	return func(p *floatPolicy) {
+		p.finiteOnly = true
+	}
+}
+
+func newFloatPolicy(opts []FloatOption) floatPolicy {
+	var p floatPolicy
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p
+}
+
+func (p floatPolicy) apply64(v float64) (float64, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		if p.finiteOnly {
+			return 0, fmt.Errorf("%w: %v", ErrNonFiniteFloat, v)
+		}
+		if p.canonicalNaN && math.IsNaN(v) {
+			return math.Float64frombits(CanonicalNaN64), nil
+		}
+	}
+	return v, nil
+}
+
+func (p floatPolicy) apply32(v float32) (float32, error) {
+	if v != v || math.IsInf(float64(v), 0) {
+		if p.finiteOnly {
+			return 0, fmt.Errorf("%w: %v", ErrNonFiniteFloat, v)
+		}
+		if p.canonicalNaN && v != v {
+			return math.Float32frombits(CanonicalNaN32), nil
+		}
+	}
+	return v, nil
+}
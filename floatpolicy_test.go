@@ -0,0 +1,88 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFloatPolicy(t *testing.T) {
+	payloadNaN := math.Float64frombits(0xfff0000000000001)
+	canonicalKey := "fff8000000000000" // sortable bits of CanonicalNaN64
+
+	cases := []struct {
+		name  string
+		value float64
+		opts  []FloatOption
+		hex   string
+		err   error
+	}{
+		{"bit-exact NaN", payloadNaN, nil, "000ffffffffffffe", nil},
+		{"canonical NaN", payloadNaN, []FloatOption{WithCanonicalNaN()}, canonicalKey, nil},
+		{"canonical keeps Inf", math.Inf(1), []FloatOption{WithCanonicalNaN()}, "fff0000000000000", nil},
+		{"canonical keeps -0", math.Copysign(0, -1), []FloatOption{WithCanonicalNaN()}, "7fffffffffffffff", nil},
+		{"finite only NaN", math.NaN(), []FloatOption{WithFiniteOnly()}, "", ErrNonFiniteFloat},
+		{"finite only -Inf", math.Inf(-1), []FloatOption{WithFiniteOnly(), WithCanonicalNaN()}, "", ErrNonFiniteFloat},
+		{"finite only 1.5", 1.5, []FloatOption{WithFiniteOnly()}, "bff8000000000000", nil},
+	}
+
+	for _, c := range cases {
+		dst := []byte{0xAA}
+		out, err := AppendSortableFloat(dst, c.value, c.opts...)
+		if !errors.Is(err, c.err) {
+			t.Fatalf("%s: expected error %v, got %v", c.name, c.err, err)
+		}
+		if got := hex.EncodeToString(out[1:]); got != c.hex {
+			t.Errorf("%s: expected %s, got %s", c.name, c.hex, got)
+		}
+
+		k := NewTupleKey(c.opts...).PutFloat64(c.value)
+		if !errors.Is(k.Err(), c.err) {
+			t.Fatalf("%s: TupleKey: expected error %v, got %v", c.name, c.err, k.Err())
+		}
+		if c.err == nil && hex.EncodeToString(k.Bytes()) != "05"+c.hex {
+			t.Errorf("%s: TupleKey: expected 05%s, got %x", c.name, c.hex, k.Bytes())
+		}
+		if c.err != nil && k.Len() != 0 {
+			t.Errorf("%s: TupleKey: rejected float must not be appended, got %x", c.name, k.Bytes())
+		}
+	}
+}
+
+func TestFloat32Policy(t *testing.T) {
+	payloadNaN := math.Float32frombits(0x7f800001)
+
+	out, err := AppendSortableFloat32(nil, payloadNaN, WithCanonicalNaN())
+	if err != nil || hex.EncodeToString(out) != "ffc00000" {
+		t.Fatalf("expected ffc00000, got %x (%v)", out, err)
+	}
+
+	regs, err := ModbusFloat32ToRegisters(payloadNaN, ModbusABCD, WithCanonicalNaN())
+	if err != nil || regs != [2]uint16{0x7fc0, 0x0000} {
+		t.Fatalf("expected canonical NaN registers, got %04x (%v)", regs, err)
+	}
+	regs, err = ModbusFloat32ToRegisters(payloadNaN, ModbusABCD)
+	if err != nil || regs != [2]uint16{0x7f80, 0x0001} {
+		t.Fatalf("expected bit-exact NaN registers, got %04x (%v)", regs, err)
+	}
+	if _, err := ModbusFloat32ToRegisters(float32(math.Inf(1)), ModbusABCD, WithFiniteOnly()); !errors.Is(err, ErrNonFiniteFloat) {
+		t.Fatalf("expected ErrNonFiniteFloat, got %v", err)
+	}
+}
+
+func TestTupleKeyErrReset(t *testing.T) {
+	k := NewTupleKey(WithFiniteOnly())
+	k.PutInt(1).PutFloat64(math.NaN()).PutInt(2)
+	if k.Err() == nil {
+		t.Fatal("expected error for NaN component")
+	}
+
+	k.Reset()
+	if k.Err() != nil || k.Len() != 0 {
+		t.Fatalf("Reset must clear key and error, got %x (%v)", k.Bytes(), k.Err())
+	}
+	if k.PutFloat64(math.Inf(1)).Err() == nil {
+		t.Fatal("Reset must keep float policy")
+	}
+}
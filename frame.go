@@ -0,0 +1,189 @@
+package bytecast
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// ErrFrameTooLarge is returned when frame length exceeds the allowed maximum.
+var ErrFrameTooLarge = errors.New("frame too large")
+
+// WriteFrame writes payload prefixed with its length as 4-byte big-endian uint32:
+//
+//	[ len (4 bytes) | payload (len bytes) ]
+//
+// This is the standard way to stream multiple bytecast records over one connection,
+// read them back with ReadFrame.
+func WriteFrame(w io.Writer, payload []byte) error {
+	if uint64(len(payload)) > math.MaxUint32 {
+		return fmt.Errorf("%w: payload of %d bytes does not fit in uint32 length prefix", ErrFrameTooLarge, len(payload))
+	}
+
+	// single write, so header and payload are not split into separate syscalls
+	frame := make([]byte, 4+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	copy(frame[4:], payload)
+
+	_, err := w.Write(frame)
+	return err
+}
+
+// ReadFrame reads one frame written by WriteFrame and returns its payload.
+//
+// Declared length is validated against maxSize BEFORE allocating the payload buffer,
+// so hostile length prefix cannot trigger huge allocation; such frames yield ErrFrameTooLarge.
+// io.EOF is returned only if stream ends cleanly between frames,
+// stream ending inside a frame yields io.ErrUnexpectedEOF.
+func ReadFrame(r io.Reader, maxSize int) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[:])
+	if uint64(length) > uint64(max(maxSize, 0)) {
+		return nil, fmt.Errorf("%w: declared length %d exceeds maximum %d", ErrFrameTooLarge, length, maxSize)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// ChecksumError is returned by FrameReader when frame checksum does not match its payload.
+type ChecksumError struct {
+	Offset   int64 // stream offset of the bad frame (its length prefix)
+	Expected uint32
+	Actual   uint32
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("frame checksum mismatch at offset %d: expected 0x%08x, got 0x%08x", e.Offset, e.Expected, e.Actual)
+}
+
+// WriteFrameContext is WriteFrame which can be cancelled by ctx, see ReadFrameContext.
+func WriteFrameContext(ctx context.Context, w io.Writer, payload []byte) error {
+	return withWriteContext(ctx, w, func() error {
+		return WriteFrame(w, payload)
+	})
+}
+
+// ReadFrameContext is ReadFrame which can be cancelled by ctx. Blocked read is interrupted only if
+// r supports read deadlines (e.g. net.Conn), otherwise ctx is checked before reading.
+// Cancellation returns ctx.Err(); the stream can not be used for further frames after that.
+func ReadFrameContext(ctx context.Context, r io.Reader, maxSize int) ([]byte, error) {
+	var payload []byte
+	err := withReadContext(ctx, r, func() (err error) {
+		payload, err = ReadFrame(r, maxSize)
+		return err
+	})
+	return payload, err
+}
+
+// WriteFrameCRC32 writes frame like WriteFrame, but appends CRC-32 of payload (4 bytes, big-endian):
+//
+//	[ len (4 bytes) | payload | crc32(payload) (4 bytes) ]
+//
+// Length prefix covers payload and checksum. Read such frames with FrameReader and WithCRC32 option.
+func WriteFrameCRC32(w io.Writer, payload []byte, table *crc32.Table) error {
+	body := make([]byte, len(payload), len(payload)+4)
+	copy(body, payload)
+	body = binary.BigEndian.AppendUint32(body, crc32.Checksum(payload, table))
+
+	return WriteFrame(w, body)
+}
+
+// FrameReader reads length-prefixed frames (see WriteFrame) from stream, keeping track of stream offset,
+// optionally verifying and stripping trailing checksum.
+type FrameReader struct {
+	r        io.Reader
+	maxSize  int
+	crcTable *crc32.Table
+	offset   int64
+	limits   Limits
+}
+
+type FrameReaderOption func(*FrameReader)
+
+// WithCRC32 makes FrameReader verify trailing CRC-32 (written by WriteFrameCRC32) of every frame
+// and strip it before returning the payload. Mismatch is reported as *ChecksumError.
+func WithCRC32(table *crc32.Table) FrameReaderOption {
+	return func(f *FrameReader) {
+		f.crcTable = table
+	}
+}
+
+// NewFrameReader creates FrameReader accepting payloads up to maxSize bytes (checksum not included).
+func NewFrameReader(r io.Reader, maxSize int, opts ...FrameReaderOption) *FrameReader {
+	f := &FrameReader{r: r, maxSize: maxSize}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// SetLimits sets limits for subsequent frames, MaxFrameSize further caps maxSize given to NewFrameReader.
+func (f *FrameReader) SetLimits(l Limits) {
+	f.limits = l
+}
+
+// Offset returns stream offset of the next frame.
+func (f *FrameReader) Offset() int64 {
+	return f.offset
+}
+
+// ReadFrameContext is ReadFrame which can be cancelled by ctx, see package-level ReadFrameContext.
+func (f *FrameReader) ReadFrameContext(ctx context.Context) ([]byte, error) {
+	var payload []byte
+	err := withReadContext(ctx, f.r, func() (err error) {
+		payload, err = f.ReadFrame()
+		return err
+	})
+	return payload, err
+}
+
+// ReadFrame reads next frame and returns its payload.
+func (f *FrameReader) ReadFrame() ([]byte, error) {
+	frameOffset := f.offset
+
+	maxSize := f.limits.frameSize(f.maxSize)
+	if f.crcTable != nil {
+		maxSize += 4
+	}
+
+	body, err := ReadFrame(f.r, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	f.offset += 4 + int64(len(body))
+
+	if f.crcTable == nil {
+		return body, nil
+	}
+
+	if len(body) < 4 {
+		return nil, fmt.Errorf("frame at offset %d too short to contain checksum: %d bytes", frameOffset, len(body))
+	}
+
+	payload := body[:len(body)-4]
+	expected := binary.BigEndian.Uint32(body[len(body)-4:])
+	actual := crc32.Checksum(payload, f.crcTable)
+
+	if expected != actual {
+		return nil, &ChecksumError{Offset: frameOffset, Expected: expected, Actual: actual}
+	}
+
+	return payload, nil
+}
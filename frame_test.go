@@ -0,0 +1,147 @@
+package bytecast
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"testing"
+)
+
+func TestFrameRoundTrip(t *testing.T) {
+	payloads := [][]byte{
+		{},
+		{0x01},
+		bytes.Repeat([]byte{0xab}, 1000),
+	}
+
+	var buf bytes.Buffer
+	for _, p := range payloads {
+		if err := WriteFrame(&buf, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := fmt.Sprintf("%x", buf.Bytes()[:9]); got != "00000000"+"0000000101" {
+		t.Fatalf("unexpected frame layout %s", got)
+	}
+
+	for _, want := range payloads {
+		got, err := ReadFrame(&buf, 1000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("expected %x got %x", want, got)
+		}
+	}
+
+	if _, err := ReadFrame(&buf, 1000); err != io.EOF {
+		t.Fatalf("expected io.EOF after last frame, got %v", err)
+	}
+}
+
+func TestReadFrameTooLarge(t *testing.T) {
+	// declares 4 GiB - 1 payload, must be rejected without allocation
+	r := bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0x00})
+
+	if _, err := ReadFrame(r, 1<<20); !errors.Is(err, ErrFrameTooLarge) {
+		t.Fatalf("expected ErrFrameTooLarge, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	_ = WriteFrame(&buf, []byte{1, 2, 3})
+	if _, err := ReadFrame(&buf, 2); !errors.Is(err, ErrFrameTooLarge) {
+		t.Fatalf("expected ErrFrameTooLarge, got %v", err)
+	}
+}
+
+func TestReadFrameTruncated(t *testing.T) {
+	cases := [][]byte{
+		{0x00, 0x00},                   // truncated header
+		{0x00, 0x00, 0x00, 0x03, 0x01}, // truncated payload
+	}
+
+	for _, c := range cases {
+		if _, err := ReadFrame(bytes.NewReader(c), 100); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("ReadFrame(%x): expected io.ErrUnexpectedEOF, got %v", c, err)
+		}
+	}
+}
+
+func TestFrameReaderCRC32(t *testing.T) {
+	var buf bytes.Buffer
+	_ = WriteFrameCRC32(&buf, []byte("first"), crc32.IEEETable)
+	_ = WriteFrameCRC32(&buf, []byte("second"), crc32.IEEETable)
+	_ = WriteFrameCRC32(&buf, []byte{}, crc32.IEEETable)
+
+	stream := buf.Bytes()
+	// "first" frame: 4 + 5 + 4 bytes, so "second" frame starts at 13 and its payload at 17
+	stream[17] ^= 0xff
+
+	r := NewFrameReader(bytes.NewReader(stream), 100, WithCRC32(crc32.IEEETable))
+
+	payload, err := r.ReadFrame()
+	if err != nil || string(payload) != "first" {
+		t.Fatalf("expected %q, got %q (%v)", "first", payload, err)
+	}
+
+	_, err = r.ReadFrame()
+	var checksumErr *ChecksumError
+	if !errors.As(err, &checksumErr) {
+		t.Fatalf("expected *ChecksumError, got %v", err)
+	}
+	if checksumErr.Offset != 13 {
+		t.Fatalf("expected bad frame offset 13, got %d", checksumErr.Offset)
+	}
+
+	payload, err = r.ReadFrame()
+	if err != nil || len(payload) != 0 {
+		t.Fatalf("expected empty payload, got %x (%v)", payload, err)
+	}
+
+	if r.Offset() != int64(len(stream)) {
+		t.Fatalf("expected offset %d, got %d", len(stream), r.Offset())
+	}
+
+	if _, err := r.ReadFrame(); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestFrameReaderCRC32Errors(t *testing.T) {
+	// frame body shorter than checksum
+	var buf bytes.Buffer
+	_ = WriteFrame(&buf, []byte{0x01, 0x02})
+	r := NewFrameReader(&buf, 100, WithCRC32(crc32.IEEETable))
+	if _, err := r.ReadFrame(); err == nil {
+		t.Fatal("expected error for frame without checksum")
+	}
+
+	// maxSize applies to payload, checksum is not counted
+	buf.Reset()
+	_ = WriteFrameCRC32(&buf, []byte("12345"), crc32.IEEETable)
+	r = NewFrameReader(&buf, 5, WithCRC32(crc32.IEEETable))
+	if _, err := r.ReadFrame(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf.Reset()
+	_ = WriteFrameCRC32(&buf, []byte("123456"), crc32.IEEETable)
+	r = NewFrameReader(&buf, 5, WithCRC32(crc32.IEEETable))
+	if _, err := r.ReadFrame(); !errors.Is(err, ErrFrameTooLarge) {
+		t.Fatalf("expected ErrFrameTooLarge, got %v", err)
+	}
+}
+
+func TestFrameReaderWithoutCRC(t *testing.T) {
+	var buf bytes.Buffer
+	_ = WriteFrame(&buf, []byte("plain"))
+
+	r := NewFrameReader(&buf, 100)
+	payload, err := r.ReadFrame()
+	if err != nil || string(payload) != "plain" {
+		t.Fatalf("expected %q, got %q (%v)", "plain", payload, err)
+	}
+}
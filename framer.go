@@ -0,0 +1,168 @@
+package bytecast
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Framer implements generic escape-based delimiter framing for one-off serial protocols
+// that don't follow COBS or SLIP exactly.
+//
+// Every payload byte equal to delimiter or escape byte is replaced with pair
+// (escape, b ^ xor), then delimiter is appended. For example HDLC-like framing is
+// NewFramer(0x7E, 0x7D, 0x20), and SLIP-like framing with literal escaping (ESC followed by
+// the original byte) is NewFramer(0xC0, 0xDB, 0x00).
+type Framer struct {
+	delimiter byte
+	escape    byte
+	xor       byte
+}
+
+func NewFramer(delimiter byte, escape byte, xor byte) (*Framer, error) {
+	if delimiter == escape {
+		return nil, fmt.Errorf("delimiter and escape bytes must differ, got 0x%02X for both", delimiter)
+	}
+
+	// escaped escape byte must not look like delimiter, otherwise receiver cannot resync on delimiter
+	if xor != 0 && escape^xor == delimiter {
+		return nil, fmt.Errorf("xor 0x%02X turns escape byte 0x%02X into delimiter 0x%02X", xor, escape, delimiter)
+	}
+
+	return &Framer{delimiter: delimiter, escape: escape, xor: xor}, nil
+}
+
+// Encode escapes payload and appends delimiter.
+func (f *Framer) Encode(payload []byte) []byte {
+	out := make([]byte, 0, len(payload)+1)
+
+	for _, b := range payload {
+		if b == f.delimiter || b == f.escape {
+			out = append(out, f.escape, b^f.xor)
+			continue
+		}
+		out = append(out, b)
+	}
+
+	return append(out, f.delimiter)
+}
+
+// Decode unescapes single frame, trailing delimiter is optional.
+func (f *Framer) Decode(frame []byte) ([]byte, error) {
+	out := make([]byte, 0, len(frame))
+
+	for i := 0; i < len(frame); i++ {
+		b := frame[i]
+
+		switch {
+		case b == f.escape:
+			i++
+			if i == len(frame) {
+				return nil, fmt.Errorf("dangling escape byte at offset %d", i-1)
+			}
+
+			unescaped, err := f.unescape(frame[i])
+			if err != nil {
+				return nil, fmt.Errorf("%w at offset %d", err, i)
+			}
+			out = append(out, unescaped)
+		case b == f.delimiter && i == len(frame)-1:
+			// trailing delimiter
+		case b == f.delimiter:
+			return nil, fmt.Errorf("unexpected delimiter at offset %d", i)
+		default:
+			out = append(out, b)
+		}
+	}
+
+	return out, nil
+}
+
+// NewReader returns reader which yields unescaped frames from stream.
+func (f *Framer) NewReader(r io.Reader) *DelimitedFrameReader {
+	return &DelimitedFrameReader{framer: f, src: r, r: bufio.NewReader(r)}
+}
+
+func (f *Framer) unescape(b byte) (byte, error) {
+	unescaped := b ^ f.xor
+	if unescaped != f.delimiter && unescaped != f.escape {
+		return 0, fmt.Errorf("invalid escape sequence %02X %02X", f.escape, b)
+	}
+	return unescaped, nil
+}
+
+// DelimitedFrameReader reads frames produced by Framer.Encode from a stream.
+// Empty frames (e.g. repeated delimiters used as line idle filler) are skipped.
+type DelimitedFrameReader struct {
+	framer *Framer
+	src    io.Reader // for deadlines, see ReadFrameContext
+	r      *bufio.Reader
+	limits Limits
+}
+
+// SetLimits sets limits for subsequent frames. Frame longer than MaxFrameSize is discarded
+// up to the next delimiter and ErrFrameTooLarge is returned.
+func (d *DelimitedFrameReader) SetLimits(l Limits) {
+	d.limits = l
+}
+
+// ReadFrameContext is ReadFrame which can be cancelled by ctx, see package-level ReadFrameContext.
+func (d *DelimitedFrameReader) ReadFrameContext(ctx context.Context) ([]byte, error) {
+	var frame []byte
+	err := withReadContext(ctx, d.src, func() (err error) {
+		frame, err = d.ReadFrame()
+		return err
+	})
+	return frame, err
+}
+
+// ReadFrame returns next complete frame.
+//
+// io.EOF is returned when stream ends between frames, io.ErrUnexpectedEOF - when it ends inside a frame.
+// On invalid escape sequence the rest of broken frame is discarded (up to next delimiter) and error is returned.
+func (d *DelimitedFrameReader) ReadFrame() ([]byte, error) {
+	var frame []byte
+	escaped := false
+
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) && (len(frame) > 0 || escaped) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		if escaped {
+			escaped = false
+
+			unescaped, err := d.framer.unescape(b)
+			if err != nil {
+				if b != d.framer.delimiter {
+					discardUntil(d.r, d.framer.delimiter)
+				}
+				return nil, err
+			}
+
+			frame = append(frame, unescaped)
+		} else {
+			switch b {
+			case d.framer.delimiter:
+				if len(frame) > 0 {
+					return frame, nil
+				}
+			case d.framer.escape:
+				escaped = true
+			default:
+				frame = append(frame, b)
+			}
+		}
+
+		if err := d.limits.checkFrameSize(len(frame)); err != nil {
+			discardUntil(d.r, d.framer.delimiter)
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestFramerEncode(t *testing.T) {
+	hdlc, err := NewFramer(0x7e, 0x7d, 0x20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	literal, err := NewFramer(0x0a, 0x5c, 0x00)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		framer *Framer
+		data   string
+		want   string
+	}{
+		{hdlc, "", "7e"},
+		{hdlc, "010203", "0102037e"},
+		{hdlc, "7e", "7d5e7e"},
+		{hdlc, "7d", "7d5d7e"},
+		{hdlc, "017e7d02", "017d5e7d5d027e"},
+		{literal, "410a42", "415c0a420a"},
+		{literal, "5c", "5c5c0a"},
+	}
+
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.data)
+
+		encoded := tt.framer.Encode(data)
+		if got := fmt.Sprintf("%x", encoded); got != tt.want {
+			t.Errorf("Encode(%s) = %s; want %s", tt.data, got, tt.want)
+			continue
+		}
+
+		decoded, err := tt.framer.Decode(encoded)
+		if err != nil {
+			t.Errorf("Decode(%x) returned error: %v", encoded, err)
+			continue
+		}
+
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("Decode(%x) = %x; want %x", encoded, decoded, data)
+		}
+	}
+}
+
+func TestNewFramerValidation(t *testing.T) {
+	if _, err := NewFramer(0x7e, 0x7e, 0x20); err == nil {
+		t.Error("expected error for equal delimiter and escape")
+	}
+
+	if _, err := NewFramer(0x7e, 0x7d, 0x03); err == nil {
+		t.Error("expected error when escaped escape byte equals delimiter")
+	}
+}
+
+func TestFramerDecodeErrors(t *testing.T) {
+	hdlc, _ := NewFramer(0x7e, 0x7d, 0x20)
+
+	cases := []string{
+		"017e02", // delimiter in the middle
+		"017d",   // dangling escape
+		"017d01", // invalid escape
+	}
+
+	for _, c := range cases {
+		b, _ := hex.DecodeString(c)
+		if _, err := hdlc.Decode(b); err == nil {
+			t.Errorf("Decode(%s): expected error", c)
+		}
+	}
+}
+
+func TestDelimitedFrameReader(t *testing.T) {
+	literal, _ := NewFramer(0x0a, 0x5c, 0x00)
+
+	var stream []byte
+	stream = append(stream, 0x0a, 0x0a) // idle filler
+	stream = append(stream, literal.Encode([]byte("a\nb"))...)
+	stream = append(stream, 'x', 0x5c, 'y', 'z', 0x0a) // broken frame
+	stream = append(stream, literal.Encode([]byte(`c\`))...)
+	stream = append(stream, 'd', 0x5c) // truncated frame
+
+	r := literal.NewReader(bytes.NewReader(stream))
+
+	frame, err := r.ReadFrame()
+	if err != nil || string(frame) != "a\nb" {
+		t.Fatalf("expected frame %q, got %q (%v)", "a\nb", frame, err)
+	}
+
+	if _, err := r.ReadFrame(); err == nil {
+		t.Fatal("expected error for invalid escape")
+	}
+
+	frame, err = r.ReadFrame()
+	if err != nil || string(frame) != `c\` {
+		t.Fatalf("expected frame %q, got %q (%v)", `c\`, frame, err)
+	}
+
+	if _, err := r.ReadFrame(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
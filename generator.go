@@ -0,0 +1,176 @@
+package bytecast
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"reflect"
+)
+
+// Generator produces reproducible pseudo-random values of every type supported by Marshal,
+// for fuzzing harnesses and synthetic load-test data. The same seed always yields the same
+// sequence of values. Struct values respect their Schema (tag widths, pointers, ...), so every
+// generated value can be encoded. Fields of types with own codec (Register, BytecastMarshaler)
+// are left zero.
+//
+// Generator is not safe for concurrent use.
+type Generator struct {
+	r *rand.Rand
+}
+
+// NewGenerator creates Generator with given seed.
+func NewGenerator(seed int64) *Generator {
+	return &Generator{r: rand.New(rand.NewPCG(uint64(seed), 0))}
+}
+
+// Generate returns random value of type T, see Generator.
+func Generate[T any](g *Generator) (T, error) {
+	v, err := g.Value(reflect.TypeFor[T]())
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.Interface().(T), nil
+}
+
+// Value returns random value of type t, see Generator.
+func (g *Generator) Value(t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if err := g.fill(v); err != nil {
+		return reflect.Value{}, err
+	}
+	return v, nil
+}
+
+// Record returns encoding of random value of schema type.
+func (g *Generator) Record(s *Schema) ([]byte, error) {
+	v := reflect.New(s.typ).Elem()
+	if err := g.fillStruct(s, v); err != nil {
+		return nil, err
+	}
+	return s.appendStruct(nil, v)
+}
+
+func (g *Generator) fill(v reflect.Value) error {
+	t := v.Type()
+
+	if lookupCustomCodec(t) != nil {
+		return nil
+	}
+
+	switch t {
+	case reflect.TypeFor[*big.Int]():
+		v.Set(reflect.ValueOf(BigIntFromBytes(g.bytes(1 + g.r.IntN(32)))))
+		return nil
+	case reflect.TypeFor[[]byte]():
+		v.SetBytes(g.bytes(g.r.IntN(65)))
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(g.r.IntN(2) == 1)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(g.int(t.Bits()))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(g.uint(t.Bits()))
+	case reflect.String:
+		v.SetString(string(g.bytes(g.r.IntN(256))))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := g.fill(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s for Generator", t)
+		}
+		n := g.r.IntN(9)
+		v.Set(reflect.MakeSlice(t, n, n))
+		for i := range n {
+			v.Index(i).SetString(string(g.bytes(g.r.IntN(33))))
+		}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String || t.Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s for Generator", t)
+		}
+		n := g.r.IntN(9)
+		v.Set(reflect.MakeMapWithSize(t, n))
+		for range n {
+			v.SetMapIndex(reflect.ValueOf(string(g.bytes(g.r.IntN(17)))).Convert(t.Key()),
+				reflect.ValueOf(string(g.bytes(g.r.IntN(33)))).Convert(t.Elem()))
+		}
+	case reflect.Struct:
+		s, err := SchemaOf(t)
+		if err != nil {
+			return err
+		}
+		return g.fillStruct(s, v)
+	case reflect.Pointer:
+		if t.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("unsupported type %s for Generator", t)
+		}
+		v.Set(reflect.New(t.Elem()))
+		return g.fill(v.Elem())
+	default:
+		return fmt.Errorf("unsupported type %s for Generator", t)
+	}
+
+	return nil
+}
+
+// fillStruct sets every field of struct value v to random value fitting its layout.
+func (g *Generator) fillStruct(s *Schema, v reflect.Value) error {
+	for i := 0; i < len(s.fields); i++ {
+		f := &s.fields[i]
+		if f.Kind == KindReserved {
+			continue
+		}
+
+		fv := fieldByPath(v, f.path)
+		bits := min(8*f.Width, 64)
+
+		switch f.Kind {
+		case KindPresence:
+			if g.r.IntN(2) == 0 {
+				i += f.span
+				continue
+			}
+			fv.Set(reflect.New(fv.Type().Elem()))
+		case KindInt:
+			fv.SetInt(g.int(min(bits, fv.Type().Bits())))
+		case KindUint:
+			fv.SetUint(g.uint(min(bits, fv.Type().Bits())))
+		case KindBool:
+			fv.SetBool(g.r.IntN(2) == 1)
+		case KindString:
+			fv.SetString(string(g.bytes(g.r.IntN(f.Width))))
+		case KindBigInt:
+			fv.Set(reflect.ValueOf(BigIntFromBytes(g.bytes(f.Width))))
+		case KindBytes:
+			reflect.Copy(fv, reflect.ValueOf(g.bytes(f.Width)))
+		case KindCustom:
+			// left zero, see Generator
+		}
+	}
+	return nil
+}
+
+// int returns random signed integer of given bit size.
+func (g *Generator) int(bits int) int64 {
+	return int64(g.r.Uint64()<<(64-bits)) >> (64 - bits)
+}
+
+// uint returns random unsigned integer of given bit size.
+func (g *Generator) uint(bits int) uint64 {
+	return g.r.Uint64() >> (64 - bits)
+}
+
+func (g *Generator) bytes(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(g.r.Uint32())
+	}
+	return b
+}
@@ -0,0 +1,92 @@
+package bytecast
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestGeneratorReproducible(t *testing.T) {
+	a, b := NewGenerator(42), NewGenerator(42)
+	for range 20 {
+		x, err := Generate[optionalTestRecord](a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, _ := Generate[optionalTestRecord](b)
+		if !reflect.DeepEqual(x, y) {
+			t.Fatalf("expected equal values for equal seeds, got %+v and %+v", x, y)
+		}
+	}
+
+	x, _ := Generate[uint64](NewGenerator(1))
+	y, _ := Generate[uint64](NewGenerator(2))
+	if x == y {
+		t.Error("expected different values for different seeds")
+	}
+}
+
+func TestGeneratorRoundTrip(t *testing.T) {
+	type tagged struct {
+		Small   uint32   `bytecast:"width=1"`
+		Signed  int64    `bytecast:"width=3,le"`
+		Label   string   `bytecast:"width=5"`
+		Balance *big.Int `bytecast:"width=9"`
+		Ptr     *dumpTestRecord
+		Pad     uint8 `bytecast:"pad=2"`
+	}
+
+	types := []reflect.Type{
+		reflect.TypeFor[bool](), reflect.TypeFor[int16](), reflect.TypeFor[uint64](), reflect.TypeFor[string](),
+		reflect.TypeFor[[]byte](), reflect.TypeFor[[]string](), reflect.TypeFor[map[string]string](),
+		reflect.TypeFor[*big.Int](), reflect.TypeFor[Bytes32](), reflect.TypeFor[Address](),
+		reflect.TypeFor[dumpTestRecord](), reflect.TypeFor[*optionalTestRecord](), reflect.TypeFor[tagged](),
+	}
+
+	g := NewGenerator(7)
+	for _, typ := range types {
+		for range 50 {
+			v, err := g.Value(typ)
+			if err != nil {
+				t.Fatalf("%s: %v", typ, err)
+			}
+
+			data, err := Marshal(v.Interface())
+			if err != nil {
+				t.Fatalf("%s: generated value %v can not be encoded: %v", typ, v, err)
+			}
+
+			target := typ
+			if typ.Kind() == reflect.Pointer && typ != reflect.TypeFor[*big.Int]() {
+				target = typ.Elem()
+			}
+			out := reflect.New(target)
+			if err := Unmarshal(data, out.Interface()); err != nil {
+				t.Fatalf("%s: %v", typ, err)
+			}
+			again, _ := Marshal(out.Elem().Interface())
+			if !reflect.DeepEqual(data, again) {
+				t.Fatalf("%s: round trip changed encoding", typ)
+			}
+		}
+	}
+
+	if _, err := g.Value(reflect.TypeFor[int]()); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
+
+func TestGeneratorRecord(t *testing.T) {
+	s, err := SchemaOf(dumpTestRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := NewGenerator(3).Record(s)
+	if err != nil || len(data) != s.Size() {
+		t.Fatalf("expected %d bytes, got %d (%v)", s.Size(), len(data), err)
+	}
+	if ok, err := IsCanonical(data, dumpTestRecord{}); err != nil || !ok {
+		t.Fatalf("expected canonical record, got %v (%v)", ok, err)
+	}
+}
@@ -0,0 +1,158 @@
+package bytecast
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Packed hex digits: every byte carries two digits (nibbles), e.g. "1234" → 0x12 0x34.
+// Odd-length strings are completed with filler nibble either at the end (default, "123" → 0x12 0x3F)
+// or at the beginning (WithPadHigh, "123" → 0xF1 0x23).
+//
+// SIM / ICCID / telephony numbers (TBCD) also swap nibbles inside every byte,
+// use WithSwappedNibbles for them: "8944" → 0x98 0x44.
+
+type hexDigitsConfig struct {
+	padHigh bool
+	filler  byte
+	swapped bool
+}
+
+type HexDigitsOption func(*hexDigitsConfig)
+
+// WithPadHigh puts filler nibble before the first digit of odd-length string, instead of after the last one.
+func WithPadHigh() HexDigitsOption {
+	return func(c *hexDigitsConfig) {
+		c.padHigh = true
+	}
+}
+
+// WithFiller sets filler nibble for odd-length strings, default is 0xF.
+func WithFiller(nibble byte) HexDigitsOption {
+	return func(c *hexDigitsConfig) {
+		c.filler = nibble & 0x0F
+	}
+}
+
+// WithSwappedNibbles stores the first digit of every pair in the low nibble (TBCD, ICCID).
+func WithSwappedNibbles() HexDigitsOption {
+	return func(c *hexDigitsConfig) {
+		c.swapped = true
+	}
+}
+
+func newHexDigitsConfig(opts []HexDigitsOption) hexDigitsConfig {
+	c := hexDigitsConfig{filler: 0x0F}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// PackHexDigits packs string of hex digits (case-insensitive) into (len(digits)+1)/2 bytes.
+// Any digit is accepted, but if even-length input may end with the filler digit (start with it for WithPadHigh),
+// unpack with UnpackHexDigitsN: UnpackHexDigits would take that digit for padding.
+func PackHexDigits(digits string, opts ...HexDigitsOption) ([]byte, error) {
+	c := newHexDigitsConfig(opts)
+
+	nibbles := make([]byte, 0, len(digits)+1)
+	for i := 0; i < len(digits); i++ {
+		n, ok := hexNibble(digits[i])
+		if !ok {
+			return nil, fmt.Errorf("invalid hex digit %q at position %d", digits[i], i)
+		}
+		nibbles = append(nibbles, n)
+	}
+
+	if len(nibbles)%2 != 0 {
+		if c.padHigh {
+			nibbles = append([]byte{c.filler}, nibbles...)
+		} else {
+			nibbles = append(nibbles, c.filler)
+		}
+	}
+
+	if c.swapped {
+		for i := 0; i < len(nibbles); i += 2 {
+			nibbles[i], nibbles[i+1] = nibbles[i+1], nibbles[i]
+		}
+	}
+
+	return JoinNibbles(nibbles)
+}
+
+// UnpackHexDigits is the inverse of PackHexDigits, it returns lowercase hex digits.
+// Filler nibble is stripped only from the padded end (last digit by default, first with WithPadHigh),
+// so the same options must be used for packing and unpacking. When the number of digits is known,
+// prefer UnpackHexDigitsN, which does not mistake a genuine filler digit for padding.
+func UnpackHexDigits(packed []byte, opts ...HexDigitsOption) string {
+	c := newHexDigitsConfig(opts)
+
+	nibbles := c.nibbles(packed)
+	if len(nibbles) > 0 {
+		if c.padHigh && nibbles[0] == c.filler {
+			nibbles = nibbles[1:]
+		} else if !c.padHigh && nibbles[len(nibbles)-1] == c.filler {
+			nibbles = nibbles[:len(nibbles)-1]
+		}
+	}
+
+	return formatHexNibbles(nibbles)
+}
+
+// UnpackHexDigitsN unpacks exactly n digits packed by PackHexDigits. Packed length must be (n+1)/2 bytes,
+// and for odd n the padded end must hold the filler nibble.
+func UnpackHexDigitsN(packed []byte, n int, opts ...HexDigitsOption) (string, error) {
+	c := newHexDigitsConfig(opts)
+
+	if n < 0 || len(packed) != (n+1)/2 {
+		return "", fmt.Errorf("expected %d bytes for %d packed hex digits, but got %d bytes", (n+1)/2, n, len(packed))
+	}
+
+	nibbles := c.nibbles(packed)
+	if n%2 != 0 {
+		pad := len(nibbles) - 1
+		if c.padHigh {
+			pad = 0
+		}
+		if nibbles[pad] != c.filler {
+			return "", fmt.Errorf("expected filler nibble 0x%x at padded end, but got 0x%x", c.filler, nibbles[pad])
+		}
+		nibbles = slices.Delete(nibbles, pad, pad+1)
+	}
+
+	return formatHexNibbles(nibbles), nil
+}
+
+// nibbles splits packed bytes into nibbles in digit order.
+func (c hexDigitsConfig) nibbles(packed []byte) []byte {
+	nibbles := SplitNibbles(packed)
+	if c.swapped {
+		for i := 0; i < len(nibbles); i += 2 {
+			nibbles[i], nibbles[i+1] = nibbles[i+1], nibbles[i]
+		}
+	}
+	return nibbles
+}
+
+func formatHexNibbles(nibbles []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(nibbles))
+	for _, n := range nibbles {
+		sb.WriteByte("0123456789abcdef"[n])
+	}
+	return sb.String()
+}
+
+func hexNibble(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,65 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestPackHexDigits(t *testing.T) {
+	tests := []struct {
+		name   string
+		digits string
+		opts   []HexDigitsOption
+		packed string
+		exact  bool // only UnpackHexDigitsN can restore digits: filler digit at the padded end of even input
+	}{
+		{"even", "1234", nil, "1234", false},
+		{"odd pad low", "123", nil, "123f", false},
+		{"odd pad high", "123", []HexDigitsOption{WithPadHigh()}, "f123", false},
+		{"custom filler", "123", []HexDigitsOption{WithPadHigh(), WithFiller(0)}, "0123", false},
+		{"hex digits", "aBcD", nil, "abcd", false},
+		{"even ending in hex digit", "abce", nil, "abce", false},
+		{"even custom filler", "0123", []HexDigitsOption{WithPadHigh(), WithFiller(0xE)}, "0123", false},
+		{"even ending in filler", "abcf", nil, "abcf", true},
+		{"even starting with filler", "0123", []HexDigitsOption{WithPadHigh(), WithFiller(0)}, "0123", true},
+		{"odd with inner filler", "f1f", []HexDigitsOption{WithPadHigh()}, "ff1f", false},
+		{"odd ending in filler", "abf", nil, "abff", false},
+		{"empty", "", nil, "", false},
+		// ICCID 89 44 ... with swapped nibbles and F filler
+		{"iccid", "894410", []HexDigitsOption{WithSwappedNibbles()}, "984401", false},
+		{"phone odd", "12345", []HexDigitsOption{WithSwappedNibbles()}, "2143f5", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packed, err := PackHexDigits(tt.digits, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := hex.EncodeToString(packed); got != tt.packed {
+				t.Fatalf("expected %s got %s", tt.packed, got)
+			}
+
+			want := strings.ToLower(tt.digits)
+			if back := UnpackHexDigits(packed, tt.opts...); !tt.exact && back != want {
+				t.Fatalf("round-trip: expected %q got %q", want, back)
+			}
+			if back, err := UnpackHexDigitsN(packed, len(tt.digits), tt.opts...); err != nil || back != want {
+				t.Fatalf("round-trip with digit count: expected %q got %q (%v)", want, back, err)
+			}
+		})
+	}
+}
+
+func TestPackHexDigitsInvalid(t *testing.T) {
+	if _, err := PackHexDigits("12g4"); err == nil {
+		t.Fatal("expected error for non-hex digit")
+	}
+	if _, err := UnpackHexDigitsN([]byte{0x12, 0x34}, 3); err == nil {
+		t.Fatal("expected error for missing filler nibble")
+	}
+	if _, err := UnpackHexDigitsN([]byte{0x12, 0x3f}, 5); err == nil {
+		t.Fatal("expected error for digit count not matching packed length")
+	}
+}
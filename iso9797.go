@@ -0,0 +1,134 @@
+package bytecast
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// ISO9797Method1Pad
+//
+//	Returns copy of data right-padded with zero bytes to a multiple of blockSize (ISO/IEC 9797-1 padding method 1).
+//	Aligned data gets no padding, empty data becomes a single zero block. Padding can only be removed
+//	when the original length is known, see ISO9797Method1Unpad.
+func ISO9797Method1Pad(data []byte, blockSize int) ([]byte, error) {
+	if blockSize < 1 {
+		return nil, fmt.Errorf("unsupported block size %d, must be positive", blockSize)
+	}
+
+	size := (len(data) + blockSize - 1) / blockSize * blockSize
+	if size == 0 {
+		size = blockSize
+	}
+	out := make([]byte, size)
+	copy(out, data)
+	return out, nil
+}
+
+// ISO9797Method1Unpad
+//
+//	Removes padding method 1 from data given the original message length. Fails with ErrInvalidPadding
+//	unless data is exactly as long as ISO9797Method1Pad would make it and all padding bytes are zero.
+func ISO9797Method1Unpad(data []byte, blockSize int, length int) ([]byte, error) {
+	expected, err := ISO9797Method1Pad(nil, blockSize)
+	if err != nil {
+		return nil, err
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("%w: negative length %d", ErrInvalidPadding, length)
+	}
+	if length > 0 {
+		expected = make([]byte, (length+blockSize-1)/blockSize*blockSize)
+	}
+	if len(data) != len(expected) {
+		return nil, fmt.Errorf("%w: got %d bytes, expected %d for length %d", ErrInvalidPadding, len(data), len(expected), length)
+	}
+	if !isZero(data[length:]) {
+		return nil, fmt.Errorf("%w: non-zero padding byte", ErrInvalidPadding)
+	}
+	return data[:length], nil
+}
+
+// ISO9797Method2Pad
+//
+//	Returns copy of data followed by 0x80 and as few zero bytes as needed to reach a multiple of blockSize
+//	(ISO/IEC 9797-1 padding method 2). A full block is added to aligned data.
+func ISO9797Method2Pad(data []byte, blockSize int) ([]byte, error) {
+	return Pad(data, blockSize, PaddingISO7816)
+}
+
+// ISO9797Method2Unpad
+//
+//	Removes padding method 2. len(data) must be a non-zero multiple of blockSize and padding must be 0x80
+//	followed by zero bytes within the last block, otherwise ErrInvalidPadding is returned.
+func ISO9797Method2Unpad(data []byte, blockSize int) ([]byte, error) {
+	return Unpad(data, blockSize, PaddingISO7816)
+}
+
+// ISO9797Method3Pad
+//
+//	Returns length block followed by data zero-padded to a multiple of blockSize (ISO/IEC 9797-1 padding
+//	method 3). Length block holds the data length in bits as a big-endian number right-justified in
+//	blockSize bytes. Aligned data, including empty data, gets no zero padding.
+func ISO9797Method3Pad(data []byte, blockSize int) ([]byte, error) {
+	if blockSize < 1 {
+		return nil, fmt.Errorf("unsupported block size %d, must be positive", blockSize)
+	}
+
+	hi, lo := bits.Mul64(uint64(len(data)), 8)
+	if hi != 0 || (blockSize < 8 && lo>>(blockSize*8) != 0) {
+		return nil, fmt.Errorf("data length %d bytes does not fit in %d byte length block", len(data), blockSize)
+	}
+
+	size := (len(data) + blockSize - 1) / blockSize * blockSize
+	out := make([]byte, blockSize+size)
+	for i := blockSize - 1; i >= 0 && lo != 0; i-- {
+		out[i] = byte(lo)
+		lo >>= 8
+	}
+	copy(out[blockSize:], data)
+	return out, nil
+}
+
+// ISO9797Method3Unpad
+//
+//	Removes padding method 3, taking the message length from the leading length block. Fails with
+//	ErrInvalidPadding if the length is not a whole number of bytes, does not match the amount of
+//	following blocks, or any padding byte is non-zero.
+func ISO9797Method3Unpad(data []byte, blockSize int) ([]byte, error) {
+	if blockSize < 1 {
+		return nil, fmt.Errorf("unsupported block size %d, must be positive", blockSize)
+	}
+	if len(data) < blockSize || len(data)%blockSize != 0 {
+		return nil, fmt.Errorf("%w: length %d is not a non-zero multiple of block size %d", ErrInvalidPadding, len(data), blockSize)
+	}
+
+	var bitLength uint64
+	for i, b := range data[:blockSize] {
+		if bitLength>>56 != 0 {
+			return nil, fmt.Errorf("%w: length block overflows at byte %d", ErrInvalidPadding, i)
+		}
+		bitLength = bitLength<<8 | uint64(b)
+	}
+	if bitLength%8 != 0 {
+		return nil, fmt.Errorf("%w: length of %d bits is not a whole number of bytes", ErrInvalidPadding, bitLength)
+	}
+
+	body := data[blockSize:]
+	length := bitLength / 8
+	if length > uint64(len(body)) || uint64(len(body))-length >= uint64(blockSize) {
+		return nil, fmt.Errorf("%w: length %d bytes does not match %d padded bytes", ErrInvalidPadding, length, len(body))
+	}
+	if !isZero(body[length:]) {
+		return nil, fmt.Errorf("%w: non-zero padding byte", ErrInvalidPadding)
+	}
+	return body[:length], nil
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestISO9797Padding(t *testing.T) {
+	cases := []struct {
+		method int
+		data   string
+		hex    string
+	}{
+		{1, "0102030405", "0102030405000000"},
+		{1, "0102030405060708", "0102030405060708"},
+		{1, "", "0000000000000000"},
+		{2, "0102030405", "0102030405800000"},
+		{2, "0102030405060708", "01020304050607088000000000000000"},
+		{2, "", "8000000000000000"},
+		{3, "0102030405", "00000000000000280102030405000000"},
+		{3, "0102030405060708", "00000000000000400102030405060708"},
+		{3, "", "0000000000000000"},
+	}
+	for _, c := range cases {
+		data, _ := hex.DecodeString(c.data)
+
+		var padded, back []byte
+		var err error
+		switch c.method {
+		case 1:
+			padded, err = ISO9797Method1Pad(data, 8)
+		case 2:
+			padded, err = ISO9797Method2Pad(data, 8)
+		case 3:
+			padded, err = ISO9797Method3Pad(data, 8)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(padded); got != c.hex {
+			t.Errorf("method %d: pad(%s) = %s, expected %s", c.method, c.data, got, c.hex)
+		}
+
+		switch c.method {
+		case 1:
+			back, err = ISO9797Method1Unpad(padded, 8, len(data))
+		case 2:
+			back, err = ISO9797Method2Unpad(padded, 8)
+		case 3:
+			back, err = ISO9797Method3Unpad(padded, 8)
+		}
+		if err != nil {
+			t.Fatalf("method %d: unpad(%s): %v", c.method, c.hex, err)
+		}
+		if got := hex.EncodeToString(back); got != c.data {
+			t.Errorf("method %d: unpad(%s) = %s, expected %s", c.method, c.hex, got, c.data)
+		}
+	}
+
+	// length block of 4 byte blocks holds at most 2^32-1 bits
+	padded, err := ISO9797Method3Pad([]byte{0xAA}, 4)
+	if err != nil || hex.EncodeToString(padded) != "00000008aa000000" {
+		t.Errorf("4 byte block: got %x, %v", padded, err)
+	}
+	if _, err := ISO9797Method3Pad(make([]byte, 1<<13), 1); err == nil {
+		t.Error("expected error for length not fitting length block")
+	}
+}
+
+func TestISO9797UnpadErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func([]byte) ([]byte, error)
+		hex  string
+	}{
+		{"method 1 length too long", func(b []byte) ([]byte, error) { return ISO9797Method1Unpad(b, 8, 9) }, "0102030405000000"},
+		{"method 1 extra block", func(b []byte) ([]byte, error) { return ISO9797Method1Unpad(b, 8, 5) }, "01020304050000000000000000000000"},
+		{"method 1 non-zero", func(b []byte) ([]byte, error) { return ISO9797Method1Unpad(b, 8, 5) }, "0102030405000100"},
+		{"method 1 empty", func(b []byte) ([]byte, error) { return ISO9797Method1Unpad(b, 8, 0) }, ""},
+		{"method 2 no marker", func(b []byte) ([]byte, error) { return ISO9797Method2Unpad(b, 8) }, "0102030405000000"},
+		{"method 2 unaligned", func(b []byte) ([]byte, error) { return ISO9797Method2Unpad(b, 8) }, "01028000"},
+		{"method 3 no length block", func(b []byte) ([]byte, error) { return ISO9797Method3Unpad(b, 8) }, ""},
+		{"method 3 partial bits", func(b []byte) ([]byte, error) { return ISO9797Method3Unpad(b, 8) }, "00000000000000270102030405000000"},
+		{"method 3 length too long", func(b []byte) ([]byte, error) { return ISO9797Method3Unpad(b, 8) }, "00000000000000480102030405060708"},
+		{"method 3 extra block", func(b []byte) ([]byte, error) { return ISO9797Method3Unpad(b, 8) }, "000000000000002801020304050000000000000000000000"},
+		{"method 3 non-zero", func(b []byte) ([]byte, error) { return ISO9797Method3Unpad(b, 8) }, "00000000000000280102030405000001"},
+		{"method 3 overflow", func(b []byte) ([]byte, error) { return ISO9797Method3Unpad(b, 16) }, "0100000000000000000000000000000000000000000000000000000000000000"},
+	}
+	for _, c := range cases {
+		data, _ := hex.DecodeString(c.hex)
+		if _, err := c.fn(data); !errors.Is(err, ErrInvalidPadding) {
+			t.Errorf("%s: expected ErrInvalidPadding, got %v", c.name, err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package bytecast
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// UniversalKey is a 16-byte SMPTE 336M universal label, e.g. MISB ST 0601 UAS Datalink Local Set key
+// 06 0E 2B 34 02 0B 01 01 0E 01 03 01 01 00 00 00.
+type UniversalKey [16]byte
+
+// String returns key as dot-separated hex bytes, the way SMPTE registers print labels.
+func (k UniversalKey) String() string {
+	var sb strings.Builder
+	for i, b := range k {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		fmt.Fprintf(&sb, "%02X", b)
+	}
+	return sb.String()
+}
+
+// KLV is one key-length-value packet. Value aliases the parsed buffer for ParseKLV.
+type KLV struct {
+	Key   UniversalKey
+	Value []byte
+}
+
+// AppendKLV appends packet with BER-encoded length:
+//
+//	[ key (16 bytes) | length (BER: 1 byte below 128, else 0x80|n + n bytes) | value ]
+func AppendKLV(dst []byte, key UniversalKey, value []byte) []byte {
+	dst = append(dst, key[:]...)
+	dst = AppendBERLength(dst, uint64(len(value)))
+	return append(dst, value...)
+}
+
+// ParseKLV splits data into packets, data must consist of whole packets. Indefinite BER length is rejected.
+func ParseKLV(data []byte, opts ...DecodeOption) ([]KLV, error) {
+	limits := &newDecodeConfig(opts).limits
+
+	var out []KLV
+	for off := 0; off < len(data); {
+		if err := limits.checkElements(uint64(len(out) + 1)); err != nil {
+			return nil, err
+		}
+		if len(data)-off < 16 {
+			return nil, fmt.Errorf("KLV at offset %d: truncated key", off)
+		}
+
+		key := UniversalKey(data[off : off+16])
+		length, n, err := DecodeBERLength(data[off+16:])
+		if err != nil {
+			return nil, fmt.Errorf("KLV %s at offset %d: %w", key, off, err)
+		}
+
+		start := off + 16 + n
+		if length > uint64(len(data)-start) {
+			return nil, fmt.Errorf("KLV %s at offset %d declares %d bytes, but only %d bytes left", key, off, length, len(data)-start)
+		}
+
+		out = append(out, KLV{Key: key, Value: data[start : start+int(length) : start+int(length)]})
+		off = start + int(length)
+	}
+
+	return out, nil
+}
+
+// KLVReader reads KLV packets from a stream (e.g. MISB metadata demuxed from MPEG-TS), keeping track of stream offset.
+type KLVReader struct {
+	r       io.Reader
+	maxSize int
+	keys    map[UniversalKey]bool
+	offset  int64
+	limits  Limits
+}
+
+type KLVReaderOption func(*KLVReader)
+
+// WithKLVKeys makes KLVReader return only packets with given keys, other packets are skipped without
+// buffering, even if they are larger than maxSize. Streams often carry keys the consumer does not know.
+func WithKLVKeys(keys ...UniversalKey) KLVReaderOption {
+	return func(k *KLVReader) {
+		k.keys = make(map[UniversalKey]bool, len(keys))
+		for _, key := range keys {
+			k.keys[key] = true
+		}
+	}
+}
+
+// NewKLVReader creates KLVReader accepting values up to maxSize bytes.
+func NewKLVReader(r io.Reader, maxSize int, opts ...KLVReaderOption) *KLVReader {
+	k := &KLVReader{r: r, maxSize: maxSize}
+	for _, opt := range opts {
+		opt(k)
+	}
+	return k
+}
+
+// SetLimits sets limits for subsequent packets, MaxFrameSize further caps maxSize given to NewKLVReader.
+func (k *KLVReader) SetLimits(l Limits) {
+	k.limits = l
+}
+
+// Offset returns stream offset of the next packet.
+func (k *KLVReader) Offset() int64 {
+	return k.offset
+}
+
+// Next reads next packet. Returns io.EOF at clean end of stream and io.ErrUnexpectedEOF inside a packet.
+// Values larger than allowed size yield error wrapping ErrFrameTooLarge, the stream can not be resumed after it.
+func (k *KLVReader) Next() (KLV, error) {
+	for {
+		packetOffset := k.offset
+
+		var key UniversalKey
+		if _, err := io.ReadFull(k.r, key[:]); err != nil {
+			return KLV{}, err
+		}
+
+		length, n, err := k.readLength()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return KLV{}, fmt.Errorf("KLV %s at offset %d: %w", key, packetOffset, err)
+		}
+		k.offset += 16 + int64(n)
+
+		if k.keys != nil && !k.keys[key] {
+			skipped, err := io.CopyN(io.Discard, k.r, int64(min(length, 1<<63-1)))
+			k.offset += skipped
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = io.ErrUnexpectedEOF
+				}
+				return KLV{}, fmt.Errorf("KLV %s at offset %d: %w", key, packetOffset, err)
+			}
+			continue
+		}
+
+		if maxSize := k.limits.frameSize(k.maxSize); length > uint64(maxSize) {
+			return KLV{}, fmt.Errorf("%w: KLV %s at offset %d declares %d bytes, max %d", ErrFrameTooLarge, key, packetOffset, length, maxSize)
+		}
+
+		value := make([]byte, length)
+		if _, err := io.ReadFull(k.r, value); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return KLV{}, fmt.Errorf("KLV %s at offset %d: %w", key, packetOffset, err)
+		}
+		k.offset += int64(length)
+
+		return KLV{Key: key, Value: value}, nil
+	}
+}
+
+// readLength reads BER length octets from the stream.
+func (k *KLVReader) readLength() (uint64, int, error) {
+	var buf [9]byte
+	if _, err := io.ReadFull(k.r, buf[:1]); err != nil {
+		return 0, 0, err
+	}
+
+	// long form, invalid forms are reported by DecodeBERLength from the first byte
+	n := 1
+	if buf[0] > 0x80 && buf[0]&0x7F <= 8 {
+		n += int(buf[0] & 0x7F)
+		if _, err := io.ReadFull(k.r, buf[1:n]); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return DecodeBERLength(buf[:n])
+}
@@ -0,0 +1,127 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+var (
+	klvTestUASKey   = UniversalKey{0x06, 0x0E, 0x2B, 0x34, 0x02, 0x0B, 0x01, 0x01, 0x0E, 0x01, 0x03, 0x01, 0x01, 0x00, 0x00, 0x00}
+	klvTestOtherKey = UniversalKey{0x06, 0x0E, 0x2B, 0x34, 0x01, 0x01, 0x01, 0x01}
+)
+
+func TestAppendKLV(t *testing.T) {
+	b := AppendKLV(nil, klvTestUASKey, []byte{1, 2, 3})
+	if got := hex.EncodeToString(b); got != "060e2b34020b01010e01030101000000"+"03010203" {
+		t.Errorf("unexpected short-form packet %s", got)
+	}
+
+	b = AppendKLV(nil, klvTestUASKey, make([]byte, 200))
+	if got := hex.EncodeToString(b[16:18]); got != "81c8" {
+		t.Errorf("expected long-form length 81c8, got %s", got)
+	}
+	b = AppendKLV(nil, klvTestUASKey, make([]byte, 300))
+	if got := hex.EncodeToString(b[16:19]); got != "82012c" {
+		t.Errorf("expected long-form length 82012c, got %s", got)
+	}
+
+	if s := klvTestUASKey.String(); s != "06.0E.2B.34.02.0B.01.01.0E.01.03.01.01.00.00.00" {
+		t.Errorf("unexpected key string %s", s)
+	}
+}
+
+func TestParseKLV(t *testing.T) {
+	packets := []KLV{
+		{klvTestUASKey, []byte("abc")},
+		{klvTestOtherKey, bytes.Repeat([]byte{7}, 130)},
+		{klvTestUASKey, []byte{}},
+	}
+	var data []byte
+	for _, p := range packets {
+		data = AppendKLV(data, p.Key, p.Value)
+	}
+
+	got, err := ParseKLV(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, packets) {
+		t.Errorf("got %v, expected %v", got, packets)
+	}
+
+	if _, err := ParseKLV(data, WithLimits(Limits{MaxElements: 2})); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+
+	for _, tail := range []string{"0201", "80", "89", "820100", "05"} {
+		b, _ := hex.DecodeString(tail)
+		if _, err := ParseKLV(append(klvTestUASKey[:], b...)); err == nil {
+			t.Errorf("expected error for packet with length octets %s", tail)
+		}
+	}
+}
+
+func TestKLVReader(t *testing.T) {
+	var data []byte
+	data = AppendKLV(data, klvTestOtherKey, bytes.Repeat([]byte{1}, 1000))
+	data = AppendKLV(data, klvTestUASKey, []byte("first"))
+	data = AppendKLV(data, klvTestOtherKey, []byte("skip"))
+	data = AppendKLV(data, klvTestUASKey, []byte("second"))
+
+	r := NewKLVReader(bytes.NewReader(data), 64, WithKLVKeys(klvTestUASKey))
+
+	var values []string
+	for {
+		p, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, string(p.Value))
+	}
+	if !reflect.DeepEqual(values, []string{"first", "second"}) {
+		t.Errorf("unexpected values %q", values)
+	}
+	if r.Offset() != int64(len(data)) {
+		t.Errorf("expected offset %d, got %d", len(data), r.Offset())
+	}
+
+	// without key filter oversized packet is an error
+	r = NewKLVReader(bytes.NewReader(data), 64)
+	if _, err := r.Next(); !errors.Is(err, ErrFrameTooLarge) {
+		t.Errorf("expected ErrFrameTooLarge, got %v", err)
+	}
+
+	r = NewKLVReader(bytes.NewReader(data[1019:]), 64)
+	r.SetLimits(Limits{MaxFrameSize: 4})
+	if _, err := r.Next(); !errors.Is(err, ErrFrameTooLarge) {
+		t.Errorf("expected ErrFrameTooLarge with MaxFrameSize, got %v", err)
+	}
+}
+
+func TestKLVReaderTruncated(t *testing.T) {
+	full := AppendKLV(nil, klvTestUASKey, make([]byte, 200))
+
+	for _, n := range []int{5, 16, 17, 100} {
+		r := NewKLVReader(bytes.NewReader(full[:n]), 1024)
+		if _, err := r.Next(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%d bytes: expected io.ErrUnexpectedEOF, got %v", n, err)
+		}
+	}
+
+	r := NewKLVReader(bytes.NewReader(full[:100]), 1024, WithKLVKeys(klvTestOtherKey))
+	if _, err := r.Next(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("skipped packet: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	indefinite := append(klvTestUASKey[:], 0x80)
+	if _, err := NewKLVReader(bytes.NewReader(indefinite), 1024).Next(); err == nil {
+		t.Error("expected error for indefinite length")
+	}
+}
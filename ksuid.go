@@ -0,0 +1,80 @@
+package bytecast
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	ksuidEpoch     = 1400000000 // 2014-05-13 16:53:20 UTC
+)
+
+// KSUID is a 20-byte K-Sortable Unique Identifier (Segment):
+//
+//	[ seconds since 2014-05-13 16:53:20 UTC (uint32) | payload (16 bytes) ]
+//
+// Canonical string form is 27 characters of base62, which sorts the same way as bytes.
+type KSUID [20]byte
+
+// NewKSUID builds KSUID from t and 16 bytes read from entropy (e.g. crypto/rand.Reader).
+func NewKSUID(t time.Time, entropy io.Reader) (KSUID, error) {
+	var k KSUID
+
+	ts := t.Unix() - ksuidEpoch
+	if ts < 0 || ts > 1<<32-1 {
+		return k, fmt.Errorf("time %v is out of KSUID range", t)
+	}
+
+	b := Uint32To4Bytes(uint32(ts))
+	copy(k[:4], b[:])
+
+	if _, err := io.ReadFull(entropy, k[4:]); err != nil {
+		return KSUID{}, fmt.Errorf("read KSUID payload: %w", err)
+	}
+
+	return k, nil
+}
+
+// KSUIDFromBytes converts slice of exactly 20 bytes to KSUID.
+func KSUIDFromBytes(b []byte) (KSUID, error) {
+	if len(b) != 20 {
+		return KSUID{}, fmt.Errorf("expected exactly 20 bytes for KSUID, but got %d bytes", len(b))
+	}
+	return KSUID(b), nil
+}
+
+// KSUIDFromString parses 27-character base62 form.
+func KSUIDFromString(s string) (KSUID, error) {
+	if len(s) != 27 {
+		return KSUID{}, fmt.Errorf("expected 27 characters in KSUID, but got %d in %q", len(s), s)
+	}
+
+	n, err := decodeBaseN(s, base62Alphabet)
+	if err != nil {
+		return KSUID{}, fmt.Errorf("invalid KSUID %q: %w", s, err)
+	}
+	if n.BitLen() > 160 {
+		return KSUID{}, fmt.Errorf("KSUID %q overflows 160 bits", s)
+	}
+
+	var k KSUID
+	n.FillBytes(k[:])
+	return k, nil
+}
+
+// String returns canonical 27-character base62 form.
+func (k KSUID) String() string {
+	return encodeBaseN(k[:], base62Alphabet, 27)
+}
+
+// Time returns timestamp component of KSUID.
+func (k KSUID) Time() time.Time {
+	return time.Unix(int64(Uint32From4Bytes([4]byte(k[:4])))+ksuidEpoch, 0)
+}
+
+// Payload returns 16-byte random component of KSUID.
+func (k KSUID) Payload() [16]byte {
+	return [16]byte(k[4:])
+}
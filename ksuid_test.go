@@ -0,0 +1,69 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestKSUIDString(t *testing.T) {
+	// example from the segmentio/ksuid README
+	k, err := KSUIDFromString("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(k[:]); got != "0669f7efb5a1cd34b5f99d1154fb6853345c9735" {
+		t.Errorf("unexpected bytes %s", got)
+	}
+	if got := k.Time().UTC(); !got.Equal(time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC)) {
+		t.Errorf("unexpected time %v", got)
+	}
+	payload := k.Payload()
+	if got := hex.EncodeToString(payload[:]); got != "b5a1cd34b5f99d1154fb6853345c9735" {
+		t.Errorf("unexpected payload %s", got)
+	}
+	if got := k.String(); got != "0ujtsYcgvSTl8PAuAdqWYSMnLOv" {
+		t.Errorf("unexpected string %s", got)
+	}
+
+	maxID := KSUID(bytes.Repeat([]byte{0xff}, 20))
+	if got := maxID.String(); got != "aWgEPTl1tmebfsQzFP4bxwgy80V" {
+		t.Errorf("unexpected max KSUID string %s", got)
+	}
+}
+
+func TestNewKSUID(t *testing.T) {
+	tm := time.Unix(1700000000, 0)
+	payload := bytes.Repeat([]byte{0xab}, 16)
+
+	k, err := NewKSUID(tm, bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !k.Time().Equal(tm) {
+		t.Errorf("expected time %v, got %v", tm, k.Time())
+	}
+	if back, err := KSUIDFromString(k.String()); err != nil || back != k {
+		t.Errorf("string round trip failed: %v (%v)", back, err)
+	}
+
+	if _, err := NewKSUID(time.Unix(1300000000, 0), bytes.NewReader(payload)); err == nil {
+		t.Error("expected error for time before KSUID epoch")
+	}
+}
+
+func TestKSUIDErrors(t *testing.T) {
+	for _, s := range []string{
+		"0ujtsYcgvSTl8PAuAdqWYSMnLO",  // short
+		"0ujtsYcgvSTl8PAuAdqWYSMnLO-", // invalid character
+		"aWgEPTl1tmebfsQzFP4bxwgy80W", // overflows 160 bits
+	} {
+		if _, err := KSUIDFromString(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+	if _, err := KSUIDFromBytes(make([]byte, 16)); err == nil {
+		t.Error("expected error for 16 bytes")
+	}
+}
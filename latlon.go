@@ -0,0 +1,93 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// DefaultCoordinateScale stores coordinates as micro-degrees (~11 cm at the equator).
+const DefaultCoordinateScale = 1e6
+
+// LatLonOption configures LatLonToBytes and LatLonFromBytes.
+type LatLonOption func(*latLonConfig)
+
+type latLonConfig struct {
+	scale float64
+}
+
+// WithCoordinateScale sets number of stored units per degree, e.g. 1e7 for GPS receivers (u-blox, OSM) reporting 1e-7 degrees.
+// Scale must keep ±180 degrees within int32, so it can not exceed ~1.19e7.
+func WithCoordinateScale(scale float64) LatLonOption {
+	return func(c *latLonConfig) {
+		c.scale = scale
+	}
+}
+
+// LatLonToBytes
+//
+//	Encodes latitude and longitude (degrees) as two big-endian int32 values scaled by coordinate scale:
+//
+//	[ latitude int32 | longitude int32 ]
+//
+//	Values are rounded to the nearest unit. Latitude must be within [-90, 90] and longitude within [-180, 180].
+func LatLonToBytes(lat float64, lon float64, opts ...LatLonOption) ([8]byte, error) {
+	var out [8]byte
+
+	c, err := newLatLonConfig(opts)
+	if err != nil {
+		return out, err
+	}
+
+	if err := validateLatLon(lat, lon); err != nil {
+		return out, err
+	}
+
+	binary.BigEndian.PutUint32(out[:4], uint32(int32(math.Round(lat*c.scale))))
+	binary.BigEndian.PutUint32(out[4:], uint32(int32(math.Round(lon*c.scale))))
+
+	return out, nil
+}
+
+// LatLonFromBytes
+//
+//	Decodes coordinates written by LatLonToBytes with the same coordinate scale.
+//	Returns an error when decoded values are out of range, which usually means scale mismatch.
+func LatLonFromBytes(b [8]byte, opts ...LatLonOption) (lat float64, lon float64, err error) {
+	c, err := newLatLonConfig(opts)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	lat = float64(int32(binary.BigEndian.Uint32(b[:4]))) / c.scale
+	lon = float64(int32(binary.BigEndian.Uint32(b[4:]))) / c.scale
+
+	if err := validateLatLon(lat, lon); err != nil {
+		return 0, 0, err
+	}
+
+	return lat, lon, nil
+}
+
+func newLatLonConfig(opts []LatLonOption) (*latLonConfig, error) {
+	c := &latLonConfig{scale: DefaultCoordinateScale}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if !(c.scale > 0) || 180*c.scale > math.MaxInt32 {
+		return nil, fmt.Errorf("unsupported coordinate scale %v, must be positive and keep ±180 within int32", c.scale)
+	}
+
+	return c, nil
+}
+
+func validateLatLon(lat float64, lon float64) error {
+	if !(lat >= -90 && lat <= 90) {
+		return fmt.Errorf("latitude %v is out of range [-90, 90]", lat)
+	}
+	if !(lon >= -180 && lon <= 180) {
+		return fmt.Errorf("longitude %v is out of range [-180, 180]", lon)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+func TestLatLonBytes(t *testing.T) {
+	cases := []struct {
+		lat, lon float64
+		opts     []LatLonOption
+		hex      string
+	}{
+		{0, 0, nil, "0000000000000000"},
+		{50.450001, 30.523333, nil, "0301ce5101d1bfc5"},
+		{-90, -180, nil, "faa2b580f5456b00"},
+		{90, 180, []LatLonOption{WithCoordinateScale(1e7)}, "35a4e9006b49d200"},
+	}
+	for _, c := range cases {
+		b, err := LatLonToBytes(c.lat, c.lon, c.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b[:]); got != c.hex {
+			t.Errorf("LatLonToBytes(%v, %v) = %s, expected %s", c.lat, c.lon, got, c.hex)
+		}
+
+		lat, lon, err := LatLonFromBytes(b, c.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(lat-c.lat) > 1e-6 || math.Abs(lon-c.lon) > 1e-6 {
+			t.Errorf("round trip of (%v, %v) gave (%v, %v)", c.lat, c.lon, lat, lon)
+		}
+	}
+}
+
+func TestLatLonBytesErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		lat, lon float64
+		opts     []LatLonOption
+	}{
+		{"latitude", 90.5, 0, nil},
+		{"longitude", 0, -181, nil},
+		{"nan", math.NaN(), 0, nil},
+		{"scale too big", 0, 0, []LatLonOption{WithCoordinateScale(1e8)}},
+		{"zero scale", 0, 0, []LatLonOption{WithCoordinateScale(0)}},
+	}
+	for _, c := range cases {
+		if _, err := LatLonToBytes(c.lat, c.lon, c.opts...); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+	}
+
+	// 1e7-scaled longitude 180 decoded with default scale is out of range
+	b, _ := LatLonToBytes(0, 180, WithCoordinateScale(1e7))
+	if _, _, err := LatLonFromBytes(b); err == nil {
+		t.Error("expected error for scale mismatch")
+	}
+}
@@ -0,0 +1,79 @@
+package bytecast
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLimitExceeded is returned when declared length, count or nesting of decoded input exceeds Limits.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// ErrLengthExceedsLimit is returned when declared length of string exceeds configured maximum
+// (WithMaxLength, Limits.MaxStringLength). It matches ErrLimitExceeded with errors.Is.
+var ErrLengthExceedsLimit = fmt.Errorf("length %w", ErrLimitExceeded)
+
+// Limits caps what length-prefixed decoders accept from untrusted input. Decoders always validate
+// declared lengths against the input size, Limits additionally bound allocations for inputs which
+// are large themselves (e.g. a frame of configured maximum size full of tiny strings).
+// Zero field means no limit.
+//
+// Pass Limits with WithLimits to Decode, StringsFromBytes and MapFromBytes,
+// and with SetLimits to frame readers.
+type Limits struct {
+	MaxStringLength int // max length of one string, key or value
+	MaxElements     int // max number of entries of string list or map
+	MaxDepth        int // max nesting depth of struct types (struct with scalar fields only has depth 1)
+	MaxFrameSize    int // max frame payload size of FrameReader, DelimitedFrameReader and SLIPReader
+}
+
+// WithLimits makes decoding enforce l, see Limits.
+func WithLimits(l Limits) DecodeOption {
+	return func(c *decodeConfig) {
+		c.limits = l
+	}
+}
+
+func (l *Limits) checkStringLength(n uint64) error {
+	if l.MaxStringLength > 0 && n > uint64(l.MaxStringLength) {
+		return fmt.Errorf("%w: declared string length %d, max %d", ErrLengthExceedsLimit, n, l.MaxStringLength)
+	}
+	return nil
+}
+
+func (l *Limits) checkElements(n uint64) error {
+	if l.MaxElements > 0 && n > uint64(l.MaxElements) {
+		return fmt.Errorf("%w: %d elements, max %d", ErrLimitExceeded, n, l.MaxElements)
+	}
+	return nil
+}
+
+func (l *Limits) checkDepth(depth int) error {
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return fmt.Errorf("%w: nesting depth %d, max %d", ErrLimitExceeded, depth, l.MaxDepth)
+	}
+	return nil
+}
+
+// frameSize returns maxSize capped by MaxFrameSize.
+func (l *Limits) frameSize(maxSize int) int {
+	if l.MaxFrameSize > 0 {
+		return min(maxSize, l.MaxFrameSize)
+	}
+	return maxSize
+}
+
+// checkFrameSize is used by delimited frame readers, which learn the frame size while reading it.
+func (l *Limits) checkFrameSize(n int) error {
+	if l.MaxFrameSize > 0 && n > l.MaxFrameSize {
+		return fmt.Errorf("%w: frame exceeds %d bytes: %w", ErrFrameTooLarge, l.MaxFrameSize, ErrLimitExceeded)
+	}
+	return nil
+}
+
+func newDecodeConfig(opts []DecodeOption) *decodeConfig {
+	c := &decodeConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
@@ -0,0 +1,120 @@
+package bytecast
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLimitsStringsAndMaps(t *testing.T) {
+	list, _ := StringsToBytes([]string{"a", "bb", "cccc"})
+	m := MapToBytes(map[string]string{"k": "long value", "x": "y"})
+
+	cases := []struct {
+		name   string
+		limits Limits
+		ok     bool
+	}{
+		{"no limits", Limits{}, true},
+		{"generous", Limits{MaxStringLength: 10, MaxElements: 3}, true},
+		{"long string", Limits{MaxStringLength: 3}, false},
+		{"many elements", Limits{MaxElements: 1}, false},
+	}
+	for _, c := range cases {
+		_, err := StringsFromBytes(list, WithLimits(c.limits))
+		if (err == nil) != c.ok || !c.ok && !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("%s: StringsFromBytes: unexpected error %v", c.name, err)
+		}
+
+		_, err = MapFromBytes(m, WithLimits(c.limits))
+		if (err == nil) != c.ok || !c.ok && !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("%s: MapFromBytes: unexpected error %v", c.name, err)
+		}
+
+		var out []string
+		_, err = Decode(list, &out, WithLimits(c.limits))
+		if (err == nil) != c.ok {
+			t.Errorf("%s: Decode: unexpected error %v", c.name, err)
+		}
+	}
+}
+
+func TestLimitsDepth(t *testing.T) {
+	type inner struct{ A uint8 }
+	type middle struct{ In inner }
+	type outer struct {
+		M middle
+		P *inner
+	}
+
+	s, err := SchemaOf(outer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.depth != 3 {
+		t.Fatalf("expected depth 3, got %d", s.depth)
+	}
+
+	data := make([]byte, s.Size())
+	var v outer
+	if _, err := Decode(data, &v, WithLimits(Limits{MaxDepth: 3})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decode(data, &v, WithLimits(Limits{MaxDepth: 2})); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected ErrLimitExceeded, got %v", err)
+	}
+}
+
+func TestLimitsFrameSize(t *testing.T) {
+	framer, err := NewFramer(0x7e, 0x7d, 0x20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lengthPrefixed bytes.Buffer
+	_ = WriteFrame(&lengthPrefixed, []byte("too long"))
+	_ = WriteFrame(&lengthPrefixed, []byte("ok"))
+
+	delimited := append(framer.Encode([]byte("too long")), framer.Encode([]byte("ok"))...)
+	slip := append(SLIPEncode([]byte("too long")), SLIPEncode([]byte("ok"))...)
+
+	fr := NewFrameReader(&lengthPrefixed, 1024)
+	dr := framer.NewReader(bytes.NewReader(delimited))
+	sr := NewSLIPReader(bytes.NewReader(slip))
+
+	limits := Limits{MaxFrameSize: 4}
+	fr.SetLimits(limits)
+	dr.SetLimits(limits)
+	sr.SetLimits(limits)
+
+	readers := map[string]interface{ ReadFrame() ([]byte, error) }{"length-prefixed": fr, "delimited": dr, "slip": sr}
+	for name, r := range readers {
+		_, err := r.ReadFrame()
+		if !errors.Is(err, ErrFrameTooLarge) {
+			t.Fatalf("%s: expected ErrFrameTooLarge, got %v", name, err)
+		}
+		if name == "length-prefixed" {
+			continue // stream position is lost after oversized length prefix
+		}
+		if !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("%s: expected ErrLimitExceeded", name)
+		}
+		frame, err := r.ReadFrame()
+		if err != nil || string(frame) != "ok" {
+			t.Fatalf("%s: expected next frame after oversized one, got %q (%v)", name, frame, err)
+		}
+	}
+}
+
+func TestDiscardUntilLongInput(t *testing.T) {
+	sr := NewSLIPReader(strings.NewReader(strings.Repeat("x", 10000) + string([]byte{SLIPEnd, 'o', 'k', SLIPEnd})))
+	sr.SetLimits(Limits{MaxFrameSize: 8})
+
+	if _, err := sr.ReadFrame(); !errors.Is(err, ErrFrameTooLarge) {
+		t.Fatalf("expected ErrFrameTooLarge, got %v", err)
+	}
+	if frame, err := sr.ReadFrame(); err != nil || string(frame) != "ok" {
+		t.Fatalf("expected ok, got %q (%v)", frame, err)
+	}
+}
@@ -0,0 +1,117 @@
+package bytecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// LinearCodec converts physical values to raw integer fields and back, as telemetry formats describe them:
+//
+//	value = raw*Scale + Offset
+//
+// e.g. temperature stored as int16 in 0.1 °C steps with -40 °C offset is LinearCodec{Scale: 0.1, Offset: -40, Width: 2, Signed: true}.
+// Raw field is big-endian, Width bytes (1..8), two's complement if Signed.
+type LinearCodec struct {
+	Scale  float64
+	Offset float64
+	Width  int
+	Signed bool
+}
+
+// Encode converts value to raw = round((value - Offset) / Scale) and encodes it,
+// values outside of raw range are rejected.
+func (c LinearCodec) Encode(value float64) ([]byte, error) {
+	return c.Append(nil, value)
+}
+
+// Append appends encoding of value (see Encode) to dst. On error dst is returned unchanged.
+func (c LinearCodec) Append(dst []byte, value float64) ([]byte, error) {
+	if err := c.validate(); err != nil {
+		return dst, err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return dst, fmt.Errorf("value %v can not be encoded", value)
+	}
+
+	raw := math.Round((value - c.Offset) / c.Scale)
+	lo, hi := c.rawRange()
+	if raw < lo || raw > hi {
+		return dst, fmt.Errorf("value %v (raw %v) is out of %s range [%v, %v]", value, raw, c.rawName(), lo*c.Scale+c.Offset, hi*c.Scale+c.Offset)
+	}
+
+	var out []byte
+	var err error
+	if c.Signed {
+		out, err = IntXXToBytesAndExpandWidth(int64(raw), 8*c.Width, c.Width)
+	} else {
+		out, err = UintXXToBytesAndExpandWidth(uint64(raw), 8*c.Width, c.Width)
+	}
+	if err != nil {
+		return dst, err
+	}
+
+	return append(dst, out...), nil
+}
+
+// Decode decodes exactly Width bytes and returns raw*Scale + Offset.
+func (c LinearCodec) Decode(b []byte) (float64, error) {
+	if err := c.validate(); err != nil {
+		return 0, err
+	}
+	if len(b) != c.Width {
+		return 0, fmt.Errorf("expected exactly %d bytes for %s field, but got %d bytes", c.Width, c.rawName(), len(b))
+	}
+
+	if c.Signed {
+		raw, err := IntXXFromBytes(b, 8*c.Width)
+		if err != nil {
+			return 0, err
+		}
+		return float64(raw)*c.Scale + c.Offset, nil
+	}
+
+	raw, err := UintXXFromBytes(b, 8*c.Width)
+	if err != nil {
+		return 0, err
+	}
+	return float64(raw)*c.Scale + c.Offset, nil
+}
+
+func (c LinearCodec) validate() error {
+	if c.Width < 1 || c.Width > 8 {
+		return fmt.Errorf("unsupported linear codec width %d, must be 1..8 bytes", c.Width)
+	}
+	if c.Scale == 0 || math.IsNaN(c.Scale) || math.IsInf(c.Scale, 0) {
+		return fmt.Errorf("invalid linear codec scale %v", c.Scale)
+	}
+	if math.IsNaN(c.Offset) || math.IsInf(c.Offset, 0) {
+		return fmt.Errorf("invalid linear codec offset %v", c.Offset)
+	}
+	return nil
+}
+
+// rawRange returns raw limits as float64; for 64-bit fields upper limit is the largest float64 below 2^63 / 2^64,
+// so conversion of accepted raw to integer never overflows.
+func (c LinearCodec) rawRange() (float64, float64) {
+	bits := 8 * c.Width
+	if c.Signed {
+		hi := math.Ldexp(1, bits-1) - 1
+		if bits == 64 {
+			hi = math.Nextafter(math.Ldexp(1, 63), 0)
+		}
+		return -math.Ldexp(1, bits-1), hi
+	}
+
+	hi := math.Ldexp(1, bits) - 1
+	if bits == 64 {
+		hi = math.Nextafter(math.Ldexp(1, 64), 0)
+	}
+	return 0, hi
+}
+
+func (c LinearCodec) rawName() string {
+	if c.Signed {
+		return fmt.Sprintf("int%d", 8*c.Width)
+	}
+	return fmt.Sprintf("uint%d", 8*c.Width)
+}
@@ -0,0 +1,74 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+func TestLinearCodec(t *testing.T) {
+	temperature := LinearCodec{Scale: 0.1, Offset: -40, Width: 2, Signed: true}
+	voltage := LinearCodec{Scale: 0.001, Width: 2}
+	rpm := LinearCodec{Scale: 0.25, Width: 2}
+
+	cases := []struct {
+		name  string
+		codec LinearCodec
+		value float64
+		hex   string
+	}{
+		{"temperature", temperature, 21.5, "0267"},
+		{"temperature below offset", temperature, -45, "ffce"},
+		{"voltage", voltage, 3.3, "0ce4"},
+		{"voltage max", voltage, 65.535, "ffff"},
+		{"rpm", rpm, 1500.25, "1771"},
+	}
+	for _, c := range cases {
+		b, err := c.codec.Encode(c.value)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("%s: Encode(%v) = %s, expected %s", c.name, c.value, got, c.hex)
+		}
+		got, err := c.codec.Decode(b)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if math.Abs(got-c.value) > c.codec.Scale/2 {
+			t.Errorf("%s: Decode(%s) = %v, expected %v", c.name, c.hex, got, c.value)
+		}
+	}
+
+	dst, err := rpm.Append([]byte{0xAA}, 1)
+	if err != nil || hex.EncodeToString(dst) != "aa0004" {
+		t.Errorf("Append = %x (%v), expected aa0004", dst, err)
+	}
+
+	wide := LinearCodec{Scale: 1, Width: 8, Signed: true}
+	if _, err := wide.Encode(math.Ldexp(1, 63)); err == nil {
+		t.Error("expected error for 2^63 in int64 field")
+	}
+	if b, err := wide.Encode(-math.Ldexp(1, 63)); err != nil || hex.EncodeToString(b) != "8000000000000000" {
+		t.Errorf("expected min int64, got %x (%v)", b, err)
+	}
+}
+
+func TestLinearCodecErrors(t *testing.T) {
+	voltage := LinearCodec{Scale: 0.001, Width: 2}
+
+	for _, v := range []float64{-0.001, 65.536, math.NaN(), math.Inf(1)} {
+		if _, err := voltage.Encode(v); err == nil {
+			t.Errorf("Encode(%v): expected error", v)
+		}
+	}
+	if _, err := voltage.Decode([]byte{1}); err == nil {
+		t.Error("expected error for short input")
+	}
+
+	for _, c := range []LinearCodec{{Scale: 0, Width: 2}, {Scale: 1, Width: 0}, {Scale: 1, Width: 9}, {Scale: math.NaN(), Width: 1}, {Scale: 1, Offset: math.Inf(-1), Width: 1}} {
+		if _, err := c.Encode(1); err == nil {
+			t.Errorf("%+v: expected error", c)
+		}
+	}
+}
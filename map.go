@@ -0,0 +1,107 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+)
+
+// MapToBytes encodes string map deterministically: entries are sorted by key,
+// so equal maps always produce equal bytes (safe for hashing and signing):
+//
+//	[ count (uvarint) | keyLen (uvarint) | key | valueLen (uvarint) | value | ... ]
+func MapToBytes(m map[string]string) []byte {
+	return AppendMap(nil, m)
+}
+
+// AppendMap appends encoding of m (see MapToBytes) to dst.
+func AppendMap(dst []byte, m map[string]string) []byte {
+	keys := make([]string, 0, len(m))
+	size := binary.MaxVarintLen64
+	for k, v := range m {
+		keys = append(keys, k)
+		size += 2*binary.MaxVarintLen64 + len(k) + len(v)
+	}
+	slices.Sort(keys)
+
+	out := slices.Grow(dst, size)
+	out = binary.AppendUvarint(out, uint64(len(keys)))
+	for _, k := range keys {
+		out = appendUvarintString(out, k)
+		out = appendUvarintString(out, m[k])
+	}
+
+	return out
+}
+
+// MapFromBytes decodes map written by MapToBytes, data must contain exactly one map.
+// Keys must be in strictly ascending order, so duplicated or unsorted keys are rejected.
+// Declared count and lengths are also checked against Limits given WithLimits.
+func MapFromBytes(data []byte, opts ...DecodeOption) (map[string]string, error) {
+	return mapFromBytes(data, &newDecodeConfig(opts).limits)
+}
+
+func mapFromBytes(data []byte, limits *Limits) (map[string]string, error) {
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid map entry count")
+	}
+	rest := data[n:]
+
+	// every entry takes at least 2 bytes (two zero lengths)
+	if count > uint64(len(rest)/2) {
+		return nil, fmt.Errorf("declared %d map entries, but only %d bytes left", count, len(rest))
+	}
+	if err := limits.checkElements(count); err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string, count)
+	prev := ""
+	for i := uint64(0); i < count; i++ {
+		var k, v string
+		var err error
+
+		if k, rest, err = readUvarintString(rest, limits); err != nil {
+			return nil, fmt.Errorf("map entry %d key: %w", i, err)
+		}
+		if v, rest, err = readUvarintString(rest, limits); err != nil {
+			return nil, fmt.Errorf("map entry %d value: %w", i, err)
+		}
+
+		if i > 0 && k <= prev {
+			return nil, fmt.Errorf("map entry %d: key %q is not greater than previous key %q", i, k, prev)
+		}
+		prev = k
+
+		m[k] = v
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d unexpected trailing bytes after map", len(rest))
+	}
+
+	return m, nil
+}
+
+func appendUvarintString(dst []byte, s string) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(s)))
+	return append(dst, s...)
+}
+
+func readUvarintString(data []byte, limits *Limits) (string, []byte, error) {
+	l, n := binary.Uvarint(data)
+	if n <= 0 {
+		return "", nil, fmt.Errorf("invalid length prefix")
+	}
+	data = data[n:]
+
+	if l > uint64(len(data)) {
+		return "", nil, fmt.Errorf("declared length %d, but only %d bytes left", l, len(data))
+	}
+	if err := limits.checkStringLength(l); err != nil {
+		return "", nil, err
+	}
+
+	return string(data[:l]), data[l:], nil
+}
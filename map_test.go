@@ -0,0 +1,57 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestMapToBytes(t *testing.T) {
+	m := map[string]string{"b": "2", "a": "1"}
+
+	out := MapToBytes(m)
+	if got := hex.EncodeToString(out); got != "02"+"0161"+"0131"+"0162"+"0132" {
+		t.Fatalf("unexpected layout %s", got)
+	}
+
+	// deterministic regardless of map iteration order
+	for i := 0; i < 10; i++ {
+		if !bytes.Equal(MapToBytes(maps.Clone(m)), out) {
+			t.Fatal("encoding must be deterministic")
+		}
+	}
+
+	for _, in := range []map[string]string{
+		{},
+		{"": ""},
+		{"long": strings.Repeat("x", 300), "env": "prod", "ключ": "значення"},
+	} {
+		back, err := MapFromBytes(MapToBytes(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !maps.Equal(back, in) {
+			t.Fatalf("expected %v got %v", in, back)
+		}
+	}
+}
+
+func TestMapFromBytesMalformed(t *testing.T) {
+	cases := map[string]string{
+		"empty":          "",
+		"hostile count":  "ffffffff0f",
+		"unsorted keys":  "02" + "0162" + "0132" + "0161" + "0131",
+		"duplicate keys": "02" + "0161" + "0131" + "0161" + "0132",
+		"short value":    "01" + "0161" + "0531",
+		"trailing bytes": "00" + "00",
+	}
+
+	for name, h := range cases {
+		data, _ := hex.DecodeString(h)
+		if _, err := MapFromBytes(data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
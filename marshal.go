@@ -0,0 +1,359 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"reflect"
+)
+
+// ErrDigestMismatch is returned by VerifyDigest when data does not match its trailing digest.
+var ErrDigestMismatch = errors.New("digest mismatch")
+
+// Marshal encodes single value of supported type using fixed layouts of this package:
+//
+//	bool                          → 1 byte (BoolTo1Byte)
+//	int8/16/32/64, uint8/16/32/64 → 1/2/4/8 bytes, big-endian (two's complement for signed)
+//	*big.Int                      → sign byte + minimal magnitude (BigIntToMinimalBytes)
+//	string                        → 256 bytes (StringTo256Bytes)
+//	[]byte                        → raw bytes as is
+//	[]string                      → count + length-prefixed entries (StringsToBytes)
+//	map[string]string             → sorted entries (MapToBytes)
+//	Bytes8/16/20/32/64, Address   → raw bytes of the array
+//	struct or pointer to struct   → fields one by one, see Schema
+//	type registered with Register → its own encoding
+//	BytecastMarshaler             → its own encoding
+//
+// Decode result with Unmarshal into pointer to the same type.
+func Marshal(v any) ([]byte, error) {
+	return MarshalAppend(nil, v)
+}
+
+// MarshalAppend appends encoding of v (see Marshal) to dst and returns the extended buffer,
+// so hot encode loops can reuse one buffer. On error dst is returned unchanged.
+func MarshalAppend(dst []byte, v any) ([]byte, error) {
+	out, err := appendValue(dst, v)
+	if err != nil {
+		return dst, err
+	}
+	return out, nil
+}
+
+// BufferTooSmallError is returned by MarshalTo when encoding of the value does not fit the buffer.
+// It matches io.ErrShortBuffer with errors.Is.
+type BufferTooSmallError struct {
+	Need int // encoded size of the value
+	Have int // length of the buffer
+}
+
+func (e *BufferTooSmallError) Error() string {
+	return fmt.Sprintf("buffer too small: need %d bytes, have %d", e.Need, e.Have)
+}
+
+func (e *BufferTooSmallError) Unwrap() error {
+	return io.ErrShortBuffer
+}
+
+// MarshalTo encodes v (see Marshal) into the beginning of buf and returns number of bytes written,
+// for encoding directly into pre-sized network or DMA buffers. If the encoding is longer than buf,
+// nothing is written and *BufferTooSmallError is returned; on other errors buf content is unspecified.
+func MarshalTo(buf []byte, v any) (int, error) {
+	size, err := SizeOf(v)
+	if err != nil {
+		return 0, err
+	}
+	if size > len(buf) {
+		return 0, &BufferTooSmallError{Need: size, Have: len(buf)}
+	}
+
+	out, err := appendValue(buf[:0:len(buf)], v)
+	if err != nil {
+		return 0, err
+	}
+	return len(out), nil
+}
+
+// Unmarshal decodes data produced by Marshal into v, which must be non-nil pointer to supported type.
+// data must contain exactly one encoded value, extra bytes after fixed-size value yield ErrTrailingBytes
+// (use Decode with AllowTrailingBytes to ignore them). Struct fields decoded before an error are left modified.
+func Unmarshal(data []byte, v any) error {
+	return unmarshal(data, v, &decodeConfig{})
+}
+
+func unmarshal(data []byte, v any, c *decodeConfig) error {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		if c := lookupCustomCodec(rv.Type().Elem()); c != nil {
+			x, err := c.decode(data)
+			if err != nil {
+				return err
+			}
+			rv.Elem().Set(reflect.ValueOf(x))
+			return nil
+		}
+	}
+
+	if err := checkTrailingBytes(data, v); err != nil {
+		return err
+	}
+
+	switch p := v.(type) {
+	case *bool:
+		b, err := exactBytes[[1]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = BoolFrom1Byte(b)
+		return nil
+	case *int8:
+		b, err := exactBytes[[1]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = Int8From1Byte(b)
+		return nil
+	case *int16:
+		b, err := exactBytes[[2]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = Int16From2Bytes(b)
+		return nil
+	case *int32:
+		b, err := exactBytes[[4]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = Int32From4Bytes(b)
+		return nil
+	case *int64:
+		b, err := exactBytes[[8]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = Int64From8Bytes(b)
+		return nil
+	case *uint8:
+		b, err := exactBytes[[1]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = Uint8From1Byte(b)
+		return nil
+	case *uint16:
+		b, err := exactBytes[[2]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = Uint16From2Bytes(b)
+		return nil
+	case *uint32:
+		b, err := exactBytes[[4]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = Uint32From4Bytes(b)
+		return nil
+	case *uint64:
+		b, err := exactBytes[[8]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = binary.BigEndian.Uint64(b[:])
+		return nil
+	case **big.Int:
+		x, err := BigIntFromMinimalBytes(data)
+		if err != nil {
+			return err
+		}
+		*p = x
+		return nil
+	case *big.Int:
+		x, err := BigIntFromMinimalBytes(data)
+		if err != nil {
+			return err
+		}
+		p.Set(x)
+		return nil
+	case *string:
+		b, err := exactBytes[[256]byte](data)
+		if err != nil {
+			return err
+		}
+		*p = StringFrom256Bytes(b)
+		return nil
+	case *[]byte:
+		*p = append((*p)[:0], data...)
+		return nil
+	case *[]string:
+		values, err := stringsFromBytes(data, &c.limits)
+		if err != nil {
+			return err
+		}
+		*p = values
+		return nil
+	case *map[string]string:
+		m, err := mapFromBytes(data, &c.limits)
+		if err != nil {
+			return err
+		}
+		*p = m
+		return nil
+	case *Bytes8:
+		return fixedFromSlice(p[:], data)
+	case *Bytes16:
+		return fixedFromSlice(p[:], data)
+	case *Bytes20:
+		return fixedFromSlice(p[:], data)
+	case *Bytes32:
+		return fixedFromSlice(p[:], data)
+	case *Bytes64:
+		return fixedFromSlice(p[:], data)
+	case *Address:
+		return fixedFromSlice(p[:], data)
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct {
+		s, err := SchemaOf(rv.Type())
+		if err != nil {
+			return err
+		}
+		if err := c.limits.checkDepth(s.depth); err != nil {
+			return err
+		}
+		return s.decodeStruct(data, rv.Elem(), c)
+	}
+
+	return fmt.Errorf("unsupported type %T for Unmarshal", v)
+}
+
+// EncodeWithDigest marshals v and appends digest of the encoded bytes computed by h:
+//
+//	[ Marshal(v) | h(Marshal(v)) ]
+//
+// h is reset before use. Verify and decode result with VerifyDigest using the same hash.
+func EncodeWithDigest(v any, h hash.Hash) ([]byte, error) {
+	data, err := Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	h.Reset()
+	h.Write(data)
+
+	return h.Sum(data), nil
+}
+
+// VerifyDigest checks trailing digest of data produced by EncodeWithDigest and, if it matches,
+// unmarshals the value into v. Mismatch yields ErrDigestMismatch, v is left untouched then.
+// Digest is compared in constant time.
+func VerifyDigest(data []byte, h hash.Hash, v any) error {
+	size := h.Size()
+	if len(data) < size {
+		return fmt.Errorf("data of %d bytes too short to contain %d-byte digest", len(data), size)
+	}
+
+	payload, digest := data[:len(data)-size], data[len(data)-size:]
+
+	h.Reset()
+	h.Write(payload)
+
+	if !EqualConstantTime(h.Sum(nil), digest) {
+		return ErrDigestMismatch
+	}
+
+	return Unmarshal(payload, v)
+}
+
+// appendValue appends encoding of v (see Marshal) to dst.
+func appendValue(dst []byte, v any) ([]byte, error) {
+	if c := lookupCustomCodec(reflect.TypeOf(v)); c != nil {
+		b, err := c.encode(v)
+		return append(dst, b...), err
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		if c := lookupCustomCodec(rv.Type().Elem()); c != nil {
+			b, err := c.encode(rv.Elem().Interface())
+			return append(dst, b...), err
+		}
+	}
+
+	switch x := v.(type) {
+	case bool:
+		b := BoolTo1Byte(x)
+		return append(dst, b[:]...), nil
+	case int8:
+		return append(dst, byte(x)), nil
+	case int16:
+		b := Int16To2Bytes(x)
+		return append(dst, b[:]...), nil
+	case int32:
+		b := Int32To4Bytes(x)
+		return append(dst, b[:]...), nil
+	case int64:
+		b := Int64To8Bytes(x)
+		return append(dst, b[:]...), nil
+	case uint8:
+		return append(dst, x), nil
+	case uint16:
+		b := Uint16To2Bytes(x)
+		return append(dst, b[:]...), nil
+	case uint32:
+		b := Uint32To4Bytes(x)
+		return append(dst, b[:]...), nil
+	case uint64:
+		return binary.BigEndian.AppendUint64(dst, x), nil
+	case *big.Int:
+		if x == nil {
+			return dst, fmt.Errorf("nil *big.Int")
+		}
+		return append(dst, BigIntToMinimalBytes(x)...), nil
+	case string:
+		return AppendString256(dst, x)
+	case []byte:
+		return append(dst, x...), nil
+	case []string:
+		return AppendStrings(dst, x)
+	case map[string]string:
+		return AppendMap(dst, x), nil
+	case Bytes8:
+		return append(dst, x[:]...), nil
+	case Bytes16:
+		return append(dst, x[:]...), nil
+	case Bytes20:
+		return append(dst, x[:]...), nil
+	case Bytes32:
+		return append(dst, x[:]...), nil
+	case Bytes64:
+		return append(dst, x[:]...), nil
+	case Address:
+		return append(dst, x[:]...), nil
+	}
+
+	if isStructValue(v) {
+		rv := reflect.Indirect(reflect.ValueOf(v))
+		if !rv.IsValid() {
+			return dst, fmt.Errorf("nil %T", v)
+		}
+
+		s, err := SchemaOf(rv.Type())
+		if err != nil {
+			return dst, err
+		}
+		return s.appendStruct(dst, rv)
+	}
+
+	return dst, fmt.Errorf("unsupported type %T for Marshal", v)
+}
+
+// exactBytes converts data to fixed-size array, data length must match array size exactly.
+func exactBytes[A [1]byte | [2]byte | [4]byte | [8]byte | [256]byte](data []byte) (A, error) {
+	var out A
+	if len(data) != len(out) {
+		return out, fmt.Errorf("expected exactly %d bytes, but got %d bytes", len(out), len(data))
+	}
+	return A(data), nil
+}
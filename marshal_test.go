@@ -0,0 +1,228 @@
+package bytecast
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"hash/crc32"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	cases := []struct {
+		value any
+		size  int
+	}{
+		{true, 1},
+		{int8(-1), 1},
+		{int16(math.MinInt16), 2},
+		{int32(-123456), 4},
+		{int64(math.MinInt64), 8},
+		{uint8(200), 1},
+		{uint16(math.MaxUint16), 2},
+		{uint32(math.MaxUint32), 4},
+		{uint64(math.MaxUint64), 8},
+		{big.NewInt(-1000), 3},
+		{"hello", 256},
+		{[]byte{1, 2, 3}, 3},
+		{Bytes8{1}, 8},
+		{Bytes32{31: 1}, 32},
+		{Address{19: 1}, 20},
+	}
+
+	for _, c := range cases {
+		data, err := Marshal(c.value)
+		if err != nil {
+			t.Fatalf("Marshal(%v) returned error: %v", c.value, err)
+		}
+		if len(data) != c.size {
+			t.Fatalf("Marshal(%v): expected %d bytes, got %d", c.value, c.size, len(data))
+		}
+
+		target := reflect.New(reflect.TypeOf(c.value))
+		if err := Unmarshal(data, target.Interface()); err != nil {
+			t.Fatalf("Unmarshal(%T) returned error: %v", c.value, err)
+		}
+
+		got := target.Elem().Interface()
+		if x, ok := c.value.(*big.Int); ok {
+			if x.Cmp(got.(*big.Int)) != 0 {
+				t.Fatalf("expected %s got %s", x, got)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, c.value) {
+			t.Fatalf("expected %v got %v", c.value, got)
+		}
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	if _, err := Marshal(3.14); err == nil {
+		t.Fatal("expected error for unsupported type")
+	}
+
+	if _, err := Marshal((*big.Int)(nil)); err == nil {
+		t.Fatal("expected error for nil *big.Int")
+	}
+
+	var i32 int32 = 7
+	if err := Unmarshal([]byte{1, 2, 3}, &i32); err == nil || i32 != 7 {
+		t.Fatalf("expected length error and untouched target, got %d (%v)", i32, err)
+	}
+
+	var f float64
+	if err := Unmarshal([]byte{1}, &f); err == nil {
+		t.Fatal("expected error for unsupported type")
+	}
+}
+
+func TestEncodeWithDigest(t *testing.T) {
+	data, err := EncodeWithDigest(int32(-42), sha256.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 4+sha256.Size {
+		t.Fatalf("expected %d bytes, got %d", 4+sha256.Size, len(data))
+	}
+
+	var got int32
+	if err := VerifyDigest(data, sha256.New(), &got); err != nil || got != -42 {
+		t.Fatalf("expected -42, got %d (%v)", got, err)
+	}
+
+	data[0] ^= 0x01
+	got = 0
+	if err := VerifyDigest(data, sha256.New(), &got); !errors.Is(err, ErrDigestMismatch) || got != 0 {
+		t.Fatalf("expected ErrDigestMismatch and untouched target, got %d (%v)", got, err)
+	}
+
+	if err := VerifyDigest([]byte{1, 2}, crc32.NewIEEE(), &got); err == nil {
+		t.Fatal("expected error for data shorter than digest")
+	}
+}
+
+func TestMarshalAppend(t *testing.T) {
+	prefix := []byte{0xaa, 0xbb}
+	values := []any{
+		uint16(0x0102), "hi", big.NewInt(-5), []string{"a", "b"}, map[string]string{"k": "v"},
+		dumpTestRecord{Version: 1, Delta: -1, Ok: true}, &optionalTestRecord{ID: 3},
+	}
+	for _, v := range values {
+		want, err := Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := MarshalAppend(bytes.Clone(prefix), v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, append(bytes.Clone(prefix), want...)) {
+			t.Errorf("MarshalAppend(%T) = % x, expected prefix + % x", v, got, want)
+		}
+	}
+
+	// failed append leaves dst as is
+	got, err := MarshalAppend(prefix, struct {
+		N uint16 `bytecast:"width=1"`
+	}{N: 300})
+	if err == nil || !bytes.Equal(got, prefix) {
+		t.Fatalf("expected error and unchanged dst, got % x (%v)", got, err)
+	}
+}
+
+func TestMarshalAppendAllocs(t *testing.T) {
+	rec := &dumpTestRecord{Version: 1, Delta: 2, Ok: true}
+	buf := make([]byte, 0, 64)
+	if _, err := MarshalAppend(buf, rec); err != nil { // warm schema cache
+		t.Fatal(err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = MarshalAppend(buf[:0], rec)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations with preallocated buffer, got %v", allocs)
+	}
+}
+
+func TestCompositeAppend(t *testing.T) {
+	prefix := []byte{0xff}
+
+	enum, err := NewEnum[uint16]("color", 2, map[uint16]string{1: "red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	union := NewUnion()
+	if err := RegisterVariant[uint8](union, 7); err != nil {
+		t.Fatal(err)
+	}
+	versioned := NewVersioned[uint8](2)
+
+	cases := []struct {
+		name   string
+		append func([]byte) ([]byte, error)
+		encode func() ([]byte, error)
+	}{
+		{"strings", func(b []byte) ([]byte, error) { return AppendStrings(b, []string{"x"}) }, func() ([]byte, error) { return StringsToBytes([]string{"x"}) }},
+		{"map", func(b []byte) ([]byte, error) { return AppendMap(b, map[string]string{"a": "b"}), nil }, func() ([]byte, error) { return MapToBytes(map[string]string{"a": "b"}), nil }},
+		{"optional", func(b []byte) ([]byte, error) { return AppendOptional(b, Some(uint8(1))) }, func() ([]byte, error) { return OptionalToBytes(Some(uint8(1))) }},
+		{"enum", func(b []byte) ([]byte, error) { return enum.Append(b, 1) }, func() ([]byte, error) { return enum.Encode(1) }},
+		{"union", func(b []byte) ([]byte, error) { return union.Append(b, uint8(9)) }, func() ([]byte, error) { return union.Encode(uint8(9)) }},
+		{"versioned", func(b []byte) ([]byte, error) { return versioned.Append(b, 9) }, func() ([]byte, error) { return versioned.Encode(9) }},
+		{"words", func(b []byte) ([]byte, error) { return AppendWords32(b, uint8(1), true) }, func() ([]byte, error) { return PackWords32(uint8(1), true) }},
+	}
+	for _, c := range cases {
+		want, err := c.encode()
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		got, err := c.append(bytes.Clone(prefix))
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if !bytes.Equal(got, append(bytes.Clone(prefix), want...)) {
+			t.Errorf("%s: got % x, expected prefix + % x", c.name, got, want)
+		}
+	}
+
+	if got, err := enum.Append(prefix, 5); err == nil || !bytes.Equal(got, prefix) {
+		t.Errorf("expected error and unchanged dst for unknown enum value, got % x (%v)", got, err)
+	}
+}
+
+func TestMarshalTo(t *testing.T) {
+	buf := make([]byte, 32)
+	for i := range buf {
+		buf[i] = 0xee
+	}
+
+	n, err := MarshalTo(buf, dumpTestRecord{Version: 1, Delta: -2, Ok: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := Marshal(dumpTestRecord{Version: 1, Delta: -2, Ok: true})
+	if n != len(want) || !bytes.Equal(buf[:n], want) || buf[n] != 0xee {
+		t.Fatalf("expected % x written, got %d bytes: % x", want, n, buf)
+	}
+
+	n, err = MarshalTo(buf[:3], uint32(7))
+	var tooSmall *BufferTooSmallError
+	if !errors.As(err, &tooSmall) || tooSmall.Need != 4 || tooSmall.Have != 3 || n != 0 {
+		t.Fatalf("expected BufferTooSmallError{4, 3}, got %d, %v", n, err)
+	}
+	if !errors.Is(err, io.ErrShortBuffer) {
+		t.Errorf("expected error to match io.ErrShortBuffer")
+	}
+	if buf[0] != 0x01 {
+		t.Errorf("expected buffer untouched on error")
+	}
+
+	if _, err := MarshalTo(buf, int(1)); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
@@ -0,0 +1,105 @@
+package bytecast
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	mjdEpochUnix = -3506716800 // 1858-11-17 00:00:00 UTC, MJD 0
+	nsPerDay     = 86400 * int64(time.Second)
+
+	// MJDOffset is difference between Julian Date and Modified Julian Date: MJD = JD - MJDOffset.
+	MJDOffset = 2400000.5
+)
+
+// ModifiedJulianDate returns Modified Julian Date of t (days since 1858-11-17 00:00 UTC, with fraction).
+// float64 keeps ~10 µs precision for present-day dates, use MJDToBytes for exact day and fraction.
+// Leap seconds are not counted, every day has 86400 seconds.
+func ModifiedJulianDate(t time.Time) float64 {
+	return float64(t.Unix()-mjdEpochUnix)/86400 + float64(t.Nanosecond())/float64(nsPerDay)
+}
+
+// JulianDate returns Julian Date of t, see ModifiedJulianDate.
+func JulianDate(t time.Time) float64 {
+	return ModifiedJulianDate(t) + MJDOffset
+}
+
+// MJDToBytes
+//
+//	Encodes t as Modified Julian Date day count followed by fraction of the day, as used by
+//	satellite telemetry (CCSDS day segmented time) and broadcast (DVB 16-bit MJD) formats:
+//
+//	[ MJD day (dayWidth bytes, unsigned) | day fraction (fractionWidth bytes, unsigned binary fraction) ]
+//
+//	dayWidth is 1..8 bytes, fractionWidth is 0..8 bytes (0 stores day only). Fraction is
+//	nanoseconds of day scaled to 2^(8*fractionWidth) and truncated, e.g. 4 bytes give ~20 µs resolution.
+//	Dates before 1858-11-17 and day counts not fitting dayWidth are rejected.
+func MJDToBytes(t time.Time, dayWidth int, fractionWidth int) ([]byte, error) {
+	if err := validateMJDWidths(dayWidth, fractionWidth); err != nil {
+		return nil, err
+	}
+
+	seconds := t.Unix() - mjdEpochUnix
+	if seconds < 0 {
+		return nil, fmt.Errorf("time %v is before MJD epoch 1858-11-17", t)
+	}
+
+	day := uint64(seconds / 86400)
+	out, err := UintXXToBytesAndExpandWidth(day, min(8*dayWidth, 64), dayWidth)
+	if err != nil {
+		return nil, fmt.Errorf("MJD %d does not fit %d bytes", day, dayWidth)
+	}
+
+	if fractionWidth == 0 {
+		return out, nil
+	}
+
+	ns := big.NewInt(seconds%86400*int64(time.Second) + int64(t.Nanosecond()))
+	fraction := ns.Lsh(ns, uint(8*fractionWidth))
+	fraction.Quo(fraction, big.NewInt(nsPerDay))
+
+	return append(out, fraction.FillBytes(make([]byte, fractionWidth))...), nil
+}
+
+// MJDFromBytes
+//
+//	Decodes value written by MJDToBytes, fraction width is len(b)-dayWidth.
+//	Fraction is rounded to the nearest nanosecond. Returns UTC time.
+func MJDFromBytes(b []byte, dayWidth int) (time.Time, error) {
+	fractionWidth := len(b) - dayWidth
+	if err := validateMJDWidths(dayWidth, fractionWidth); err != nil {
+		return time.Time{}, err
+	}
+
+	day, err := UintXXFromBytes(b[:dayWidth], min(8*dayWidth, 64))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if day > (1<<63-1-mjdEpochUnix)/86400 {
+		return time.Time{}, fmt.Errorf("MJD %d is out of range", day)
+	}
+
+	t := time.Unix(int64(day)*86400+mjdEpochUnix, 0).UTC()
+	if fractionWidth == 0 {
+		return t, nil
+	}
+
+	ns := new(big.Int).SetBytes(b[dayWidth:])
+	ns.Mul(ns, big.NewInt(nsPerDay))
+	ns.Add(ns, new(big.Int).Lsh(big.NewInt(1), uint(8*fractionWidth-1))) // round half up
+	ns.Rsh(ns, uint(8*fractionWidth))
+
+	return t.Add(time.Duration(ns.Int64())), nil
+}
+
+func validateMJDWidths(dayWidth int, fractionWidth int) error {
+	if dayWidth < 1 || dayWidth > 8 {
+		return fmt.Errorf("unsupported MJD day width %d, must be 1..8 bytes", dayWidth)
+	}
+	if fractionWidth < 0 || fractionWidth > 8 {
+		return fmt.Errorf("unsupported MJD fraction width %d, must be 0..8 bytes", fractionWidth)
+	}
+	return nil
+}
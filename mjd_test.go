@@ -0,0 +1,81 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestModifiedJulianDate(t *testing.T) {
+	cases := []struct {
+		t   time.Time
+		mjd float64
+	}{
+		{time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 51544.5}, // J2000.0
+		{time.Unix(0, 0), 40587},
+	}
+	for _, c := range cases {
+		if got := ModifiedJulianDate(c.t); math.Abs(got-c.mjd) > 1e-9 {
+			t.Errorf("ModifiedJulianDate(%v) = %v, expected %v", c.t, got, c.mjd)
+		}
+	}
+
+	if jd := JulianDate(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)); jd != 2451545 {
+		t.Errorf("expected JD 2451545 for J2000.0, got %v", jd)
+	}
+}
+
+func TestMJDBytes(t *testing.T) {
+	cases := []struct {
+		t                       time.Time
+		dayWidth, fractionWidth int
+		hex                     string
+	}{
+		// DVB example: 1993-10-13 is MJD 0xC079
+		{time.Date(1993, 10, 13, 12, 45, 0, 0, time.UTC), 2, 0, "c079"},
+		{time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 3, 4, "00c95880000000"},
+		{time.Date(2000, 1, 1, 6, 0, 0, 0, time.UTC), 2, 1, "c95840"},
+	}
+	for _, c := range cases {
+		b, err := MJDToBytes(c.t, c.dayWidth, c.fractionWidth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("MJDToBytes(%v) = %s, expected %s", c.t, got, c.hex)
+		}
+	}
+
+	// nanosecond precision with 8-byte fraction
+	tm := time.Date(2024, 5, 1, 13, 14, 15, 123456789, time.UTC)
+	b, err := MJDToBytes(tm, 4, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := MJDFromBytes(b, 4); err != nil || !got.Equal(tm) {
+		t.Fatalf("expected %v, got %v (%v)", tm, got, err)
+	}
+
+	// 4-byte fraction keeps ~20 µs
+	b, _ = MJDToBytes(tm, 4, 4)
+	if got, err := MJDFromBytes(b, 4); err != nil || got.Sub(tm).Abs() > 21*time.Microsecond {
+		t.Fatalf("expected %v within 21µs, got %v (%v)", tm, got, err)
+	}
+}
+
+func TestMJDBytesErrors(t *testing.T) {
+	if _, err := MJDToBytes(time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), 4, 0); err == nil {
+		t.Error("expected error before MJD epoch")
+	}
+	if _, err := MJDToBytes(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), 2, 0); err == nil {
+		t.Error("expected error for MJD not fitting 2 bytes")
+	}
+	if _, err := MJDToBytes(time.Now(), 0, 2); err == nil {
+		t.Error("expected error for zero day width")
+	}
+	if _, err := MJDFromBytes([]byte{1, 2, 3}, 4); err == nil {
+		t.Error("expected error for short input")
+	}
+}
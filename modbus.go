@@ -0,0 +1,158 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ModbusOrder is the order of bytes of multi-register values, named after the position of bytes of
+// big-endian value ABCD in consecutive registers. Vendors disagree on it, check device documentation.
+type ModbusOrder int
+
+const (
+	ModbusABCD ModbusOrder = iota // big-endian, high word first (Modbus default)
+	ModbusCDAB                    // word swap: low word first, bytes inside registers big-endian
+	ModbusBADC                    // byte swap: high word first, bytes inside registers swapped
+	ModbusDCBA                    // little-endian: low word first, bytes swapped
+)
+
+// RegistersToBytes encodes registers as they appear in Modbus PDU, each register big-endian.
+func RegistersToBytes(regs []uint16) []byte {
+	out := make([]byte, 0, 2*len(regs))
+	for _, r := range regs {
+		out = binary.BigEndian.AppendUint16(out, r)
+	}
+	return out
+}
+
+// RegistersFromBytes decodes Modbus PDU register data, len(b) must be even.
+func RegistersFromBytes(b []byte) ([]uint16, error) {
+	if len(b)%2 != 0 {
+		return nil, fmt.Errorf("expected even number of bytes for registers, but got %d bytes", len(b))
+	}
+	regs := make([]uint16, len(b)/2)
+	for i := range regs {
+		regs[i] = binary.BigEndian.Uint16(b[2*i:])
+	}
+	return regs, nil
+}
+
+// ModbusUint32ToRegisters splits v into 2 registers in given order.
+func ModbusUint32ToRegisters(v uint32, order ModbusOrder) ([2]uint16, error) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+
+	regs, err := modbusToRegisters(b[:], order)
+	if err != nil {
+		return [2]uint16{}, err
+	}
+	return [2]uint16(regs), nil
+}
+
+// ModbusUint32FromRegisters combines exactly 2 registers in given order.
+func ModbusUint32FromRegisters(regs []uint16, order ModbusOrder) (uint32, error) {
+	b, err := modbusFromRegisters(regs, 2, order)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+// ModbusFloat32ToRegisters splits IEEE 754 single-precision v into 2 registers in given order,
+// opts set NaN/Inf policy (see FloatOption).
+func ModbusFloat32ToRegisters(v float32, order ModbusOrder, opts ...FloatOption) ([2]uint16, error) {
+	v, err := newFloatPolicy(opts).apply32(v)
+	if err != nil {
+		return [2]uint16{}, err
+	}
+	return ModbusUint32ToRegisters(math.Float32bits(v), order)
+}
+
+// ModbusFloat32FromRegisters combines exactly 2 registers in given order into float32.
+func ModbusFloat32FromRegisters(regs []uint16, order ModbusOrder) (float32, error) {
+	u, err := ModbusUint32FromRegisters(regs, order)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float32frombits(u), nil
+}
+
+// ModbusStringToRegisters
+//
+//	Packs ASCII string into n registers, two characters per register, first character in the high byte
+//	(or in the low byte if byteSwap is set). Unused bytes are zero.
+func ModbusStringToRegisters(s string, n int, byteSwap bool) ([]uint16, error) {
+	if len(s) > 2*n {
+		return nil, fmt.Errorf("string of %d bytes does not fit %d registers", len(s), n)
+	}
+
+	b := make([]byte, 2*n)
+	copy(b, s)
+	if byteSwap {
+		swapRegisterBytes(b)
+	}
+
+	return RegistersFromBytes(b)
+}
+
+// ModbusStringFromRegisters unpacks string written by ModbusStringToRegisters,
+// trailing NUL bytes and spaces (both used as padding by devices) are removed.
+func ModbusStringFromRegisters(regs []uint16, byteSwap bool) string {
+	b := RegistersToBytes(regs)
+	if byteSwap {
+		swapRegisterBytes(b)
+	}
+	return string(bytes.TrimRight(b, "\x00 "))
+}
+
+// modbusToRegisters converts big-endian value bytes into registers in given order.
+func modbusToRegisters(b []byte, order ModbusOrder) ([]uint16, error) {
+	b = slices.Clone(b)
+	if err := modbusReorder(b, order); err != nil {
+		return nil, err
+	}
+	return RegistersFromBytes(b)
+}
+
+// modbusFromRegisters converts exactly n registers in given order into big-endian value bytes.
+func modbusFromRegisters(regs []uint16, n int, order ModbusOrder) ([]byte, error) {
+	if len(regs) != n {
+		return nil, fmt.Errorf("expected exactly %d registers, but got %d", n, len(regs))
+	}
+	b := RegistersToBytes(regs)
+	if err := modbusReorder(b, order); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// modbusReorder converts between big-endian value and register layout in place, the conversion is its own inverse.
+func modbusReorder(b []byte, order ModbusOrder) error {
+	switch order {
+	case ModbusABCD:
+	case ModbusCDAB:
+		swapRegisterOrder(b)
+	case ModbusBADC:
+		swapRegisterBytes(b)
+	case ModbusDCBA:
+		slices.Reverse(b)
+	default:
+		return fmt.Errorf("unknown modbus order %d", order)
+	}
+	return nil
+}
+
+func swapRegisterBytes(b []byte) {
+	for i := 0; i+1 < len(b); i += 2 {
+		b[i], b[i+1] = b[i+1], b[i]
+	}
+}
+
+func swapRegisterOrder(b []byte) {
+	for i, j := 0, len(b)-2; i < j; i, j = i+2, j-2 {
+		b[i], b[i+1], b[j], b[j+1] = b[j], b[j+1], b[i], b[i+1]
+	}
+}
@@ -0,0 +1,92 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"slices"
+	"testing"
+)
+
+func TestModbusFloat32(t *testing.T) {
+	// 123.456 = 0x42F6E979
+	cases := []struct {
+		order ModbusOrder
+		regs  [2]uint16
+	}{
+		{ModbusABCD, [2]uint16{0x42F6, 0xE979}},
+		{ModbusCDAB, [2]uint16{0xE979, 0x42F6}},
+		{ModbusBADC, [2]uint16{0xF642, 0x79E9}},
+		{ModbusDCBA, [2]uint16{0x79E9, 0xF642}},
+	}
+	for _, c := range cases {
+		regs, err := ModbusFloat32ToRegisters(123.456, c.order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if regs != c.regs {
+			t.Errorf("order %d: got %04X, expected %04X", c.order, regs, c.regs)
+		}
+		if v, err := ModbusFloat32FromRegisters(c.regs[:], c.order); err != nil || v != 123.456 {
+			t.Errorf("order %d: decoded %v (%v)", c.order, v, err)
+		}
+		if v, err := ModbusUint32FromRegisters(c.regs[:], c.order); err != nil || v != 0x42F6E979 {
+			t.Errorf("order %d: decoded %#x (%v)", c.order, v, err)
+		}
+	}
+}
+
+func TestModbusRegistersBytes(t *testing.T) {
+	pdu, _ := hex.DecodeString("000a0102ffff")
+	regs, err := RegistersFromBytes(pdu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(regs, []uint16{10, 0x0102, 0xFFFF}) {
+		t.Errorf("unexpected registers %04X", regs)
+	}
+	if got := hex.EncodeToString(RegistersToBytes(regs)); got != "000a0102ffff" {
+		t.Errorf("unexpected bytes %s", got)
+	}
+	if _, err := RegistersFromBytes([]byte{1, 2, 3}); err == nil {
+		t.Error("expected error for odd length")
+	}
+}
+
+func TestModbusString(t *testing.T) {
+	regs, err := ModbusStringToRegisters("PM5560", 4, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(regs, []uint16{0x504D, 0x3535, 0x3630, 0x0000}) {
+		t.Errorf("unexpected registers %04X", regs)
+	}
+	if s := ModbusStringFromRegisters(regs, false); s != "PM5560" {
+		t.Errorf("unexpected string %q", s)
+	}
+
+	swapped, _ := ModbusStringToRegisters("ABC", 2, true)
+	if !slices.Equal(swapped, []uint16{0x4241, 0x0043}) {
+		t.Errorf("unexpected byte-swapped registers %04X", swapped)
+	}
+	if s := ModbusStringFromRegisters(swapped, true); s != "ABC" {
+		t.Errorf("unexpected string %q", s)
+	}
+	if s := ModbusStringFromRegisters([]uint16{0x4142, 0x2020}, false); s != "AB" {
+		t.Errorf("expected space padding to be trimmed, got %q", s)
+	}
+
+	if _, err := ModbusStringToRegisters("ABCDE", 2, false); err == nil {
+		t.Error("expected error for string not fitting registers")
+	}
+}
+
+func TestModbusErrors(t *testing.T) {
+	if _, err := ModbusUint32ToRegisters(1, ModbusOrder(9)); err == nil {
+		t.Error("expected error for unknown order")
+	}
+	if _, err := ModbusUint32FromRegisters([]uint16{1}, ModbusABCD); err == nil {
+		t.Error("expected error for 1 register")
+	}
+	if _, err := ModbusFloat32FromRegisters([]uint16{1, 2, 3}, ModbusABCD); err == nil {
+		t.Error("expected error for 3 registers")
+	}
+}
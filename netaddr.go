@@ -0,0 +1,202 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+)
+
+// AddrToBytes
+//
+//	Encodes IP address with its IPv6 zone:
+//
+//	IPv4: [ 4 | address (4 bytes) ]
+//	IPv6: [ 6 | address (16 bytes) | zone length (1 byte) | zone ]
+//
+//	IPv4-mapped IPv6 addresses (::ffff:a.b.c.d) stay IPv6. Zero netip.Addr is encoded as single 0 byte.
+func AddrToBytes(a netip.Addr) ([]byte, error) {
+	return appendAddr(nil, a)
+}
+
+// AddrFromBytes decodes address written by AddrToBytes, b must contain exactly one address.
+func AddrFromBytes(b []byte) (netip.Addr, error) {
+	a, n, err := readAddr(b)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if n != len(b) {
+		return netip.Addr{}, fmt.Errorf("unexpected %d trailing bytes after address", len(b)-n)
+	}
+	return a, nil
+}
+
+// PrefixToBytes
+//
+//	Encodes IP prefix as address followed by prefix length:
+//
+//	[ 4 | address (4 bytes) | bits (1 byte) ]
+//	[ 6 | address (16 bytes) | bits (1 byte) ]
+//
+//	Address is stored as is, host bits are not masked (use Prefix.Masked before encoding if needed).
+func PrefixToBytes(p netip.Prefix) ([]byte, error) {
+	if !p.IsValid() {
+		return nil, fmt.Errorf("invalid prefix %v", p)
+	}
+
+	a := p.Addr()
+	out := make([]byte, 0, 2+a.BitLen()/8)
+	out = append(out, addrVersion(a))
+	out = append(out, a.AsSlice()...)
+	return append(out, byte(p.Bits())), nil
+}
+
+// PrefixFromBytes decodes prefix written by PrefixToBytes.
+func PrefixFromBytes(b []byte) (netip.Prefix, error) {
+	if len(b) < 1 {
+		return netip.Prefix{}, fmt.Errorf("expected at least 1 byte for prefix, but got 0 bytes")
+	}
+
+	size, err := addrSize(b[0])
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if size == 0 {
+		return netip.Prefix{}, fmt.Errorf("prefix can not have empty address")
+	}
+	if len(b) != 2+size {
+		return netip.Prefix{}, fmt.Errorf("expected %d bytes for IPv%d prefix, but got %d bytes", 2+size, b[0], len(b))
+	}
+
+	a, _ := netip.AddrFromSlice(b[1 : 1+size])
+	bits := int(b[1+size])
+	if bits > a.BitLen() {
+		return netip.Prefix{}, fmt.Errorf("prefix length %d exceeds %d bits of IPv%d", bits, a.BitLen(), b[0])
+	}
+
+	return netip.PrefixFrom(a, bits), nil
+}
+
+// AddrPortTo18Bytes
+//
+//	Encodes socket endpoint as 16-byte address followed by big-endian port:
+//
+//	[ address (16 bytes) | port (uint16) ]
+//
+//	IPv4 addresses are stored IPv4-mapped (::ffff:a.b.c.d). Zoned addresses are rejected,
+//	since zone does not fit the fixed layout.
+func AddrPortTo18Bytes(ap netip.AddrPort) ([18]byte, error) {
+	var out [18]byte
+
+	a := ap.Addr()
+	if !a.IsValid() {
+		return out, fmt.Errorf("invalid address in %v", ap)
+	}
+	if a.Zone() != "" {
+		return out, fmt.Errorf("zoned address %v can not be stored in 18 bytes", a)
+	}
+
+	a16 := a.As16()
+	copy(out[:16], a16[:])
+	binary.BigEndian.PutUint16(out[16:], ap.Port())
+	return out, nil
+}
+
+// AddrPortFrom18Bytes decodes endpoint written by AddrPortTo18Bytes.
+// IPv4-mapped addresses are returned as IPv4, so IPv4 endpoints round-trip as is.
+func AddrPortFrom18Bytes(b [18]byte) netip.AddrPort {
+	a := netip.AddrFrom16([16]byte(b[:16])).Unmap()
+	return netip.AddrPortFrom(a, binary.BigEndian.Uint16(b[16:]))
+}
+
+// AddrPortTo6Bytes encodes IPv4 endpoint as [ address (4 bytes) | port (uint16) ].
+// IPv4-mapped IPv6 addresses are accepted and unmapped, other IPv6 addresses are rejected.
+func AddrPortTo6Bytes(ap netip.AddrPort) ([6]byte, error) {
+	var out [6]byte
+
+	a := ap.Addr().Unmap()
+	if !a.Is4() {
+		return out, fmt.Errorf("address %v is not IPv4", ap.Addr())
+	}
+
+	a4 := a.As4()
+	copy(out[:4], a4[:])
+	binary.BigEndian.PutUint16(out[4:], ap.Port())
+	return out, nil
+}
+
+// AddrPortFrom6Bytes decodes endpoint written by AddrPortTo6Bytes.
+func AddrPortFrom6Bytes(b [6]byte) netip.AddrPort {
+	return netip.AddrPortFrom(netip.AddrFrom4([4]byte(b[:4])), binary.BigEndian.Uint16(b[4:]))
+}
+
+func appendAddr(dst []byte, a netip.Addr) ([]byte, error) {
+	if !a.IsValid() {
+		return append(dst, 0), nil
+	}
+
+	dst = append(dst, addrVersion(a))
+	dst = append(dst, a.AsSlice()...)
+	if a.Is4() {
+		return dst, nil
+	}
+
+	zone := a.Zone()
+	if len(zone) > 255 {
+		return nil, fmt.Errorf("zone %q is too long, max 255 bytes allowed", zone)
+	}
+	dst = append(dst, byte(len(zone)))
+	return append(dst, zone...), nil
+}
+
+// readAddr decodes one address from the beginning of b and returns number of consumed bytes.
+func readAddr(b []byte) (netip.Addr, int, error) {
+	if len(b) < 1 {
+		return netip.Addr{}, 0, fmt.Errorf("expected at least 1 byte for address, but got 0 bytes")
+	}
+
+	size, err := addrSize(b[0])
+	if err != nil {
+		return netip.Addr{}, 0, err
+	}
+	if size == 0 {
+		return netip.Addr{}, 1, nil
+	}
+
+	if len(b) < 1+size {
+		return netip.Addr{}, 0, fmt.Errorf("expected %d bytes for IPv%d address, but got %d bytes", 1+size, b[0], len(b))
+	}
+	a, _ := netip.AddrFromSlice(b[1 : 1+size])
+	if a.Is4() {
+		return a, 1 + size, nil
+	}
+
+	if len(b) < 2+size {
+		return netip.Addr{}, 0, fmt.Errorf("missing zone length after IPv6 address")
+	}
+	zoneLen := int(b[1+size])
+	if len(b) < 2+size+zoneLen {
+		return netip.Addr{}, 0, fmt.Errorf("declared zone length %d, but got %d bytes", zoneLen, len(b)-2-size)
+	}
+
+	return a.WithZone(string(b[2+size : 2+size+zoneLen])), 2 + size + zoneLen, nil
+}
+
+func addrVersion(a netip.Addr) byte {
+	if a.Is4() {
+		return 4
+	}
+	return 6
+}
+
+func addrSize(version byte) (int, error) {
+	switch version {
+	case 0:
+		return 0, nil
+	case 4:
+		return 4, nil
+	case 6:
+		return 16, nil
+	default:
+		return 0, fmt.Errorf("unknown IP version %d", version)
+	}
+}
@@ -0,0 +1,121 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"net/netip"
+	"testing"
+)
+
+func TestAddrBytes(t *testing.T) {
+	cases := []struct {
+		addr netip.Addr
+		hex  string
+	}{
+		{netip.MustParseAddr("192.168.1.10"), "04c0a8010a"},
+		{netip.MustParseAddr("2001:db8::1"), "0620010db800000000000000000000000100"},
+		{netip.MustParseAddr("fe80::1%eth0"), "06fe8000000000000000000000000000010465746830"},
+		{netip.MustParseAddr("::ffff:10.0.0.1"), "0600000000000000000000ffff0a00000100"},
+		{netip.Addr{}, "00"},
+	}
+	for _, c := range cases {
+		b, err := AddrToBytes(c.addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("AddrToBytes(%v) = %s, expected %s", c.addr, got, c.hex)
+		}
+		if got, err := AddrFromBytes(b); err != nil || got != c.addr {
+			t.Errorf("AddrFromBytes(%s) = %v (%v), expected %v", c.hex, got, err, c.addr)
+		}
+	}
+}
+
+func TestPrefixBytes(t *testing.T) {
+	cases := []struct {
+		prefix netip.Prefix
+		hex    string
+	}{
+		{netip.MustParsePrefix("10.0.0.0/8"), "040a00000008"},
+		{netip.MustParsePrefix("192.168.1.7/24"), "04c0a8010718"},
+		{netip.MustParsePrefix("2001:db8::/32"), "0620010db800000000000000000000000020"},
+		{netip.MustParsePrefix("::/0"), "060000000000000000000000000000000000"},
+	}
+	for _, c := range cases {
+		b, err := PrefixToBytes(c.prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("PrefixToBytes(%v) = %s, expected %s", c.prefix, got, c.hex)
+		}
+		if got, err := PrefixFromBytes(b); err != nil || got != c.prefix {
+			t.Errorf("PrefixFromBytes(%s) = %v (%v), expected %v", c.hex, got, err, c.prefix)
+		}
+	}
+
+	if _, err := PrefixToBytes(netip.Prefix{}); err == nil {
+		t.Error("expected error for invalid prefix")
+	}
+}
+
+func TestNetAddrErrors(t *testing.T) {
+	addrs := []string{"", "05", "04c0a801", "0620010db8000000000000000000000001", "06fe800000000000000000000000000001046574", "04c0a8010a00"}
+	for _, h := range addrs {
+		b, _ := hex.DecodeString(h)
+		if _, err := AddrFromBytes(b); err == nil {
+			t.Errorf("AddrFromBytes(%q): expected error", h)
+		}
+	}
+
+	prefixes := []string{"", "00", "040a000000", "040a00000021", "0620010db800000000000000000000000081"}
+	for _, h := range prefixes {
+		b, _ := hex.DecodeString(h)
+		if _, err := PrefixFromBytes(b); err == nil {
+			t.Errorf("PrefixFromBytes(%q): expected error", h)
+		}
+	}
+}
+
+func TestAddrPortBytes(t *testing.T) {
+	cases := []struct {
+		ap  netip.AddrPort
+		hex string
+	}{
+		{netip.MustParseAddrPort("192.168.1.10:8080"), "00000000000000000000ffffc0a8010a1f90"},
+		{netip.MustParseAddrPort("[2001:db8::1]:443"), "20010db800000000000000000000000101bb"},
+	}
+	for _, c := range cases {
+		b, err := AddrPortTo18Bytes(c.ap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b[:]); got != c.hex {
+			t.Errorf("AddrPortTo18Bytes(%v) = %s, expected %s", c.ap, got, c.hex)
+		}
+		if got := AddrPortFrom18Bytes(b); got != c.ap {
+			t.Errorf("AddrPortFrom18Bytes(%s) = %v, expected %v", c.hex, got, c.ap)
+		}
+	}
+
+	if _, err := AddrPortTo18Bytes(netip.MustParseAddrPort("[fe80::1%eth0]:22")); err == nil {
+		t.Error("expected error for zoned address")
+	}
+	if _, err := AddrPortTo18Bytes(netip.AddrPort{}); err == nil {
+		t.Error("expected error for invalid address")
+	}
+
+	b, err := AddrPortTo6Bytes(netip.MustParseAddrPort("[::ffff:10.0.0.1]:53"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(b[:]); got != "0a0000010035" {
+		t.Errorf("AddrPortTo6Bytes = %s, expected 0a0000010035", got)
+	}
+	if got := AddrPortFrom6Bytes(b); got != netip.MustParseAddrPort("10.0.0.1:53") {
+		t.Errorf("AddrPortFrom6Bytes = %v", got)
+	}
+	if _, err := AddrPortTo6Bytes(netip.MustParseAddrPort("[2001:db8::1]:53")); err == nil {
+		t.Error("expected error for IPv6 address")
+	}
+}
@@ -0,0 +1,44 @@
+package bytecast
+
+import "fmt"
+
+// NibbleToByte joins two 4-bit values into one byte, high nibble first: (0x1, 0x2) → 0x12.
+func NibbleToByte(high, low byte) (byte, error) {
+	if high > 0x0F || low > 0x0F {
+		return 0, fmt.Errorf("nibble out of range: high 0x%x, low 0x%x, must be 0x0..0xf", high, low)
+	}
+	return high<<4 | low, nil
+}
+
+// NibbleFromByte splits byte into its high and low nibbles: 0x12 → (0x1, 0x2).
+func NibbleFromByte(b byte) (high, low byte) {
+	return b >> 4, b & 0x0F
+}
+
+// SplitNibbles returns 2*len(b) nibbles of b, high nibble of every byte first.
+func SplitNibbles(b []byte) []byte {
+	out := make([]byte, 0, 2*len(b))
+	for _, c := range b {
+		out = append(out, c>>4, c&0x0F)
+	}
+	return out
+}
+
+// JoinNibbles is the inverse of SplitNibbles: packs pairs of nibbles (high first) into bytes.
+// Number of nibbles must be even, every nibble must be in range 0x0..0xf.
+func JoinNibbles(nibbles []byte) ([]byte, error) {
+	if len(nibbles)%2 != 0 {
+		return nil, fmt.Errorf("odd number of nibbles: %d", len(nibbles))
+	}
+
+	out := make([]byte, len(nibbles)/2)
+	for i := range out {
+		b, err := NibbleToByte(nibbles[2*i], nibbles[2*i+1])
+		if err != nil {
+			return nil, fmt.Errorf("nibbles at index %d: %w", 2*i, err)
+		}
+		out[i] = b
+	}
+
+	return out, nil
+}
@@ -0,0 +1,50 @@
+package bytecast
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNibbleByte(t *testing.T) {
+	b, err := NibbleToByte(0x1, 0xF)
+	if err != nil || b != 0x1F {
+		t.Fatalf("expected 0x1f got %#x (%v)", b, err)
+	}
+
+	high, low := NibbleFromByte(0xA5)
+	if high != 0xA || low != 0x5 {
+		t.Fatalf("expected (0xa, 0x5) got (%#x, %#x)", high, low)
+	}
+
+	if _, err := NibbleToByte(0x10, 0); err == nil {
+		t.Fatal("expected error for high nibble 0x10")
+	}
+	if _, err := NibbleToByte(0, 0xFF); err == nil {
+		t.Fatal("expected error for low nibble 0xff")
+	}
+}
+
+func TestSplitJoinNibbles(t *testing.T) {
+	in := []byte{0x12, 0xAB, 0x00, 0xFF}
+	nibbles := SplitNibbles(in)
+
+	if !bytes.Equal(nibbles, []byte{1, 2, 0xA, 0xB, 0, 0, 0xF, 0xF}) {
+		t.Fatalf("unexpected nibbles %x", nibbles)
+	}
+
+	out, err := JoinNibbles(nibbles)
+	if err != nil || !bytes.Equal(out, in) {
+		t.Fatalf("expected %x got %x (%v)", in, out, err)
+	}
+
+	if len(SplitNibbles(nil)) != 0 {
+		t.Fatal("expected no nibbles for empty input")
+	}
+
+	if _, err := JoinNibbles([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected error for odd number of nibbles")
+	}
+	if _, err := JoinNibbles([]byte{1, 0x10}); err == nil {
+		t.Fatal("expected error for nibble out of range")
+	}
+}
@@ -0,0 +1,33 @@
+package bytecast
+
+import "unsafe"
+
+// StringFromBytesNoCopy
+//
+//	Returns string which aliases backing array of b instead of copying it.
+//	Useful for read-only parsing of very large (e.g. memory-mapped) inputs, where string copies dominate the profile.
+//
+//	UNSAFE: Go strings are assumed to be immutable. Caller MUST NOT modify b (or unmap/reuse its memory)
+//	while returned string or any substring of it is alive, otherwise string content silently changes
+//	and map keys, comparisons etc. break. When in doubt, use string(b).
+func StringFromBytesNoCopy(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(&b[0], len(b))
+}
+
+// StringFrom256BytesNoCopy
+//
+//	Same as StringFrom256Bytes, but returned string aliases *byteVal instead of copying it.
+//	The same restrictions as for StringFromBytesNoCopy apply: *byteVal must stay unmodified
+//	while returned string is in use. Invalid length byte is handled as in StringFrom256Bytes.
+func StringFrom256BytesNoCopy(byteVal *[256]byte) string {
+	significantBytesCount := int(byteVal[0])
+
+	if significantBytesCount == 0 {
+		return ""
+	}
+
+	return StringFromBytesNoCopy(byteVal[256-significantBytesCount:])
+}
@@ -0,0 +1,43 @@
+package bytecast
+
+import (
+	"testing"
+)
+
+func TestStringFromBytesNoCopy(t *testing.T) {
+	if got := StringFromBytesNoCopy(nil); got != "" {
+		t.Fatalf("expected empty string, got %q", got)
+	}
+
+	b := []byte("hello")
+	s := StringFromBytesNoCopy(b)
+	if s != "hello" {
+		t.Fatalf("expected %q got %q", "hello", s)
+	}
+
+	// string aliases the buffer, this is exactly what callers must avoid in real code
+	b[0] = 'j'
+	if s != "jello" {
+		t.Fatalf("expected string to alias buffer, got %q", s)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		s = StringFromBytesNoCopy(b)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestStringFrom256BytesNoCopy(t *testing.T) {
+	for _, want := range []string{"", "a", "hello world"} {
+		b, err := StringTo256Bytes(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := StringFrom256BytesNoCopy(&b); got != want {
+			t.Fatalf("expected %q got %q", want, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// ntpEpoch is the NTP prime epoch, 1900-01-01 00:00:00 UTC, as Unix seconds.
+const ntpEpoch = -2208988800
+
+// NTPTimestampToBytes
+//
+//	Encodes t as 64-bit NTP timestamp: 32-bit seconds since 1900-01-01 00:00:00 UTC followed by
+//	32-bit binary fraction of second, both big-endian.
+//
+//	Seconds field wraps every 2^32 seconds (~136 years): era 0 ends 2036-02-07 06:28:16 UTC,
+//	later times are stored modulo 2^32 as the protocol does, so decoder needs to know the era,
+//	see NTPTimestampFromBytes. Times before 1900 are rejected.
+func NTPTimestampToBytes(t time.Time) ([8]byte, error) {
+	var out [8]byte
+
+	seconds := t.Unix() - ntpEpoch
+	if seconds < 0 {
+		return out, fmt.Errorf("time %v is before NTP epoch 1900-01-01", t)
+	}
+
+	fraction := uint64(t.Nanosecond()) << 32 / 1e9
+
+	binary.BigEndian.PutUint32(out[:4], uint32(seconds))
+	binary.BigEndian.PutUint32(out[4:], uint32(fraction))
+	return out, nil
+}
+
+// NTPTimestampFromBytes
+//
+//	Decodes 64-bit NTP timestamp into UTC time using era rule of RFC 4330: seconds with the top bit
+//	set belong to era 0 (1968..2036), others to era 1 (2036..2104). Use NTPTimestampFromBytesNear
+//	for other ranges.
+//
+//	Fraction is rounded to the nearest nanosecond, so timestamps written by NTPTimestampToBytes
+//	decode exactly.
+func NTPTimestampFromBytes(b [8]byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(b[:4]))
+	if seconds&0x80000000 == 0 {
+		seconds += 1 << 32
+	}
+	return ntpTime(seconds, binary.BigEndian.Uint32(b[4:]))
+}
+
+// NTPTimestampFromBytesNear
+//
+//	Decodes 64-bit NTP timestamp choosing the era which puts result closest to pivot,
+//	e.g. current time for live packets or file time for archived captures.
+func NTPTimestampFromBytesNear(b [8]byte, pivot time.Time) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(b[:4]))
+
+	// era of pivot, then shift by one era if the timestamp is more than half an era away
+	base := (pivot.Unix() - ntpEpoch) >> 32 << 32
+	seconds += base
+	switch offset := seconds - (pivot.Unix() - ntpEpoch); {
+	case offset > 1<<31:
+		seconds -= 1 << 32
+	case offset < -(1 << 31):
+		seconds += 1 << 32
+	}
+
+	return ntpTime(seconds, binary.BigEndian.Uint32(b[4:]))
+}
+
+// NTPEra returns NTP era of t: 0 for 1900..2036, 1 for 2036..2172, negative before 1900.
+func NTPEra(t time.Time) int64 {
+	return (t.Unix() - ntpEpoch) >> 32
+}
+
+func ntpTime(seconds int64, fraction uint32) time.Time {
+	nsec := (uint64(fraction)*1e9 + 1<<31) >> 32
+	return time.Unix(seconds+ntpEpoch, int64(nsec)).UTC()
+}
@@ -0,0 +1,74 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestNTPTimestamp(t *testing.T) {
+	cases := []struct {
+		t   time.Time
+		hex string
+	}{
+		{time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), "0000000000000000"},
+		{time.Unix(0, 0).UTC(), "83aa7e8000000000"},
+		{time.Date(2024, 5, 1, 12, 0, 0, 500000000, time.UTC), "e9dcad4080000000"},
+		{time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC), "0000000000000000"}, // start of era 1
+		{time.Date(2040, 1, 1, 0, 0, 0, 123456789, time.UTC), "0754fd001f9add37"},
+	}
+	for _, c := range cases {
+		b, err := NTPTimestampToBytes(c.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b[:]); got != c.hex {
+			t.Errorf("NTPTimestampToBytes(%v) = %s, expected %s", c.t, got, c.hex)
+		}
+
+		decoded := NTPTimestampFromBytesNear(b, c.t.Add(50*365*24*time.Hour))
+		if !decoded.Equal(c.t) {
+			t.Errorf("NTPTimestampFromBytesNear(%s) = %v, expected %v", c.hex, decoded, c.t)
+		}
+	}
+
+	if _, err := NTPTimestampToBytes(time.Date(1899, 12, 31, 23, 59, 59, 0, time.UTC)); err == nil {
+		t.Error("expected error before NTP epoch")
+	}
+}
+
+func TestNTPTimestampEras(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	future := time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tm := range []time.Time{now, future} {
+		b, _ := NTPTimestampToBytes(tm)
+		if got := NTPTimestampFromBytes(b); !got.Equal(tm) {
+			t.Errorf("RFC 4330 era rule: expected %v, got %v", tm, got)
+		}
+	}
+
+	// 1900 is outside RFC 4330 window, nearest era is needed
+	b, _ := NTPTimestampToBytes(time.Date(1910, 1, 1, 0, 0, 0, 0, time.UTC))
+	if got := NTPTimestampFromBytes(b); got.Year() != 2046 {
+		t.Errorf("expected 1910 timestamp to map to era 1 (2046), got %v", got)
+	}
+	if got := NTPTimestampFromBytesNear(b, time.Date(1920, 1, 1, 0, 0, 0, 0, time.UTC)); got.Year() != 1910 {
+		t.Errorf("expected 1910 near 1920 pivot, got %v", got)
+	}
+
+	if NTPEra(now) != 0 || NTPEra(future) != 1 || NTPEra(time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC)) != -1 {
+		t.Error("unexpected NTPEra")
+	}
+}
+
+func TestNTPFractionRoundTrip(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, ns := range []int{0, 1, 2, 999, 123456789, 500000000, 999999999} {
+		tm := base.Add(time.Duration(ns))
+		b, _ := NTPTimestampToBytes(tm)
+		if got := NTPTimestampFromBytes(b); !got.Equal(tm) {
+			t.Errorf("expected %v, got %v", tm, got)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package bytecast
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// OIDToBytes
+//
+//	Encodes object identifier arcs as ASN.1 content octets (X.690 section 8.19), without tag and length:
+//	first two arcs are combined into 40*first + second, then every value is written base-128, most significant
+//	group first, with high bit set on all bytes but the last. 1.2.840.113549 → 2A 86 48 86 F7 0D.
+//
+//	At least two arcs are required, first arc must be 0, 1 or 2 and second arc below 40 unless first is 2.
+func OIDToBytes(arcs []uint64) ([]byte, error) {
+	if len(arcs) < 2 {
+		return nil, fmt.Errorf("object identifier needs at least 2 arcs, got %d", len(arcs))
+	}
+	if arcs[0] > 2 {
+		return nil, fmt.Errorf("first object identifier arc must be 0, 1 or 2, got %d", arcs[0])
+	}
+	if arcs[0] < 2 && arcs[1] >= 40 {
+		return nil, fmt.Errorf("second object identifier arc must be below 40 under %d, got %d", arcs[0], arcs[1])
+	}
+	if arcs[1] > math.MaxUint64-80 {
+		return nil, fmt.Errorf("second object identifier arc %d is too large", arcs[1])
+	}
+
+	out := appendBase128(nil, 40*arcs[0]+arcs[1])
+	for _, a := range arcs[2:] {
+		out = appendBase128(out, a)
+	}
+	return out, nil
+}
+
+// OIDFromBytes decodes content octets written by OIDToBytes. Non-minimal encodings (leading 0x80),
+// truncated last arc and arcs over 64 bits are rejected.
+func OIDFromBytes(b []byte) ([]uint64, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty object identifier")
+	}
+
+	var arcs []uint64
+	for len(b) > 0 {
+		v, n, err := readBase128(b)
+		if err != nil {
+			return nil, fmt.Errorf("object identifier arc %d: %w", len(arcs), err)
+		}
+		b = b[n:]
+
+		if arcs == nil {
+			switch {
+			case v < 40:
+				arcs = append(arcs, 0, v)
+			case v < 80:
+				arcs = append(arcs, 1, v-40)
+			default:
+				arcs = append(arcs, 2, v-80)
+			}
+			continue
+		}
+		arcs = append(arcs, v)
+	}
+
+	return arcs, nil
+}
+
+// ParseOID parses dotted form "1.2.840.113549".
+func ParseOID(s string) ([]uint64, error) {
+	parts := strings.Split(s, ".")
+	arcs := make([]uint64, len(parts))
+	for i, p := range parts {
+		v, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid arc %q in object identifier %q", p, s)
+		}
+		arcs[i] = v
+	}
+	return arcs, nil
+}
+
+// FormatOID returns dotted form of arcs.
+func FormatOID(arcs []uint64) string {
+	parts := make([]string, len(arcs))
+	for i, a := range arcs {
+		parts[i] = strconv.FormatUint(a, 10)
+	}
+	return strings.Join(parts, ".")
+}
+
+func appendBase128(dst []byte, v uint64) []byte {
+	groups := max((bits.Len64(v)+6)/7, 1)
+	for i := groups - 1; i > 0; i-- {
+		dst = append(dst, 0x80|byte(v>>(7*i)))
+	}
+	return append(dst, byte(v&0x7F))
+}
+
+// readBase128 decodes one base-128 value and returns it with number of consumed bytes.
+func readBase128(b []byte) (uint64, int, error) {
+	if b[0] == 0x80 {
+		return 0, 0, fmt.Errorf("non-minimal base-128 encoding")
+	}
+
+	var v uint64
+	for i, c := range b {
+		if v>>57 != 0 {
+			return 0, 0, fmt.Errorf("value exceeds 64 bits")
+		}
+		v = v<<7 | uint64(c&0x7F)
+		if c&0x80 == 0 {
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("truncated base-128 value")
+}
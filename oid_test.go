@@ -0,0 +1,64 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"slices"
+	"testing"
+)
+
+func TestOIDBytes(t *testing.T) {
+	cases := []struct {
+		oid string
+		hex string
+	}{
+		{"1.2.840.113549", "2a864886f70d"},
+		{"1.2.840.113549.1.1.11", "2a864886f70d01010b"}, // sha256WithRSAEncryption
+		{"2.5.4.3", "550403"},                           // commonName
+		{"1.3.6.1.4.1.311.21.20", "2b0601040182371514"},
+		{"0.0", "00"},
+		{"2.999.3", "883703"},
+		{"1.2.18446744073709551615", "2a81ffffffffffffffff7f"},
+	}
+	for _, c := range cases {
+		arcs, err := ParseOID(c.oid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := OIDToBytes(arcs)
+		if err != nil {
+			t.Fatalf("OIDToBytes(%s): %v", c.oid, err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("OIDToBytes(%s) = %s, expected %s", c.oid, got, c.hex)
+		}
+
+		back, err := OIDFromBytes(b)
+		if err != nil {
+			t.Fatalf("OIDFromBytes(%s): %v", c.hex, err)
+		}
+		if !slices.Equal(back, arcs) || FormatOID(back) != c.oid {
+			t.Errorf("OIDFromBytes(%s) = %s, expected %s", c.hex, FormatOID(back), c.oid)
+		}
+	}
+}
+
+func TestOIDErrors(t *testing.T) {
+	for _, arcs := range [][]uint64{nil, {1}, {3, 1}, {1, 40}, {0, 40}, {2, 1<<64 - 1}} {
+		if _, err := OIDToBytes(arcs); err == nil {
+			t.Errorf("OIDToBytes(%v): expected error", arcs)
+		}
+	}
+
+	for _, h := range []string{"", "2a86", "2a8048", "2a82ffffffffffffffff7f"} {
+		b, _ := hex.DecodeString(h)
+		if _, err := OIDFromBytes(b); err == nil {
+			t.Errorf("OIDFromBytes(%q): expected error", h)
+		}
+	}
+
+	for _, s := range []string{"", "1..2", "1.x", "1.-2"} {
+		if _, err := ParseOID(s); err == nil {
+			t.Errorf("ParseOID(%q): expected error", s)
+		}
+	}
+}
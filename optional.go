@@ -0,0 +1,67 @@
+package bytecast
+
+import "fmt"
+
+// Optional holds value which may be absent, e.g. field added in newer record version.
+type Optional[T any] struct {
+	Value T
+	Valid bool // false means absent, Value is zero then
+}
+
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Valid: true}
+}
+
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// Get returns value and whether it is present.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Valid
+}
+
+// OptionalToBytes encodes presence byte followed by the value encoded with Marshal:
+//
+//	absent  → [ 0x00 ]
+//	present → [ 0x01 | Marshal(value) ]
+//
+// Unlike pointer fields of struct codec (see Schema), absent value takes just one byte.
+func OptionalToBytes[T any](o Optional[T]) ([]byte, error) {
+	return AppendOptional(nil, o)
+}
+
+// AppendOptional appends encoding of o (see OptionalToBytes) to dst. On error dst is returned unchanged.
+func AppendOptional[T any](dst []byte, o Optional[T]) ([]byte, error) {
+	if !o.Valid {
+		return append(dst, 0x00), nil
+	}
+	out, err := appendValue(append(dst, 0x01), o.Value)
+	if err != nil {
+		return dst, err
+	}
+	return out, nil
+}
+
+// OptionalFromBytes decodes value written by OptionalToBytes, data must contain exactly one value.
+func OptionalFromBytes[T any](data []byte) (Optional[T], error) {
+	if len(data) == 0 {
+		return Optional[T]{}, fmt.Errorf("expected at least 1 byte for optional value, but got 0 bytes")
+	}
+
+	switch data[0] {
+	case 0x00:
+		if len(data) != 1 {
+			return Optional[T]{}, fmt.Errorf("absent optional value followed by %d unexpected bytes", len(data)-1)
+		}
+		return Optional[T]{}, nil
+	case 0x01:
+		var v T
+		if err := Unmarshal(data[1:], &v); err != nil {
+			return Optional[T]{}, err
+		}
+		return Some(v), nil
+	}
+
+	return Optional[T]{}, fmt.Errorf("invalid presence byte 0x%02x", data[0])
+}
@@ -0,0 +1,107 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOptionalBytes(t *testing.T) {
+	out, err := OptionalToBytes(Some(int16(-2)))
+	if err != nil || hex.EncodeToString(out) != "01fffe" {
+		t.Fatalf("expected 01fffe got %x (%v)", out, err)
+	}
+
+	v, err := OptionalFromBytes[int16](out)
+	if err != nil || !v.Valid || v.Value != -2 {
+		t.Fatalf("expected Some(-2), got %+v (%v)", v, err)
+	}
+
+	out, err = OptionalToBytes(None[uint64]())
+	if err != nil || hex.EncodeToString(out) != "00" {
+		t.Fatalf("expected 00 got %x (%v)", out, err)
+	}
+
+	absent, err := OptionalFromBytes[uint64](out)
+	if err != nil || absent.Valid {
+		t.Fatalf("expected None, got %+v (%v)", absent, err)
+	}
+	if _, ok := absent.Get(); ok {
+		t.Fatal("Get must report absent value")
+	}
+
+	for _, bad := range []string{"", "02", "0000", "01ff"} {
+		data, _ := hex.DecodeString(bad)
+		if _, err := OptionalFromBytes[int16](data); err == nil {
+			t.Errorf("OptionalFromBytes(%s): expected error", bad)
+		}
+	}
+}
+
+type optionalTestRecord struct {
+	ID    uint8
+	Limit *int32
+	Extra *optionalTestExtra
+}
+
+type optionalTestExtra struct {
+	Flag bool
+	Code uint16
+}
+
+func TestStructPointerFields(t *testing.T) {
+	layout, err := DescribeLayout(optionalTestRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []FieldLayout{
+		{"ID", 0, 1, KindUint, false},
+		{"Limit", 1, 1, KindPresence, false},
+		{"Limit", 2, 4, KindInt, false},
+		{"Extra", 6, 1, KindPresence, false},
+		{"Extra.Flag", 7, 1, KindBool, false},
+		{"Extra.Code", 8, 2, KindUint, false},
+	}
+	if !reflect.DeepEqual(layout, expected) {
+		t.Fatalf("unexpected layout %v", layout)
+	}
+
+	limit := int32(-5)
+	in := optionalTestRecord{ID: 1, Limit: &limit}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(data); got != "01"+"01fffffffb"+"00000000" {
+		t.Fatalf("unexpected encoding %s", got)
+	}
+
+	out := optionalTestRecord{Extra: &optionalTestExtra{Code: 9}}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != 1 || out.Limit == nil || *out.Limit != -5 || out.Extra != nil {
+		t.Fatalf("unexpected result %+v", out)
+	}
+
+	data[6] = 2
+	if err := Unmarshal(data, &out); err == nil {
+		t.Fatal("expected error for invalid presence byte")
+	}
+
+	type node struct{ Next *node }
+	if _, err := SchemaOf(node{}); err == nil {
+		t.Fatal("expected error for recursive type")
+	}
+}
+
+func TestAppendOptionalError(t *testing.T) {
+	dst := []byte{0xAA}
+	out, err := AppendOptional(dst, Some(strings.Repeat("x", 300)))
+	if err == nil || len(out) != len(dst) {
+		t.Fatalf("expected error and unchanged dst, got %x (%v)", out, err)
+	}
+}
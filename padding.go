@@ -0,0 +1,131 @@
+package bytecast
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidPadding is returned by Unpad when padding is malformed. It intentionally carries no details,
+// so callers decrypting untrusted input do not build a padding oracle out of error messages.
+var ErrInvalidPadding = errors.New("invalid padding")
+
+// PaddingScheme selects block padding for Pad and Unpad.
+type PaddingScheme int
+
+const (
+	PaddingPKCS7   PaddingScheme = iota + 1 // n bytes of value n (RFC 5652), always added
+	PaddingX923                             // n-1 zero bytes followed by n (ANSI X9.23), always added
+	PaddingISO7816                          // 0x80 followed by zero bytes (ISO/IEC 7816-4), always added
+	PaddingZero                             // zero bytes up to block boundary, nothing added to aligned data
+)
+
+// Pad
+//
+//	Returns copy of data padded to a multiple of blockSize bytes (1..255 for PKCS#7 and X9.23,
+//	any positive size otherwise). All schemes but PaddingZero add a full block to already aligned data,
+//	so padding can always be removed unambiguously. PaddingZero can not be removed reliably from
+//	data ending with zero bytes, use it only where the format requires it.
+func Pad(data []byte, blockSize int, scheme PaddingScheme) ([]byte, error) {
+	if err := validatePadding(blockSize, scheme); err != nil {
+		return nil, err
+	}
+
+	n := blockSize - len(data)%blockSize
+	if scheme == PaddingZero && n == blockSize {
+		n = 0
+	}
+
+	out := make([]byte, len(data)+n)
+	copy(out, data)
+	pad := out[len(data):]
+
+	switch scheme {
+	case PaddingPKCS7:
+		for i := range pad {
+			pad[i] = byte(n)
+		}
+	case PaddingX923:
+		pad[n-1] = byte(n)
+	case PaddingISO7816:
+		pad[0] = 0x80
+	}
+
+	return out, nil
+}
+
+// Unpad
+//
+//	Validates and removes padding added by Pad, returning subslice of data. len(data) must be a non-zero
+//	multiple of blockSize (any multiple for PaddingZero). PKCS#7 and X9.23 padding is checked in time
+//	independent of padding content. PaddingZero removes all trailing zero bytes.
+func Unpad(data []byte, blockSize int, scheme PaddingScheme) ([]byte, error) {
+	if err := validatePadding(blockSize, scheme); err != nil {
+		return nil, err
+	}
+	if len(data)%blockSize != 0 || (len(data) == 0 && scheme != PaddingZero) {
+		return nil, fmt.Errorf("%w: length %d is not a non-zero multiple of block size %d", ErrInvalidPadding, len(data), blockSize)
+	}
+
+	switch scheme {
+	case PaddingPKCS7, PaddingX923:
+		return unpadCounted(data, blockSize, scheme == PaddingPKCS7)
+	case PaddingISO7816:
+		for i := len(data) - 1; i >= len(data)-blockSize; i-- {
+			switch data[i] {
+			case 0x00:
+				continue
+			case 0x80:
+				return data[:i], nil
+			}
+			break
+		}
+		return nil, ErrInvalidPadding
+	default:
+		i := len(data)
+		for i > 0 && data[i-1] == 0 {
+			i--
+		}
+		return data[:i], nil
+	}
+}
+
+// unpadCounted checks padding whose last byte is its length, filler bytes are n (PKCS#7) or zero (X9.23).
+func unpadCounted(data []byte, blockSize int, pkcs7 bool) ([]byte, error) {
+	last := data[len(data)-blockSize:]
+	n := last[blockSize-1]
+
+	good := subtle.ConstantTimeLessOrEq(1, int(n)) & subtle.ConstantTimeLessOrEq(int(n), blockSize)
+	for i := 0; i < blockSize-1; i++ {
+		// bytes outside of padding are not checked, but are visited anyway
+		inPadding := subtle.ConstantTimeLessOrEq(blockSize-int(n), i)
+
+		expected := byte(0)
+		if pkcs7 {
+			expected = n
+		}
+		match := subtle.ConstantTimeByteEq(last[i], expected)
+		good &= match | (inPadding ^ 1)
+	}
+
+	if good != 1 {
+		return nil, ErrInvalidPadding
+	}
+	return data[:len(data)-int(n)], nil
+}
+
+func validatePadding(blockSize int, scheme PaddingScheme) error {
+	switch scheme {
+	case PaddingPKCS7, PaddingX923:
+		if blockSize < 1 || blockSize > 255 {
+			return fmt.Errorf("unsupported block size %d, must be 1..255", blockSize)
+		}
+	case PaddingISO7816, PaddingZero:
+		if blockSize < 1 {
+			return fmt.Errorf("unsupported block size %d, must be positive", blockSize)
+		}
+	default:
+		return fmt.Errorf("unknown padding scheme %d", scheme)
+	}
+	return nil
+}
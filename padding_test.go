@@ -0,0 +1,85 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestPad(t *testing.T) {
+	cases := []struct {
+		scheme PaddingScheme
+		data   string
+		hex    string
+	}{
+		{PaddingPKCS7, "0102030405", "0102030405030303"},
+		{PaddingPKCS7, "0102030405060708", "01020304050607080808080808080808"},
+		{PaddingPKCS7, "", "0808080808080808"},
+		{PaddingX923, "0102030405", "0102030405000003"},
+		{PaddingX923, "01020304050607", "0102030405060701"},
+		{PaddingISO7816, "0102030405", "0102030405800000"},
+		{PaddingISO7816, "0102030405060708", "01020304050607088000000000000000"},
+		{PaddingZero, "0102030405", "0102030405000000"},
+		{PaddingZero, "0102030405060708", "0102030405060708"},
+		{PaddingZero, "", ""},
+	}
+	for _, c := range cases {
+		data, _ := hex.DecodeString(c.data)
+		padded, err := Pad(data, 8, c.scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(padded); got != c.hex {
+			t.Errorf("scheme %d: Pad(%s) = %s, expected %s", c.scheme, c.data, got, c.hex)
+		}
+
+		back, err := Unpad(padded, 8, c.scheme)
+		if err != nil {
+			t.Fatalf("scheme %d: Unpad(%s): %v", c.scheme, c.hex, err)
+		}
+		if got := hex.EncodeToString(back); got != c.data {
+			t.Errorf("scheme %d: Unpad(%s) = %s, expected %s", c.scheme, c.hex, got, c.data)
+		}
+	}
+
+	// AES block size
+	padded, _ := Pad([]byte("YELLOW SUBMARINE"), 16, PaddingPKCS7)
+	if len(padded) != 32 || padded[31] != 16 {
+		t.Errorf("expected full block of 0x10, got %x", padded)
+	}
+}
+
+func TestUnpadErrors(t *testing.T) {
+	cases := []struct {
+		scheme PaddingScheme
+		hex    string
+	}{
+		{PaddingPKCS7, ""},
+		{PaddingPKCS7, "01020304050607"},
+		{PaddingPKCS7, "0102030405060700"},
+		{PaddingPKCS7, "0102030405060709"},
+		{PaddingPKCS7, "0102030405030203"},
+		{PaddingX923, "0102030405010003"},
+		{PaddingX923, "0102030405000000"},
+		{PaddingISO7816, "0102030405000000"},
+		{PaddingISO7816, "0102030405800001"},
+		{PaddingISO7816, "0000000000000000"},
+		{PaddingZero, "0102"},
+	}
+	for _, c := range cases {
+		data, _ := hex.DecodeString(c.hex)
+		if _, err := Unpad(data, 8, c.scheme); !errors.Is(err, ErrInvalidPadding) {
+			t.Errorf("scheme %d: Unpad(%s): expected ErrInvalidPadding, got %v", c.scheme, c.hex, err)
+		}
+	}
+
+	if _, err := Pad(nil, 256, PaddingPKCS7); err == nil {
+		t.Error("expected error for PKCS#7 block size 256")
+	}
+	if _, err := Pad(nil, 0, PaddingZero); err == nil {
+		t.Error("expected error for zero block size")
+	}
+	if _, err := Pad(nil, 8, PaddingScheme(99)); err == nil {
+		t.Error("expected error for unknown scheme")
+	}
+}
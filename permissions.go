@@ -0,0 +1,133 @@
+package bytecast
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// Permissions are POSIX permission bits as stored in archive headers (cpio, tar, zip external attributes):
+//
+//	[ setuid | setgid | sticky | rwx owner | rwx group | rwx other ]  → 12 low bits, 0o7777
+type Permissions uint16
+
+const (
+	PermSetuid Permissions = 0o4000
+	PermSetgid Permissions = 0o2000
+	PermSticky Permissions = 0o1000
+)
+
+// PermissionsFromFileMode takes permission, setuid, setgid and sticky bits of fs.FileMode.
+func PermissionsFromFileMode(m fs.FileMode) Permissions {
+	p := Permissions(m.Perm())
+	if m&fs.ModeSetuid != 0 {
+		p |= PermSetuid
+	}
+	if m&fs.ModeSetgid != 0 {
+		p |= PermSetgid
+	}
+	if m&fs.ModeSticky != 0 {
+		p |= PermSticky
+	}
+	return p
+}
+
+// FileMode converts permissions to fs.FileMode without file type bits.
+func (p Permissions) FileMode() fs.FileMode {
+	m := fs.FileMode(p & 0o777)
+	if p&PermSetuid != 0 {
+		m |= fs.ModeSetuid
+	}
+	if p&PermSetgid != 0 {
+		m |= fs.ModeSetgid
+	}
+	if p&PermSticky != 0 {
+		m |= fs.ModeSticky
+	}
+	return m
+}
+
+// ToBytes encodes permissions as 2-byte big-endian field.
+func (p Permissions) ToBytes() [2]byte {
+	return Uint16To2Bytes(uint16(p))
+}
+
+// PermissionsFromBytes decodes 2-byte field written by ToBytes, bits above 0o7777 are rejected.
+func PermissionsFromBytes(b [2]byte) (Permissions, error) {
+	p := Permissions(Uint16From2Bytes(b))
+	if p&^0o7777 != 0 {
+		return 0, fmt.Errorf("invalid permissions 0o%o, only bits 0o7777 allowed", p)
+	}
+	return p, nil
+}
+
+// String returns ls-style "rwxr-xr-x". Setuid and setgid show as "s" in place of owner/group execute
+// ("S" if execute is not set), sticky as "t" in place of other execute ("T").
+func (p Permissions) String() string {
+	out := []byte("rwxrwxrwx")
+	for i := range out {
+		if p&(1<<(8-i)) == 0 {
+			out[i] = '-'
+		}
+	}
+
+	special := []struct {
+		bit   Permissions
+		index int
+		char  byte
+	}{
+		{PermSetuid, 2, 's'},
+		{PermSetgid, 5, 's'},
+		{PermSticky, 8, 't'},
+	}
+	for _, s := range special {
+		if p&s.bit == 0 {
+			continue
+		}
+		if out[s.index] == '-' {
+			out[s.index] = s.char - 'a' + 'A'
+		} else {
+			out[s.index] = s.char
+		}
+	}
+
+	return string(out)
+}
+
+// ParsePermissions parses 9-character ls-style string produced by String.
+func ParsePermissions(s string) (Permissions, error) {
+	if len(s) != 9 {
+		return 0, fmt.Errorf("expected 9 characters in permissions, but got %q", s)
+	}
+
+	var p Permissions
+	for i := 0; i < 9; i++ {
+		c := s[i]
+		bit := Permissions(1 << (8 - i))
+
+		if c == "rwxrwxrwx"[i] {
+			p |= bit
+			continue
+		}
+		if c == '-' {
+			continue
+		}
+
+		var special Permissions
+		switch {
+		case i == 2 && (c == 's' || c == 'S'):
+			special = PermSetuid
+		case i == 5 && (c == 's' || c == 'S'):
+			special = PermSetgid
+		case i == 8 && (c == 't' || c == 'T'):
+			special = PermSticky
+		default:
+			return 0, fmt.Errorf("invalid character %q at position %d in permissions %q", c, i, s)
+		}
+		p |= special
+		if c == 's' || c == 't' {
+			p |= bit
+		}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,57 @@
+package bytecast
+
+import (
+	"io/fs"
+	"testing"
+)
+
+func TestPermissions(t *testing.T) {
+	cases := []struct {
+		perm Permissions
+		str  string
+		mode fs.FileMode
+	}{
+		{0o755, "rwxr-xr-x", 0o755},
+		{0o644, "rw-r--r--", 0o644},
+		{0o000, "---------", 0},
+		{0o4755, "rwsr-xr-x", fs.ModeSetuid | 0o755},
+		{0o2640, "rw-r-S---", fs.ModeSetgid | 0o640},
+		{0o1777, "rwxrwxrwt", fs.ModeSticky | 0o777},
+		{0o1776, "rwxrwxrwT", fs.ModeSticky | 0o776},
+		{0o7000, "--S--S--T", fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky},
+	}
+	for _, c := range cases {
+		if got := c.perm.String(); got != c.str {
+			t.Errorf("0o%o: String() = %s, expected %s", uint16(c.perm), got, c.str)
+		}
+		if got, err := ParsePermissions(c.str); err != nil || got != c.perm {
+			t.Errorf("ParsePermissions(%s) = 0o%o (%v), expected 0o%o", c.str, uint16(got), err, uint16(c.perm))
+		}
+		if got := c.perm.FileMode(); got != c.mode {
+			t.Errorf("0o%o: FileMode() = %v, expected %v", uint16(c.perm), got, c.mode)
+		}
+		if got := PermissionsFromFileMode(c.mode | fs.ModeDir); got != c.perm {
+			t.Errorf("PermissionsFromFileMode(%v) = 0o%o, expected 0o%o", c.mode, uint16(got), uint16(c.perm))
+		}
+
+		b := c.perm.ToBytes()
+		if got, err := PermissionsFromBytes(b); err != nil || got != c.perm {
+			t.Errorf("PermissionsFromBytes(%x) = 0o%o (%v)", b, uint16(got), err)
+		}
+	}
+
+	if b := Permissions(0o4755).ToBytes(); b != [2]byte{0x09, 0xed} {
+		t.Errorf("unexpected bytes %x for 0o4755", b)
+	}
+}
+
+func TestPermissionsErrors(t *testing.T) {
+	if _, err := PermissionsFromBytes([2]byte{0x10, 0x00}); err == nil {
+		t.Error("expected error for bits above 0o7777")
+	}
+	for _, s := range []string{"", "rwxr-xr-", "rwxr-xr-xx", "rwtr-xr-x", "rwxr-xr-s", "xwxr-xr-x"} {
+		if _, err := ParsePermissions(s); err == nil {
+			t.Errorf("ParsePermissions(%q): expected error", s)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package bytecast
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// PrefixesToBytes encodes list of CIDR prefixes compactly, storing only significant address bytes
+// as BGP NLRI does:
+//
+//	[ version (4|6) | bits (1 byte) | ceil(bits/8) address bytes ] ...
+//
+// Host bits are dropped (prefixes are masked), so 10.1.2.3/8 is stored as 10.0.0.0/8 in 3 bytes.
+// Entries are concatenated without count, decode with PrefixesFromBytes.
+func PrefixesToBytes(prefixes []netip.Prefix) ([]byte, error) {
+	return AppendPrefixes(nil, prefixes)
+}
+
+// AppendPrefixes appends encoding of prefixes (see PrefixesToBytes) to dst. On error dst is returned unchanged.
+func AppendPrefixes(dst []byte, prefixes []netip.Prefix) ([]byte, error) {
+	out := dst
+	for i, p := range prefixes {
+		if !p.IsValid() || p.Addr().Zone() != "" {
+			return dst, fmt.Errorf("prefix %d: invalid prefix %v", i, p)
+		}
+
+		a := p.Masked().Addr().AsSlice()
+		out = append(out, addrVersion(p.Addr()), byte(p.Bits()))
+		out = append(out, a[:(p.Bits()+7)/8]...)
+	}
+	return out, nil
+}
+
+// PrefixesFromBytes decodes list written by PrefixesToBytes. Prefixes with host bits set
+// in the last significant byte are rejected, since they can not be produced by the encoder.
+// Number of prefixes is checked against MaxElements given WithLimits.
+func PrefixesFromBytes(data []byte, opts ...DecodeOption) ([]netip.Prefix, error) {
+	limits := &newDecodeConfig(opts).limits
+
+	var out []netip.Prefix
+	for off := 0; off < len(data); {
+		if err := limits.checkElements(uint64(len(out) + 1)); err != nil {
+			return nil, err
+		}
+		if len(data)-off < 2 {
+			return nil, fmt.Errorf("prefix %d: truncated header at offset %d", len(out), off)
+		}
+
+		size, err := addrSize(data[off])
+		if err != nil || size == 0 {
+			return nil, fmt.Errorf("prefix %d: unknown IP version %d at offset %d", len(out), data[off], off)
+		}
+
+		bits := int(data[off+1])
+		if bits > 8*size {
+			return nil, fmt.Errorf("prefix %d: length %d exceeds %d bits of IPv%d", len(out), bits, 8*size, data[off])
+		}
+
+		n := (bits + 7) / 8
+		if len(data)-off-2 < n {
+			return nil, fmt.Errorf("prefix %d: expected %d address bytes, but only %d left", len(out), n, len(data)-off-2)
+		}
+
+		var buf [16]byte
+		copy(buf[:], data[off+2:off+2+n])
+		a, _ := netip.AddrFromSlice(buf[:size])
+
+		p := netip.PrefixFrom(a, bits)
+		if p.Masked() != p {
+			return nil, fmt.Errorf("prefix %d: host bits set in %v", len(out), p)
+		}
+
+		out = append(out, p)
+		off += 2 + n
+	}
+
+	return out, nil
+}
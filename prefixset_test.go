@@ -0,0 +1,73 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestPrefixesBytes(t *testing.T) {
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.128.0/17"),
+		netip.MustParsePrefix("0.0.0.0/0"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("1.2.3.4/32"),
+	}
+
+	b, err := PrefixesToBytes(prefixes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "04080a" + "0411c0a880" + "0400" + "062020010db8" + "042001020304"
+	if got := hex.EncodeToString(b); got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+
+	got, err := PrefixesFromBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, prefixes) {
+		t.Errorf("got %v, expected %v", got, prefixes)
+	}
+
+	// host bits are dropped
+	b, _ = PrefixesToBytes([]netip.Prefix{netip.MustParsePrefix("10.1.2.3/8")})
+	if got := hex.EncodeToString(b); got != "04080a" {
+		t.Errorf("expected masked prefix 04080a, got %s", got)
+	}
+
+	if got, err := PrefixesFromBytes(nil); err != nil || len(got) != 0 {
+		t.Errorf("expected empty list, got %v (%v)", got, err)
+	}
+}
+
+func TestPrefixesBytesErrors(t *testing.T) {
+	dst := []byte{1}
+	out, err := AppendPrefixes(dst, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), {}})
+	if err == nil || len(out) != 1 {
+		t.Errorf("expected error and unchanged dst, got %x (%v)", out, err)
+	}
+
+	cases := []string{
+		"04",     // truncated header
+		"0508",   // unknown version
+		"0421",   // length exceeds 32 bits
+		"04100a", // missing address byte
+		"04070b", // host bit set
+	}
+	for _, h := range cases {
+		b, _ := hex.DecodeString(h)
+		if _, err := PrefixesFromBytes(b); err == nil {
+			t.Errorf("PrefixesFromBytes(%s): expected error", h)
+		}
+	}
+
+	b, _ := hex.DecodeString("04080a04080b")
+	if _, err := PrefixesFromBytes(b, WithLimits(Limits{MaxElements: 1})); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package bytecast
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// RecordReader walks back-to-back fixed-size records of type T (see Marshal) stored in []byte or io.Reader,
+// so scanning of log files and dumps needs no manual offset bookkeeping:
+//
+//	rr, err := NewRecordReader[Entry](f)
+//	for {
+//		entry, err := rr.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+type RecordReader[T any] struct {
+	r      io.Reader
+	data   []byte // used instead of r for in-memory input
+	buf    []byte
+	size   int
+	offset int64
+}
+
+// NewRecordReader creates RecordReader reading records from r.
+// T must have fixed encoded size (struct, fixed-size integer, BytesN, ...).
+func NewRecordReader[T any](r io.Reader) (*RecordReader[T], error) {
+	size, err := recordSize[T]()
+	if err != nil {
+		return nil, err
+	}
+	return &RecordReader[T]{r: r, buf: make([]byte, size), size: size}, nil
+}
+
+// NewRecordReaderBytes creates RecordReader reading records from data, without copying it.
+func NewRecordReaderBytes[T any](data []byte) (*RecordReader[T], error) {
+	size, err := recordSize[T]()
+	if err != nil {
+		return nil, err
+	}
+	return &RecordReader[T]{data: data, size: size}, nil
+}
+
+func recordSize[T any]() (int, error) {
+	size, ok := fixedDecodeSize(new(T))
+	if !ok || size == 0 {
+		return 0, fmt.Errorf("type %T has no fixed encoded size", *new(T))
+	}
+	return size, nil
+}
+
+// RecordSize returns encoded size of one record.
+func (rr *RecordReader[T]) RecordSize() int {
+	return rr.size
+}
+
+// Offset returns input offset of the next record.
+func (rr *RecordReader[T]) Offset() int64 {
+	return rr.offset
+}
+
+// Next decodes next record. It returns io.EOF when input ends exactly at record boundary,
+// and io.ErrUnexpectedEOF when input ends in the middle of a record.
+// Decode errors are prefixed with offset of the record.
+func (rr *RecordReader[T]) Next() (T, error) {
+	var v T
+
+	record, err := rr.nextBytes()
+	if err != nil {
+		return v, err
+	}
+
+	start := rr.offset
+	rr.offset += int64(rr.size)
+
+	if err := Unmarshal(record, &v); err != nil {
+		return v, fmt.Errorf("record at offset %d: %w", start, err)
+	}
+	return v, nil
+}
+
+// NextContext is Next which can be cancelled by ctx, see ReadFrameContext.
+func (rr *RecordReader[T]) NextContext(ctx context.Context) (T, error) {
+	var v T
+	if rr.r == nil {
+		if err := ctx.Err(); err != nil {
+			return v, err
+		}
+		return rr.Next()
+	}
+
+	err := withReadContext(ctx, rr.r, func() (err error) {
+		v, err = rr.Next()
+		return err
+	})
+	return v, err
+}
+
+func (rr *RecordReader[T]) nextBytes() ([]byte, error) {
+	if rr.r == nil {
+		switch {
+		case len(rr.data) == 0:
+			return nil, io.EOF
+		case len(rr.data) < rr.size:
+			return nil, io.ErrUnexpectedEOF
+		}
+		record := rr.data[:rr.size]
+		rr.data = rr.data[rr.size:]
+		return record, nil
+	}
+
+	if _, err := io.ReadFull(rr.r, rr.buf); err != nil {
+		return nil, err
+	}
+	return rr.buf, nil
+}
@@ -0,0 +1,55 @@
+//go:build go1.23
+
+package bytecast
+
+import (
+	"io"
+	"iter"
+)
+
+// Records returns sequence of records of type T read from r (see RecordReader), for use with range:
+//
+//	for entry, err := range bytecast.Records[Entry](f) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Sequence ends at io.EOF; any other error is yielded once as the last element.
+// Breaking out of the loop stops reading.
+func Records[T any](r io.Reader) iter.Seq2[T, error] {
+	rr, err := NewRecordReader[T](r)
+	return recordSeq(rr, err)
+}
+
+// RecordsBytes is Records reading from data.
+func RecordsBytes[T any](data []byte) iter.Seq2[T, error] {
+	rr, err := NewRecordReaderBytes[T](data)
+	return recordSeq(rr, err)
+}
+
+// All returns sequence of the remaining records, see Records.
+func (rr *RecordReader[T]) All() iter.Seq2[T, error] {
+	return recordSeq(rr, nil)
+}
+
+func recordSeq[T any](rr *RecordReader[T], err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+
+		for {
+			v, err := rr.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+//go:build go1.23
+
+package bytecast
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestRecords(t *testing.T) {
+	var data []byte
+	for i := range 5 {
+		data, _ = MarshalAppend(data, uint16(i))
+	}
+
+	var got []uint16
+	for v, err := range Records[uint16](bytes.NewReader(data)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	if len(got) != 5 || got[4] != 4 {
+		t.Fatalf("expected 0..4, got %v", got)
+	}
+
+	// early break stops reading
+	r := bytes.NewReader(data)
+	for v := range Records[uint16](r) {
+		if v == 1 {
+			break
+		}
+	}
+	if r.Len() != 6 {
+		t.Fatalf("expected 3 records left unread, got %d bytes", r.Len())
+	}
+
+	// error is the last element
+	var errs []error
+	count := 0
+	for _, err := range RecordsBytes[uint16](data[:9]) {
+		count++
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if count != 5 || len(errs) != 1 || errs[0] != io.ErrUnexpectedEOF {
+		t.Fatalf("expected 4 records and io.ErrUnexpectedEOF, got %d elements, %v", count, errs)
+	}
+
+	for _, err := range RecordsBytes[[]byte](data) {
+		if err == nil {
+			t.Fatal("expected error for variable-size type")
+		}
+	}
+}
+
+func TestRecordReaderAll(t *testing.T) {
+	rr, err := NewRecordReaderBytes[uint8]([]byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rr.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := 0
+	for v, err := range rr.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum += int(v)
+	}
+	if sum != 5 {
+		t.Fatalf("expected remaining records 2 and 3, got sum %d", sum)
+	}
+}
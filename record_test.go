@@ -0,0 +1,101 @@
+package bytecast
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRecordReader(t *testing.T) {
+	records := []dumpTestRecord{
+		{Version: 1, Delta: -1, Ok: true},
+		{Version: 2, Delta: 300},
+		{Version: 3, Tag: [20]byte{0xaa}},
+	}
+
+	var data []byte
+	for _, r := range records {
+		var err error
+		if data, err = MarshalAppend(data, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	readers := map[string]func([]byte) (*RecordReader[dumpTestRecord], error){
+		"bytes": NewRecordReaderBytes[dumpTestRecord],
+		"reader": func(b []byte) (*RecordReader[dumpTestRecord], error) {
+			return NewRecordReader[dumpTestRecord](bytes.NewReader(b))
+		},
+	}
+	for name, newReader := range readers {
+		rr, err := newReader(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rr.RecordSize() != 24 {
+			t.Fatalf("%s: expected record size 24, got %d", name, rr.RecordSize())
+		}
+
+		for i, want := range records {
+			got, err := rr.Next()
+			if err != nil || got != want {
+				t.Fatalf("%s: record %d: expected %+v, got %+v (%v)", name, i, want, got, err)
+			}
+		}
+		if _, err := rr.Next(); err != io.EOF {
+			t.Fatalf("%s: expected io.EOF, got %v", name, err)
+		}
+		if rr.Offset() != int64(len(data)) {
+			t.Fatalf("%s: expected offset %d, got %d", name, len(data), rr.Offset())
+		}
+
+		rr, _ = newReader(data[:len(data)-1])
+		for range 2 {
+			if _, err := rr.Next(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := rr.Next(); err != io.ErrUnexpectedEOF {
+			t.Fatalf("%s: expected io.ErrUnexpectedEOF, got %v", name, err)
+		}
+	}
+}
+
+func TestRecordReaderDecodeError(t *testing.T) {
+	type record struct {
+		Value *uint8
+	}
+	data := []byte{0x01, 0x05, 0x07, 0x00, 0x09, 0x00}
+
+	rr, err := NewRecordReaderBytes[record](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, err := rr.Next(); err != nil || *r.Value != 5 {
+		t.Fatalf("expected value 5, got %v", err)
+	}
+
+	_, err = rr.Next()
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || err.Error() != "record at offset 2: Value: invalid presence byte 0x07 at offset 0" {
+		t.Fatalf("expected field error of second record, got %v", err)
+	}
+	if rr.Offset() != 4 {
+		t.Fatalf("failed record must be consumed, expected offset 4, got %d", rr.Offset())
+	}
+
+	_, err = rr.Next()
+	if err == nil || err.Error() != "record at offset 4: Value: invalid presence byte 0x09 at offset 0" {
+		t.Fatalf("expected field error of third record, got %v", err)
+	}
+}
+
+func TestRecordReaderVariableSize(t *testing.T) {
+	if _, err := NewRecordReaderBytes[[]byte](nil); err == nil {
+		t.Error("expected error for variable-size type")
+	}
+	if _, err := NewRecordReader[string](bytes.NewReader(nil)); err != nil {
+		t.Errorf("expected string256 records to be accepted, got %v", err)
+	}
+}
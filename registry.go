@@ -0,0 +1,97 @@
+package bytecast
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// customCodec is type-erased pair of functions passed to Register.
+type customCodec struct {
+	encode func(any) ([]byte, error)
+	decode func([]byte) (any, error)
+}
+
+var customCodecs sync.Map // reflect.Type → *customCodec
+
+// BytecastMarshaler is implemented by types which control their own wire representation.
+// Marshal and struct codec delegate to it; inside structs the same fixed-width rule as for Register applies.
+type BytecastMarshaler interface {
+	MarshalBytecast() ([]byte, error)
+}
+
+// BytecastUnmarshaler is the decoding counterpart of BytecastMarshaler, usually implemented on pointer receiver.
+type BytecastUnmarshaler interface {
+	UnmarshalBytecast(data []byte) error
+}
+
+var (
+	marshalerType   = reflect.TypeFor[BytecastMarshaler]()
+	unmarshalerType = reflect.TypeFor[BytecastUnmarshaler]()
+
+	interfaceCodecs sync.Map // reflect.Type → *customCodec built from BytecastMarshaler / BytecastUnmarshaler
+)
+
+// Register plugs encoding of third-party type T (decimal.Decimal, uuid.UUID, custom IDs)
+// into Marshal / Unmarshal and struct fields of Schema, without forking the package.
+// Registered codec takes precedence over built-in handling of T.
+//
+// Struct fields have fixed offsets, so inside structs T must always encode to the same number
+// of bytes as its zero value; Marshal fails otherwise.
+//
+// Register is meant to be called from init, before first use of T: schemas compiled earlier
+// are not updated. Like sql.Register it panics if T is registered twice.
+func Register[T any](enc func(T) ([]byte, error), dec func([]byte) (T, error)) {
+	if enc == nil || dec == nil {
+		panic("bytecast: Register with nil encoder or decoder")
+	}
+
+	t := reflect.TypeFor[T]()
+	c := &customCodec{
+		encode: func(v any) ([]byte, error) { return enc(v.(T)) },
+		decode: func(b []byte) (any, error) { return dec(b) },
+	}
+
+	if _, loaded := customCodecs.LoadOrStore(t, c); loaded {
+		panic(fmt.Sprintf("bytecast: Register called twice for type %s", t))
+	}
+}
+
+// lookupCustomCodec returns codec registered for t with Register or, if there is none,
+// codec delegating to BytecastMarshaler / BytecastUnmarshaler implemented by non-pointer type t.
+func lookupCustomCodec(t reflect.Type) *customCodec {
+	if t == nil {
+		return nil
+	}
+	if c, ok := customCodecs.Load(t); ok {
+		return c.(*customCodec)
+	}
+
+	if t.Kind() == reflect.Pointer || !reflect.PointerTo(t).Implements(marshalerType) {
+		return nil
+	}
+	if c, ok := interfaceCodecs.Load(t); ok {
+		return c.(*customCodec)
+	}
+
+	c := &customCodec{
+		encode: func(v any) ([]byte, error) {
+			// copy into new pointer, so methods with both value and pointer receivers can be called
+			p := reflect.New(t)
+			p.Elem().Set(reflect.ValueOf(v))
+			return p.Interface().(BytecastMarshaler).MarshalBytecast()
+		},
+		decode: func(b []byte) (any, error) {
+			p := reflect.New(t)
+			u, ok := p.Interface().(BytecastUnmarshaler)
+			if !ok {
+				return nil, fmt.Errorf("type %s implements BytecastMarshaler, but not BytecastUnmarshaler", t)
+			}
+			err := u.UnmarshalBytecast(b)
+			return p.Elem().Interface(), err
+		},
+	}
+
+	actual, _ := interfaceCodecs.LoadOrStore(t, c)
+	return actual.(*customCodec)
+}
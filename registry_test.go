@@ -0,0 +1,174 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// registryTestCents is third-party-like type encoded as 6-byte big-endian number of cents.
+type registryTestCents struct {
+	units, cents int64
+}
+
+// registryTestTag is registered with variable-length encoding, so it is not usable in structs.
+type registryTestTag string
+
+func init() {
+	Register(
+		func(c registryTestCents) ([]byte, error) {
+			return IntXXToBytesAndExpandWidth(c.units*100+c.cents, 48, 6)
+		},
+		func(b []byte) (registryTestCents, error) {
+			if len(b) != 6 {
+				return registryTestCents{}, fmt.Errorf("expected 6 bytes, got %d", len(b))
+			}
+			v, err := IntXXFromBytes(b, 48)
+			return registryTestCents{units: v / 100, cents: v % 100}, err
+		},
+	)
+
+	Register(
+		func(t registryTestTag) ([]byte, error) { return []byte(t), nil },
+		func(b []byte) (registryTestTag, error) { return registryTestTag(b), nil },
+	)
+}
+
+type registryTestInvoice struct {
+	ID    uint16
+	Total registryTestCents
+}
+
+func TestRegisterMarshal(t *testing.T) {
+	data, err := Marshal(registryTestCents{units: 12, cents: 34})
+	if err != nil || hex.EncodeToString(data) != "0000000004d2" {
+		t.Fatalf("expected 0000000004d2 got %x (%v)", data, err)
+	}
+
+	var c registryTestCents
+	if err := Unmarshal(data, &c); err != nil || c != (registryTestCents{12, 34}) {
+		t.Fatalf("unexpected %+v (%v)", c, err)
+	}
+
+	if err := Unmarshal([]byte{1}, &c); err == nil {
+		t.Fatal("expected error from registered decoder")
+	}
+}
+
+func TestRegisterSchema(t *testing.T) {
+	layout, err := DescribeLayout(registryTestInvoice{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layout) != 2 || layout[1] != (FieldLayout{"Total", 2, 6, KindCustom, false}) {
+		t.Fatalf("unexpected layout %v", layout)
+	}
+
+	in := registryTestInvoice{ID: 1, Total: registryTestCents{units: -1, cents: -5}}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out registryTestInvoice
+	if err := Unmarshal(data, &out); err != nil || out != in {
+		t.Fatalf("expected %+v got %+v (%v)", in, out, err)
+	}
+
+	s, _ := SchemaOf(registryTestInvoice{})
+	if dump := Dump(data, s); !strings.Contains(dump, "custom") {
+		t.Fatalf("expected custom field in dump:\n%s", dump)
+	}
+
+	// variable-length registered type breaks fixed struct layout
+	type tagged struct{ Tag registryTestTag }
+	if _, err := Marshal(tagged{Tag: "longer than zero value"}); err == nil {
+		t.Fatal("expected error for registered type changing width")
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	Register(
+		func(t registryTestTag) ([]byte, error) { return nil, nil },
+		func(b []byte) (registryTestTag, error) { return "", nil },
+	)
+}
+
+// registryTestColor controls its own 3-byte RGB representation.
+type registryTestColor struct {
+	R, G, B uint8
+	name    string // not part of wire format
+}
+
+func (c registryTestColor) MarshalBytecast() ([]byte, error) {
+	return []byte{c.R, c.G, c.B}, nil
+}
+
+func (c *registryTestColor) UnmarshalBytecast(data []byte) error {
+	if len(data) != 3 {
+		return fmt.Errorf("expected 3 bytes, got %d", len(data))
+	}
+	c.R, c.G, c.B, c.name = data[0], data[1], data[2], "decoded"
+	return nil
+}
+
+type registryTestWriteOnly struct{}
+
+func (registryTestWriteOnly) MarshalBytecast() ([]byte, error) { return []byte{1}, nil }
+
+type registryTestPixel struct {
+	X, Y  uint16
+	Color registryTestColor
+	Alpha *registryTestColor
+}
+
+func TestBytecastMarshaler(t *testing.T) {
+	data, err := Marshal(registryTestColor{R: 1, G: 2, B: 3})
+	if err != nil || hex.EncodeToString(data) != "010203" {
+		t.Fatalf("expected 010203 got %x (%v)", data, err)
+	}
+
+	// pointer to marshaler uses the same encoding
+	if data, err := Marshal(&registryTestColor{R: 1, G: 2, B: 3}); err != nil || hex.EncodeToString(data) != "010203" {
+		t.Fatalf("expected 010203 got %x (%v)", data, err)
+	}
+
+	var c registryTestColor
+	if err := Unmarshal(data, &c); err != nil || c.name != "decoded" || c.B != 3 {
+		t.Fatalf("unexpected %+v (%v)", c, err)
+	}
+
+	layout, err := DescribeLayout(registryTestPixel{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layout) != 5 || layout[2] != (FieldLayout{"Color", 4, 3, KindCustom, false}) {
+		t.Fatalf("unexpected layout %v", layout)
+	}
+
+	in := registryTestPixel{X: 1, Y: 2, Color: registryTestColor{R: 0xff}, Alpha: &registryTestColor{B: 0x80}}
+	data, err = Marshal(in)
+	if err != nil || hex.EncodeToString(data) != "00010002"+"ff0000"+"01"+"000080" {
+		t.Fatalf("unexpected encoding %x (%v)", data, err)
+	}
+
+	var out registryTestPixel
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Color.R != 0xff || out.Alpha == nil || out.Alpha.B != 0x80 {
+		t.Fatalf("unexpected %+v", out)
+	}
+
+	var w registryTestWriteOnly
+	if err := Unmarshal([]byte{1}, &w); err == nil {
+		t.Fatal("expected error for type without BytecastUnmarshaler")
+	}
+}
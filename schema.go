@@ -0,0 +1,818 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FieldKind is the wire kind of a single encoded field.
+type FieldKind int
+
+const (
+	KindInt      FieldKind = iota + 1 // signed integer, big-endian two's complement
+	KindUint                          // unsigned integer, big-endian
+	KindBool                          // 1 byte, see BoolTo1Byte
+	KindString                        // length byte + right-aligned bytes, 256 bytes by default (StringToNBytes)
+	KindBytes                         // fixed-size raw bytes ([N]byte, Bytes32, Address, ...)
+	KindPresence                      // 1 byte presence flag of pointer field, 0x00 nil, 0x01 present
+	KindCustom                        // type registered with Register, or float field with `bytecastspec` tag
+	KindBigInt                        // *big.Int, two's complement, width must be given in tag
+	KindReserved                      // reserved bytes (tag options pad, align, offset), zeros
+)
+
+var fieldKindNames = [...]string{
+	KindInt:      "int",
+	KindUint:     "uint",
+	KindBool:     "bool",
+	KindString:   "string",
+	KindBytes:    "bytes",
+	KindPresence: "presence",
+	KindCustom:   "custom",
+	KindBigInt:   "bigint",
+	KindReserved: "reserved",
+}
+
+func (k FieldKind) String() string {
+	if k > 0 && int(k) < len(fieldKindNames) {
+		return fieldKindNames[k]
+	}
+	return fmt.Sprintf("FieldKind(%d)", int(k))
+}
+
+// FieldLayout describes position of one encoded field inside a record.
+type FieldLayout struct {
+	Name         string // field path, e.g. "Header.Timestamps[2]"
+	Offset       int    // byte offset from the start of the record
+	Width        int    // encoded width in bytes
+	Kind         FieldKind
+	LittleEndian bool // integer stored little-endian (tag option "le")
+}
+
+// Schema is the compiled wire layout of a struct type, as used by Marshal / Unmarshal.
+//
+// Struct is encoded as concatenation of its exported fields in declaration order, without any padding
+// (unless offsets are given in tags):
+//
+//	bool                              → 1 byte
+//	int8..int64, uint8..uint64        → 1/2/4/8 bytes, big-endian (two's complement for signed)
+//	string                            → 256 bytes (StringTo256Bytes)
+//	*big.Int                          → width bytes, two's complement, "width" tag is required
+//	[N]byte (Bytes32, Address, ...)   → N raw bytes
+//	[N]T of other supported T         → N consecutive elements
+//	nested struct                     → its fields, inline
+//	*T                                → presence byte (0x00 nil, 0x01 present) + T,
+//	                                    T bytes are zeros for nil, so the layout stays fixed-size
+//
+// int, uint and uintptr are rejected because their size depends on platform.
+//
+// Layout of a field can be adjusted with comma-separated options in `bytecast` struct tag,
+// so one Go struct can describe existing externally-defined layout exactly:
+//
+//	"-"        skip the field
+//	width=N    encoded width in bytes: integers are range-checked (narrower) or sign/zero-extended (wider),
+//	           strings use StringToNBytes layout of N bytes (2..256), bools use N bytes
+//	be | le    byte order of integer fields, big-endian by default
+//	offset=N   field starts at byte N of the record, skipped bytes are reserved; fields can not overlap
+//	align=N    reserved bytes are inserted before the field so that it starts at multiple of N
+//	pad=N      N reserved bytes are inserted after the field
+//	default=V  value of the field when it is absent from short input decoded with FillDefaults;
+//	           supported for integers, bools and strings (not pointers to them), V can not contain commas
+//
+// float64 and float32 fields are supported only with `bytecastspec` tag holding ParseFieldSpec description,
+// e.g. `bytecastspec:"uint16 LE, scale 0.01, clamp 0..500"` stores the value as scaled uint16 (KindCustom).
+//
+// Reserved bytes are written as zeros and listed in Fields as KindReserved, decoding ignores them
+// unless Decode is called with StrictPadding.
+//
+// For arrays options apply to every element, e.g. `bytecast:"width=3,le"` on [4]int32 gives 4 x 3 bytes.
+type Schema struct {
+	typ    reflect.Type
+	fields []schemaField
+	size   int
+	depth  int // nesting depth of struct types, see Limits
+}
+
+type schemaField struct {
+	FieldLayout
+	path []int // struct field / array element indexes from the root value, -1 dereferences pointer
+	span int   // KindPresence only: number of following fields belonging to the pointed value
+
+	custom *customCodec // KindCustom only
+	spec   string       // `bytecastspec` tag of float field (KindCustom)
+	def    []byte       // encoded default value (tag option default), nil if not set
+}
+
+var schemaCache sync.Map // reflect.Type → *Schema
+
+// SchemaOf returns schema of struct type of v, v may be struct value, pointer to struct or reflect.Type.
+// Schemas are compiled once per type and cached.
+func SchemaOf(v any) (*Schema, error) {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema requires struct type, got %v", t)
+	}
+
+	if s, ok := schemaCache.Load(t); ok {
+		return s.(*Schema), nil
+	}
+
+	s := &Schema{typ: t}
+	if err := s.addFields(t, "", nil, nil, fieldTag{offset: -1}); err != nil {
+		return nil, fmt.Errorf("%s: %w", t, err)
+	}
+
+	actual, _ := schemaCache.LoadOrStore(t, s)
+	return actual.(*Schema), nil
+}
+
+// DescribeLayout returns ordered list of encoded fields of struct v with their offsets, widths and kinds,
+// e.g. to render byte-offset documentation or compare layouts between services.
+func DescribeLayout(v any) ([]FieldLayout, error) {
+	s, err := SchemaOf(v)
+	if err != nil {
+		return nil, err
+	}
+	return s.Fields(), nil
+}
+
+// Type returns struct type described by the schema.
+func (s *Schema) Type() reflect.Type {
+	return s.typ
+}
+
+// Size returns encoded length of every value of the schema type.
+func (s *Schema) Size() int {
+	return s.size
+}
+
+// Fields returns ordered list of encoded fields.
+func (s *Schema) Fields() []FieldLayout {
+	out := make([]FieldLayout, len(s.fields))
+	for i, f := range s.fields {
+		out[i] = f.FieldLayout
+	}
+	return out
+}
+
+// addFields appends fields of type t, parents holds types being compiled to detect recursive types,
+// tag holds options of the struct field t belongs to.
+func (s *Schema) addFields(t reflect.Type, name string, path []int, parents []reflect.Type, tag fieldTag) error {
+	if slices.Contains(parents, t) {
+		return fmt.Errorf("field %s: recursive type %s is not supported", name, t)
+	}
+	parents = append(parents, t)
+
+	// arrays and pointers pass the spec to their elements
+	if tag.spec != "" && t.Kind() != reflect.Array && (t.Kind() != reflect.Pointer || t == reflect.TypeFor[*big.Int]()) {
+		return s.addSpecField(t, name, path, tag)
+	}
+
+	if c := lookupCustomCodec(t); c != nil {
+		if tag.width > 0 || tag.little || tag.hasDefault {
+			return fmt.Errorf("field %s: width, byte order and default options are not applicable to %s with own codec", name, t)
+		}
+
+		zero, err := c.encode(reflect.Zero(t).Interface())
+		if err != nil {
+			return fmt.Errorf("field %s: encoding zero value of registered type %s: %w", name, t, err)
+		}
+
+		s.fields = append(s.fields, schemaField{
+			FieldLayout: FieldLayout{Name: name, Offset: s.size, Width: len(zero), Kind: KindCustom},
+			path:        path,
+			custom:      c,
+		})
+		s.size += len(zero)
+		return nil
+	}
+
+	kind := FieldKind(0)
+	width := 0
+
+	if t == reflect.TypeFor[*big.Int]() {
+		if tag.width <= 0 {
+			return fmt.Errorf("field %s: *big.Int requires width tag option", name)
+		}
+		return s.addLeaf(t, name, path, KindBigInt, tag.width, tag)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if tag.width > 0 || tag.little || tag.hasDefault {
+			return fmt.Errorf("field %s: width, byte order and default options are not applicable to struct", name)
+		}
+
+		depth := 0
+		for _, p := range parents {
+			if p.Kind() == reflect.Struct {
+				depth++
+			}
+		}
+		s.depth = max(s.depth, depth)
+
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			fieldName := f.Name
+			if name != "" {
+				fieldName = name + "." + f.Name
+			}
+
+			fieldTag, err := parseFieldTag(f.Tag.Get("bytecast"))
+			if err != nil {
+				return fmt.Errorf("field %s: %w", fieldName, err)
+			}
+			fieldTag.spec = f.Tag.Get("bytecastspec")
+			if fieldTag.skip {
+				continue
+			}
+
+			if fieldTag.offset >= 0 {
+				if fieldTag.offset < s.size {
+					return fmt.Errorf("field %s: offset %d overlaps previous fields ending at %d", fieldName, fieldTag.offset, s.size)
+				}
+				s.addReserved(fieldTag.offset - s.size)
+			}
+
+			if fieldTag.align > 0 {
+				s.addReserved((fieldTag.align - s.size%fieldTag.align) % fieldTag.align)
+			}
+
+			if err := s.addFields(f.Type, fieldName, append(slices.Clone(path), i), parents, fieldTag); err != nil {
+				return err
+			}
+
+			s.addReserved(fieldTag.pad)
+		}
+		return nil
+
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			kind, width = KindBytes, t.Len()
+			break
+		}
+
+		for i := 0; i < t.Len(); i++ {
+			if err := s.addFields(t.Elem(), fmt.Sprintf("%s[%d]", name, i), append(slices.Clone(path), i), parents, tag); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Pointer:
+		if tag.hasDefault {
+			// absent pointer field decodes as nil, there is nothing to apply the default to
+			return fmt.Errorf("field %s: default option is not applicable to pointer", name)
+		}
+
+		presence := len(s.fields)
+		s.fields = append(s.fields, schemaField{
+			FieldLayout: FieldLayout{Name: name, Offset: s.size, Width: 1, Kind: KindPresence},
+			path:        path,
+		})
+		s.size++
+
+		if err := s.addFields(t.Elem(), name, append(slices.Clone(path), -1), parents, tag); err != nil {
+			return err
+		}
+
+		s.fields[presence].span = len(s.fields) - presence - 1
+		return nil
+
+	case reflect.Bool:
+		kind, width = KindBool, 1
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		kind, width = KindInt, int(t.Size())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		kind, width = KindUint, int(t.Size())
+	case reflect.String:
+		kind, width = KindString, 256
+	case reflect.Int, reflect.Uint, reflect.Uintptr:
+		return fmt.Errorf("field %s: platform-dependent type %s is not supported, use sized integer", name, t)
+	default:
+		return fmt.Errorf("field %s: unsupported type %s", name, t)
+	}
+
+	if tag.width > 0 {
+		switch {
+		case kind == KindBytes && tag.width != width:
+			return fmt.Errorf("field %s: width of byte array can not be changed", name)
+		case kind == KindString && (tag.width < 2 || tag.width > 256):
+			return fmt.Errorf("field %s: unsupported string width %d, must be 2..256", name, tag.width)
+		}
+		width = tag.width
+	}
+
+	return s.addLeaf(t, name, path, kind, width, tag)
+}
+
+// addSpecField adds float field encoded by codec of `bytecastspec` tag (see ParseFieldSpec).
+func (s *Schema) addSpecField(t reflect.Type, name string, path []int, tag fieldTag) error {
+	if t.Kind() != reflect.Float64 && t.Kind() != reflect.Float32 {
+		return fmt.Errorf("field %s: bytecastspec tag is applicable to float64 and float32 only, got %s", name, t)
+	}
+	if tag.width > 0 || tag.little || tag.hasDefault {
+		return fmt.Errorf("field %s: width, byte order and default options are not applicable to field with bytecastspec tag", name)
+	}
+
+	codec, width, err := parseFieldSpec(tag.spec)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", name, err)
+	}
+
+	s.fields = append(s.fields, schemaField{
+		FieldLayout: FieldLayout{Name: name, Offset: s.size, Width: width, Kind: KindCustom},
+		path:        path,
+		spec:        tag.spec,
+		custom: &customCodec{
+			encode: func(v any) ([]byte, error) {
+				return codec.Append(nil, reflect.ValueOf(v).Float())
+			},
+			decode: func(b []byte) (any, error) {
+				return codec.Decode(b)
+			},
+		},
+	})
+	s.size += width
+	return nil
+}
+
+func (s *Schema) addLeaf(t reflect.Type, name string, path []int, kind FieldKind, width int, tag fieldTag) error {
+	if tag.little && kind != KindInt && kind != KindUint && kind != KindBigInt {
+		return fmt.Errorf("field %s: byte order option is applicable to integers only", name)
+	}
+
+	f := schemaField{
+		FieldLayout: FieldLayout{Name: name, Offset: s.size, Width: width, Kind: kind, LittleEndian: tag.little},
+		path:        path,
+	}
+
+	if tag.hasDefault {
+		v, err := parseDefault(t, tag.def)
+		if err != nil {
+			return fmt.Errorf("field %s: invalid default %q: %w", name, tag.def, err)
+		}
+
+		f.def = make([]byte, width)
+		if err := f.put(f.def, v); err != nil {
+			return fmt.Errorf("field %s: invalid default %q: %w", name, tag.def, err)
+		}
+	}
+
+	s.fields = append(s.fields, f)
+	s.size += width
+
+	return nil
+}
+
+// parseDefault parses value of default tag option as value of type t.
+func parseDefault(t reflect.Type, value string) (reflect.Value, error) {
+	if t == reflect.TypeFor[*big.Int]() {
+		x, ok := new(big.Int).SetString(value, 0)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("not an integer")
+		}
+		return reflect.ValueOf(x), nil
+	}
+
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, err := strconv.ParseInt(value, 0, 64)
+		if err != nil {
+			return v, err
+		}
+		if v.OverflowInt(x) {
+			return v, fmt.Errorf("value overflows %s", t)
+		}
+		v.SetInt(x)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		x, err := strconv.ParseUint(value, 0, 64)
+		if err != nil {
+			return v, err
+		}
+		if v.OverflowUint(x) {
+			return v, fmt.Errorf("value overflows %s", t)
+		}
+		v.SetUint(x)
+	case reflect.String:
+		v.SetString(value)
+	default:
+		return v, fmt.Errorf("default option is not supported for %s", t)
+	}
+	return v, nil
+}
+
+// addReserved appends n reserved bytes at the current end of the record.
+func (s *Schema) addReserved(n int) {
+	if n <= 0 {
+		return
+	}
+
+	s.fields = append(s.fields, schemaField{
+		FieldLayout: FieldLayout{Name: "<reserved>", Offset: s.size, Width: n, Kind: KindReserved},
+	})
+	s.size += n
+}
+
+// fieldTag holds parsed options of `bytecast` struct tag, see Schema.
+type fieldTag struct {
+	skip   bool
+	width  int
+	little bool
+	offset int // -1 if not set
+	align  int
+	pad    int
+
+	def        string
+	hasDefault bool
+
+	spec string // `bytecastspec` tag, see ParseFieldSpec
+}
+
+func parseFieldTag(tag string) (fieldTag, error) {
+	t := fieldTag{offset: -1}
+	if tag == "" {
+		return t, nil
+	}
+
+	if tag == "-" {
+		t.skip = true
+		return t, nil
+	}
+
+	for _, opt := range strings.Split(tag, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(opt), "=")
+
+		var err error
+		switch {
+		case key == "be" && !hasValue:
+			t.little = false
+		case key == "le" && !hasValue:
+			t.little = true
+		case key == "width" && hasValue:
+			t.width, err = strconv.Atoi(value)
+			if err == nil && t.width <= 0 {
+				err = fmt.Errorf("must be positive")
+			}
+		case key == "offset" && hasValue:
+			t.offset, err = strconv.Atoi(value)
+			if err == nil && t.offset < 0 {
+				err = fmt.Errorf("must not be negative")
+			}
+		case key == "align" && hasValue:
+			t.align, err = strconv.Atoi(value)
+			if err == nil && t.align <= 0 {
+				err = fmt.Errorf("must be positive")
+			}
+		case key == "default" && hasValue:
+			t.def, t.hasDefault = value, true
+		case key == "pad" && hasValue:
+			t.pad, err = strconv.Atoi(value)
+			if err == nil && t.pad < 0 {
+				err = fmt.Errorf("must not be negative")
+			}
+		default:
+			return t, fmt.Errorf("unknown bytecast tag option %q", opt)
+		}
+
+		if err != nil {
+			return t, fmt.Errorf("invalid bytecast tag option %q: %w", opt, err)
+		}
+	}
+
+	return t, nil
+}
+
+// appendStruct appends encoding of struct value rv (of schema type) to dst.
+func (s *Schema) appendStruct(dst []byte, rv reflect.Value) ([]byte, error) {
+	start := len(dst)
+	dst = slices.Grow(dst, s.size)[:start+s.size]
+	record := dst[start:]
+	clear(record)
+
+	for i := 0; i < len(s.fields); i++ {
+		f := &s.fields[i]
+		if f.Kind == KindReserved {
+			continue // already zeroed
+		}
+
+		v := fieldByPath(rv, f.path)
+
+		if f.Kind == KindPresence {
+			if v.IsNil() {
+				i += f.span // pointed value stays zeroed
+				continue
+			}
+			record[f.Offset] = 1
+			continue
+		}
+
+		if err := f.put(record[f.Offset:f.Offset+f.Width], v); err != nil {
+			return dst[:start], fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+
+	return dst, nil
+}
+
+// FieldError is returned by Unmarshal and Decode when a field of struct can not be decoded.
+type FieldError struct {
+	Field  string // path of the field, as in FieldLayout.Name (e.g. "Header.Timestamps[2]")
+	Offset int    // offset of the offending byte(s) within the record
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v at offset %d", e.Field, e.Err, e.Offset)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// decodeStruct decodes data into addressable struct value rv (of schema type).
+func (s *Schema) decodeStruct(data []byte, rv reflect.Value, c *decodeConfig) error {
+	if len(data) > s.size || len(data) < s.size && !c.fillDefaults {
+		return fmt.Errorf("expected exactly %d bytes for %s, but got %d bytes", s.size, s.typ, len(data))
+	}
+
+	for i := 0; i < len(s.fields); i++ {
+		f := &s.fields[i]
+
+		if end := f.Offset + f.Width; end > len(data) {
+			if f.Offset < len(data) {
+				return &FieldError{Field: f.Name, Offset: f.Offset, Err: fmt.Errorf("field truncated, input ends at %d of %d bytes", len(data), end)}
+			}
+			skip, err := s.setDefault(rv, i)
+			if err != nil {
+				return &FieldError{Field: f.Name, Offset: f.Offset, Err: err}
+			}
+			i += skip
+			continue
+		}
+
+		if f.Kind == KindReserved {
+			if c.strictPadding {
+				if j := slices.IndexFunc(data[f.Offset:f.Offset+f.Width], func(b byte) bool { return b != 0 }); j >= 0 {
+					return &FieldError{Field: f.Name, Offset: f.Offset + j, Err: ErrNonZeroPadding}
+				}
+			}
+			continue
+		}
+
+		v := fieldByPath(rv, f.path)
+
+		if f.Kind == KindPresence {
+			switch data[f.Offset] {
+			case 0:
+				v.SetZero()
+				i += f.span
+			case 1:
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+			default:
+				return &FieldError{Field: f.Name, Offset: f.Offset, Err: fmt.Errorf("invalid presence byte 0x%02x", data[f.Offset])}
+			}
+			continue
+		}
+
+		if err := f.set(v, data[f.Offset:f.Offset+f.Width]); err != nil {
+			return &FieldError{Field: f.Name, Offset: f.Offset, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// setDefault sets field i, absent from short input, to its default value or zero.
+// It returns number of following fields to skip.
+func (s *Schema) setDefault(rv reflect.Value, i int) (int, error) {
+	f := &s.fields[i]
+	if f.Kind == KindReserved {
+		return 0, nil
+	}
+
+	v := fieldByPath(rv, f.path)
+	switch {
+	case f.Kind == KindPresence:
+		v.SetZero()
+		return f.span, nil
+	case f.def != nil:
+		if err := f.set(v, f.def); err != nil {
+			return 0, fmt.Errorf("applying default: %w", err)
+		}
+	default:
+		v.SetZero()
+	}
+	return 0, nil
+}
+
+func fieldByPath(v reflect.Value, path []int) reflect.Value {
+	for _, i := range path {
+		if i < 0 {
+			v = v.Elem()
+		} else if v.Kind() == reflect.Struct {
+			v = v.Field(i)
+		} else {
+			v = v.Index(i)
+		}
+	}
+	return v
+}
+
+// put encodes field value v into out (len(out) == f.Width, zeroed).
+func (f *schemaField) put(out []byte, v reflect.Value) error {
+	if err := f.putBigEndian(out, v); err != nil {
+		return err
+	}
+
+	if f.LittleEndian {
+		slices.Reverse(out)
+	}
+
+	return nil
+}
+
+func (f *schemaField) putBigEndian(out []byte, v reflect.Value) error {
+	switch f.Kind {
+	case KindInt:
+		x := v.Int()
+		if bits.Len64(uint64(x^(x>>63))) > 8*len(out)-1 {
+			return fmt.Errorf("value %d does not fit in %d bytes", x, len(out))
+		}
+		putWidth(out, uint64(x), x < 0)
+	case KindUint:
+		x := v.Uint()
+		if bits.Len64(x) > 8*len(out) {
+			return fmt.Errorf("value %d does not fit in %d bytes", x, len(out))
+		}
+		putWidth(out, x, false)
+	case KindBool:
+		if v.Bool() {
+			out[len(out)-1] = 1
+		}
+	case KindString:
+		l := len(v.String())
+		if l > len(out)-1 {
+			return fmt.Errorf("string length exceeded, max %d bytes allowed, got %d", len(out)-1, l)
+		}
+		out[0] = uint8(l)
+		copy(out[len(out)-l:], v.String())
+	case KindBigInt:
+		x, _ := v.Interface().(*big.Int)
+		if x == nil {
+			return fmt.Errorf("nil *big.Int")
+		}
+		b, err := BigIntToBytesAndExpandWidth(x, len(out))
+		if err != nil {
+			return err
+		}
+		copy(out[len(out)-len(b):], b)
+	case KindBytes:
+		reflect.Copy(reflect.ValueOf(out), v)
+	case KindCustom:
+		b, err := f.custom.encode(v.Interface())
+		if err != nil {
+			return err
+		}
+		if len(b) != len(out) {
+			return fmt.Errorf("registered encoder of %s returned %d bytes, field width is %d", v.Type(), len(b), len(out))
+		}
+		copy(out, b)
+	}
+	return nil
+}
+
+// decode decodes field bytes into plain Go value: int64, uint64, bool, string, []byte
+// or value of registered type.
+func (f *schemaField) decode(b []byte) (any, error) {
+	if f.LittleEndian {
+		b = slices.Clone(b)
+		slices.Reverse(b)
+	}
+
+	switch f.Kind {
+	case KindInt:
+		if len(b) > 8 {
+			ext := byte(0)
+			if b[len(b)-8]&0x80 != 0 {
+				ext = 0xFF
+			}
+			if err := checkExtension(b[:len(b)-8], ext); err != nil {
+				return nil, err
+			}
+		}
+		return IntXXFromBytes(b, min(8*len(b), 64))
+	case KindUint:
+		if err := checkExtension(b[:max(len(b)-8, 0)], 0); err != nil {
+			return nil, err
+		}
+		return UintXXFromBytes(b, min(8*len(b), 64))
+	case KindBool, KindPresence:
+		if err := checkExtension(b[:len(b)-1], 0); err != nil {
+			return nil, err
+		}
+		return BoolFrom1Byte([1]byte{b[len(b)-1]}), nil
+	case KindString:
+		return StringFromNBytes(b)
+	case KindBigInt:
+		return BigIntFromBytes(b), nil
+	case KindBytes, KindReserved:
+		return b, nil
+	case KindCustom:
+		return f.custom.decode(b)
+	}
+	return nil, fmt.Errorf("unknown field kind %s", f.Kind)
+}
+
+// checkExtension verifies that high bytes of a field wider than its Go type are all ext
+// (zero or sign extension), the encoder never writes anything else there.
+func checkExtension(high []byte, ext byte) error {
+	for i, c := range high {
+		if c != ext {
+			return fmt.Errorf("byte 0x%02x at position %d is not a valid extension, value does not fit field type", c, i)
+		}
+	}
+	return nil
+}
+
+// set decodes field bytes b into addressable value v.
+func (f *schemaField) set(v reflect.Value, b []byte) error {
+	x, err := f.decode(b)
+	if err != nil {
+		return err
+	}
+
+	switch x := x.(type) {
+	case int64:
+		if v.OverflowInt(x) {
+			return fmt.Errorf("value %d overflows %s", x, v.Type())
+		}
+		v.SetInt(x)
+	case uint64:
+		if v.OverflowUint(x) {
+			return fmt.Errorf("value %d overflows %s", x, v.Type())
+		}
+		v.SetUint(x)
+	case bool:
+		v.SetBool(x)
+	case string:
+		v.SetString(x)
+	case []byte:
+		reflect.Copy(v, reflect.ValueOf(x))
+	case float64:
+		v.SetFloat(x) // KindCustom of bytecastspec field
+	default:
+		v.Set(reflect.ValueOf(x)) // KindCustom, decoder returns value of the field type
+	}
+	return nil
+}
+
+// putWidth writes value big-endian into out, sign-extending negative values when out is wider than 8 bytes.
+func putWidth(out []byte, value uint64, negative bool) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], value)
+
+	n := min(len(out), 8)
+	copy(out[len(out)-n:], buf[8-n:])
+
+	if negative {
+		for i := 0; i < len(out)-n; i++ {
+			out[i] = 0xFF
+		}
+	}
+}
+
+func isStructValue(v any) bool {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t != nil && t.Kind() == reflect.Struct
+}
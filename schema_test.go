@@ -0,0 +1,348 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type schemaTestHeader struct {
+	Version    uint8
+	Timestamps [2]int64
+}
+
+type schemaTestRecord struct {
+	Header  schemaTestHeader
+	ID      Bytes8
+	Owner   Address
+	Active  bool
+	Delta   int16
+	Name    string
+	private int32 // unexported fields are not encoded
+}
+
+func TestDescribeLayout(t *testing.T) {
+	layout, err := DescribeLayout(schemaTestRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []FieldLayout{
+		{"Header.Version", 0, 1, KindUint, false},
+		{"Header.Timestamps[0]", 1, 8, KindInt, false},
+		{"Header.Timestamps[1]", 9, 8, KindInt, false},
+		{"ID", 17, 8, KindBytes, false},
+		{"Owner", 25, 20, KindBytes, false},
+		{"Active", 45, 1, KindBool, false},
+		{"Delta", 46, 2, KindInt, false},
+		{"Name", 48, 256, KindString, false},
+	}
+
+	if !reflect.DeepEqual(layout, expected) {
+		t.Fatalf("unexpected layout:\n%v\nexpected:\n%v", layout, expected)
+	}
+
+	// pointer and reflect.Type describe the same layout
+	fromPtr, err := DescribeLayout(&schemaTestRecord{})
+	if err != nil || !reflect.DeepEqual(fromPtr, expected) {
+		t.Fatalf("pointer layout mismatch: %v (%v)", fromPtr, err)
+	}
+
+	s1, _ := SchemaOf(reflect.TypeOf(schemaTestRecord{}))
+	s2, _ := SchemaOf(schemaTestRecord{})
+	if s1 != s2 {
+		t.Fatal("schema must be cached per type")
+	}
+}
+
+func TestSchemaOfUnsupported(t *testing.T) {
+	cases := []any{
+		42,
+		nil,
+		struct{ N int }{},
+		struct{ F float64 }{},
+		struct{ S []byte }{},
+	}
+
+	for _, c := range cases {
+		if _, err := SchemaOf(c); err == nil {
+			t.Errorf("SchemaOf(%#v): expected error", c)
+		}
+	}
+
+	_, err := SchemaOf(struct{ Inner struct{ Values [2]float32 } }{})
+	if err == nil || !strings.Contains(err.Error(), "Inner.Values[0]") {
+		t.Fatalf("expected error naming field path, got %v", err)
+	}
+}
+
+func TestMarshalStruct(t *testing.T) {
+	in := schemaTestRecord{
+		Header:  schemaTestHeader{Version: 3, Timestamps: [2]int64{-1, math.MaxInt64}},
+		ID:      Bytes8{1, 2, 3, 4, 5, 6, 7, 8},
+		Owner:   Address{19: 0xaa},
+		Active:  true,
+		Delta:   -300,
+		Name:    "record",
+		private: 7,
+	}
+
+	data, err := Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 304 {
+		t.Fatalf("expected 304 bytes, got %d", len(data))
+	}
+
+	if data[0] != 3 || data[46] != 0xfe || data[47] != 0xd4 {
+		t.Fatalf("unexpected encoding %x", data[:48])
+	}
+
+	var out schemaTestRecord
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	in.private = 0
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("expected %+v got %+v", in, out)
+	}
+
+	if err := Unmarshal(data[:100], &out); err == nil {
+		t.Fatal("expected error for short data")
+	}
+
+	if _, err := Marshal((*schemaTestRecord)(nil)); err == nil {
+		t.Fatal("expected error for nil pointer")
+	}
+}
+
+type schemaTestTagged struct {
+	Magic    [2]byte
+	Length   uint32   `bytecast:"width=3"`
+	Counter  uint16   `bytecast:"le"`
+	Delta    int64    `bytecast:"width=2,le"`
+	Cache    string   `bytecast:"-"`
+	Label    string   `bytecast:"width=6"`
+	Balance  *big.Int `bytecast:"width=16"`
+	Checksum uint8    `bytecast:"offset=40"`
+	Samples  [2]int32 `bytecast:"width=3"`
+}
+
+func TestSchemaTags(t *testing.T) {
+	layout, err := DescribeLayout(schemaTestTagged{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []FieldLayout{
+		{"Magic", 0, 2, KindBytes, false},
+		{"Length", 2, 3, KindUint, false},
+		{"Counter", 5, 2, KindUint, true},
+		{"Delta", 7, 2, KindInt, true},
+		{"Label", 9, 6, KindString, false},
+		{"Balance", 15, 16, KindBigInt, false},
+		{"<reserved>", 31, 9, KindReserved, false},
+		{"Checksum", 40, 1, KindUint, false},
+		{"Samples[0]", 41, 3, KindInt, false},
+		{"Samples[1]", 44, 3, KindInt, false},
+	}
+	if !reflect.DeepEqual(layout, expected) {
+		t.Fatalf("unexpected layout:\n%v\nexpected:\n%v", layout, expected)
+	}
+
+	in := schemaTestTagged{
+		Magic:    [2]byte{'B', 'C'},
+		Length:   0x010203,
+		Counter:  0x0102,
+		Delta:    -2,
+		Cache:    "not encoded",
+		Label:    "abc",
+		Balance:  big.NewInt(-1),
+		Checksum: 0x7f,
+		Samples:  [2]int32{-1, 0x7fffff},
+	}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "4243" + "010203" + "0201" + "feff" + "0300" + "00616263" + strings.Repeat("ff", 16) +
+		strings.Repeat("00", 9) + "7f" + "ffffff" + "7fffff"
+	if got := hex.EncodeToString(data); got != want {
+		t.Fatalf("unexpected encoding\n got %s\nwant %s", got, want)
+	}
+
+	var out schemaTestTagged
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Balance.Cmp(in.Balance) != 0 {
+		t.Fatalf("expected balance %s got %s", in.Balance, out.Balance)
+	}
+	out.Balance, in.Balance, in.Cache = nil, nil, ""
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("expected %+v got %+v", in, out)
+	}
+}
+
+func TestSchemaTagErrors(t *testing.T) {
+	cases := []any{
+		struct {
+			N uint16 `bytecast:"width=1"`
+		}{N: 256}, // out of range at encode time
+		struct {
+			S string `bytecast:"width=3"`
+		}{S: "abcd"}, // too long at encode time
+		struct {
+			X *big.Int `bytecast:"width=2"`
+		}{X: big.NewInt(-32769)}, // below -2^15, would wrap to positive
+		struct {
+			X *big.Int `bytecast:"width=2"`
+		}{X: big.NewInt(-65500)}, // would be encoded as a single byte
+	}
+	for _, c := range cases {
+		if _, err := Marshal(c); err == nil {
+			t.Errorf("Marshal(%+v): expected error", c)
+		}
+	}
+
+	invalid := []any{
+		struct {
+			N uint16 `bytecast:"width=0"`
+		}{},
+		struct {
+			N uint16 `bytecast:"size=2"`
+		}{},
+		struct {
+			B [4]byte `bytecast:"width=2"`
+		}{},
+		struct {
+			S string `bytecast:"width=300"`
+		}{},
+		struct {
+			B bool `bytecast:"le"`
+		}{},
+		struct {
+			X *big.Int
+		}{},
+		struct {
+			A uint32
+			B uint8 `bytecast:"offset=2"`
+		}{},
+		struct {
+			Inner struct{ A uint8 } `bytecast:"width=2"`
+		}{},
+		struct {
+			N uint8 `bytecast:"align=0"`
+		}{},
+		struct {
+			N uint8 `bytecast:"pad=-1"`
+		}{},
+	}
+	for _, c := range invalid {
+		if _, err := SchemaOf(c); err == nil {
+			t.Errorf("SchemaOf(%T): expected error", c)
+		}
+	}
+}
+
+func TestSchemaDecodeFieldError(t *testing.T) {
+	type header struct {
+		Kind       uint8
+		Timestamps [3]int16 `bytecast:"width=1"`
+	}
+	type record struct {
+		ID     uint32
+		Header header
+		Valid  bool
+	}
+
+	data := make([]byte, 9)
+	data[5] = 0x01
+	data[8] = 0x02 // Valid accepts any non-zero byte
+
+	var rec record
+	if err := Unmarshal(data, &rec); err != nil {
+		t.Fatal(err)
+	}
+
+	type narrow struct {
+		ID     uint32
+		Header struct {
+			Kind       uint8
+			Timestamps [3]int8
+		}
+		Extra *uint8
+	}
+	var n narrow
+	data = append(data[:8], 0x07, 0x00)
+	err := Unmarshal(data, &n)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Extra" || fieldErr.Offset != 8 {
+		t.Fatalf("expected FieldError for Extra at offset 8, got %v", err)
+	}
+	if err.Error() != "Extra: invalid presence byte 0x07 at offset 8" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+
+	type wide struct {
+		Header struct {
+			Timestamps [3]int8 `bytecast:"width=2"`
+		}
+	}
+	var w wide
+	err = Unmarshal([]byte{0, 1, 0xff, 0xfe, 1, 0}, &w)
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Header.Timestamps[2]" || fieldErr.Offset != 4 {
+		t.Fatalf("expected FieldError for Header.Timestamps[2] at offset 4, got %v", err)
+	}
+}
+
+func TestSchemaDecodeWideFields(t *testing.T) {
+	type record struct {
+		A int64  `bytecast:"width=10"`
+		U uint64 `bytecast:"width=9,le"`
+		B bool   `bytecast:"width=2"`
+	}
+
+	in := record{A: -5, U: 7, B: true}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(data); got != "fffffffffffffffffffb"+"070000000000000000"+"0001" {
+		t.Fatalf("unexpected encoding %s", got)
+	}
+	var out record
+	if err := Unmarshal(data, &out); err != nil || out != in {
+		t.Fatalf("expected %+v, got %+v (%v)", in, out, err)
+	}
+
+	const a, u, b = "00000000000000000005", "070000000000000000", "0001"
+	cases := []struct {
+		name   string
+		hex    string
+		field  string
+		offset int
+	}{
+		{"int high bytes", "12340000000000000005" + u + b, "A", 0},
+		{"int wrong sign extension", "00fffffffffffffffffb" + u + b, "A", 0},
+		{"int sign extension of positive", "ffff0000000000000005" + u + b, "A", 0},
+		{"uint high byte", a + "070000000000000001" + b, "U", 10},
+		{"bool high byte", a + u + "ff00", "B", 19},
+	}
+	for _, c := range cases {
+		data, _ := hex.DecodeString(c.hex)
+		var fieldErr *FieldError
+		if err := Unmarshal(data, &out); !errors.As(err, &fieldErr) || fieldErr.Field != c.field || fieldErr.Offset != c.offset {
+			t.Errorf("%s: expected FieldError for %s at offset %d, got %v", c.name, c.field, c.offset, err)
+		}
+	}
+}
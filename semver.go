@@ -0,0 +1,103 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver is a numeric semantic version with optional build number, as stored in firmware headers.
+type Semver struct {
+	Major uint32
+	Minor uint32
+	Patch uint32
+	Build uint32
+}
+
+// ParseSemver parses "major.minor.patch" with optional numeric build "+build", e.g. "1.4.2+317".
+// Leading "v" is accepted. Pre-release suffixes ("-rc.1") are not supported.
+func ParseSemver(s string) (Semver, error) {
+	rest := strings.TrimPrefix(s, "v")
+
+	var v Semver
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		build, err := strconv.ParseUint(rest[i+1:], 10, 32)
+		if err != nil {
+			return Semver{}, fmt.Errorf("invalid build number in version %q", s)
+		}
+		v.Build = uint32(build)
+		rest = rest[:i]
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Semver{}, fmt.Errorf("version %q must be major.minor.patch", s)
+	}
+	for i, dst := range []*uint32{&v.Major, &v.Minor, &v.Patch} {
+		n, err := strconv.ParseUint(parts[i], 10, 32)
+		if err != nil {
+			return Semver{}, fmt.Errorf("invalid component %q in version %q", parts[i], s)
+		}
+		*dst = uint32(n)
+	}
+
+	return v, nil
+}
+
+// String returns "major.minor.patch", followed by "+build" if build is not zero.
+func (v Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Build != 0 {
+		s += "+" + strconv.FormatUint(uint64(v.Build), 10)
+	}
+	return s
+}
+
+// SemverToBytes
+//
+//	Packs version into 4 or 8 big-endian bytes:
+//
+//	width 4: [ major (uint8) | minor (uint8) | patch (uint16) ]                   build must be 0
+//	width 8: [ major (uint16) | minor (uint16) | patch (uint16) | build (uint16) ]
+//
+//	Packed values compare in the same order as versions. Each component is checked against its width.
+func SemverToBytes(v Semver, width int) ([]byte, error) {
+	switch width {
+	case 4:
+		if v.Major > 0xFF || v.Minor > 0xFF || v.Patch > 0xFFFF {
+			return nil, fmt.Errorf("version %s does not fit 8.8.16 bits layout", v)
+		}
+		if v.Build != 0 {
+			return nil, fmt.Errorf("version %s has build number, which needs 8-byte layout", v)
+		}
+		return binary.BigEndian.AppendUint32(nil, v.Major<<24|v.Minor<<16|v.Patch), nil
+	case 8:
+		if v.Major > 0xFFFF || v.Minor > 0xFFFF || v.Patch > 0xFFFF || v.Build > 0xFFFF {
+			return nil, fmt.Errorf("version %s does not fit 16.16.16.16 bits layout", v)
+		}
+		packed := uint64(v.Major)<<48 | uint64(v.Minor)<<32 | uint64(v.Patch)<<16 | uint64(v.Build)
+		return binary.BigEndian.AppendUint64(nil, packed), nil
+	default:
+		return nil, fmt.Errorf("unsupported version width %d, must be 4 or 8 bytes", width)
+	}
+}
+
+// SemverFromBytes unpacks version written by SemverToBytes, layout is chosen by len(b).
+func SemverFromBytes(b []byte) (Semver, error) {
+	switch len(b) {
+	case 4:
+		u := binary.BigEndian.Uint32(b)
+		return Semver{Major: u >> 24, Minor: u >> 16 & 0xFF, Patch: u & 0xFFFF}, nil
+	case 8:
+		u := binary.BigEndian.Uint64(b)
+		return Semver{
+			Major: uint32(u >> 48),
+			Minor: uint32(u >> 32 & 0xFFFF),
+			Patch: uint32(u >> 16 & 0xFFFF),
+			Build: uint32(u & 0xFFFF),
+		}, nil
+	default:
+		return Semver{}, fmt.Errorf("unsupported version width %d, must be 4 or 8 bytes", len(b))
+	}
+}
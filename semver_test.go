@@ -0,0 +1,81 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSemverBytes(t *testing.T) {
+	cases := []struct {
+		version string
+		width   int
+		hex     string
+	}{
+		{"1.2.3", 4, "01020003"},
+		{"255.255.65535", 4, "ffffffff"},
+		{"v2.10.300", 4, "020a012c"},
+		{"1.2.3+317", 8, "000100020003013d"},
+		{"0.0.1", 8, "0000000000010000"},
+	}
+	for _, c := range cases {
+		v, err := ParseSemver(c.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := SemverToBytes(v, c.width)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("SemverToBytes(%s, %d) = %s, expected %s", c.version, c.width, got, c.hex)
+		}
+		if got, err := SemverFromBytes(b); err != nil || got != v {
+			t.Errorf("SemverFromBytes(%s) = %v (%v), expected %v", c.hex, got, err, v)
+		}
+	}
+
+	// packed order follows version order
+	ordered := []string{"0.9.9", "1.0.0", "1.0.1", "1.2.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := ParseSemver(ordered[i-1])
+		b, _ := ParseSemver(ordered[i])
+		ab, _ := SemverToBytes(a, 4)
+		bb, _ := SemverToBytes(b, 4)
+		if bytes.Compare(ab, bb) >= 0 {
+			t.Errorf("expected %s < %s when packed", a, b)
+		}
+	}
+
+	if s := (Semver{1, 2, 3, 4}).String(); s != "1.2.3+4" {
+		t.Errorf("unexpected String() %q", s)
+	}
+}
+
+func TestSemverErrors(t *testing.T) {
+	for _, s := range []string{"", "1.2", "1.2.3.4", "1.x.3", "1.2.3+", "1.2.3-rc.1", "-1.2.3"} {
+		if _, err := ParseSemver(s); err == nil {
+			t.Errorf("ParseSemver(%q): expected error", s)
+		}
+	}
+
+	cases := []struct {
+		name  string
+		v     Semver
+		width int
+	}{
+		{"major overflow", Semver{Major: 256}, 4},
+		{"patch overflow", Semver{Patch: 65536}, 4},
+		{"build in 4 bytes", Semver{Major: 1, Build: 1}, 4},
+		{"build overflow", Semver{Build: 65536}, 8},
+		{"unsupported width", Semver{}, 2},
+	}
+	for _, c := range cases {
+		if _, err := SemverToBytes(c.v, c.width); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+	}
+	if _, err := SemverFromBytes([]byte{1, 2}); err == nil {
+		t.Error("expected error for 2-byte input")
+	}
+}
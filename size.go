@@ -0,0 +1,96 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// SizeOf returns exact length of Marshal(v) without encoding v, so callers can pre-allocate buffers
+// and write length prefixes before the payload. Returns the same errors as Marshal for unsupported
+// types; registered custom codecs have no size function, so their values are encoded to measure them.
+func SizeOf(v any) (int, error) {
+	t := reflect.TypeOf(v)
+	if c := lookupCustomCodec(t); c != nil {
+		b, err := c.encode(v)
+		return len(b), err
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		if c := lookupCustomCodec(t.Elem()); c != nil {
+			b, err := c.encode(rv.Elem().Interface())
+			return len(b), err
+		}
+	}
+
+	switch x := v.(type) {
+	case bool, int8, uint8:
+		return 1, nil
+	case int16, uint16:
+		return 2, nil
+	case int32, uint32:
+		return 4, nil
+	case int64, uint64, Bytes8:
+		return 8, nil
+	case Bytes16:
+		return 16, nil
+	case Bytes20, Address:
+		return 20, nil
+	case Bytes32:
+		return 32, nil
+	case Bytes64:
+		return 64, nil
+	case string:
+		if len(x) > 255 {
+			return 0, fmt.Errorf("string length exceeded, max 255 bytes allowed")
+		}
+		return 256, nil
+	case *big.Int:
+		if x == nil {
+			return 0, fmt.Errorf("nil *big.Int")
+		}
+		return 1 + (x.BitLen()+7)/8, nil
+	case []byte:
+		return len(x), nil
+	case []string:
+		if uint64(len(x)) > math.MaxUint32 {
+			return 0, fmt.Errorf("too many strings: %d", len(x))
+		}
+		size := 4
+		for i, s := range x {
+			if uint64(len(s)) > math.MaxUint32 {
+				return 0, fmt.Errorf("string %d too long: %d bytes", i, len(s))
+			}
+			size += 4 + len(s)
+		}
+		return size, nil
+	case map[string]string:
+		size := uvarintLen(uint64(len(x)))
+		for k, v := range x {
+			size += uvarintLen(uint64(len(k))) + len(k) + uvarintLen(uint64(len(v))) + len(v)
+		}
+		return size, nil
+	}
+
+	if isStructValue(v) {
+		rv := reflect.Indirect(reflect.ValueOf(v))
+		if !rv.IsValid() {
+			return 0, fmt.Errorf("nil %T", v)
+		}
+
+		s, err := SchemaOf(rv.Type())
+		if err != nil {
+			return 0, err
+		}
+		return s.size, nil
+	}
+
+	return 0, fmt.Errorf("unsupported type %T for Marshal", v)
+}
+
+func uvarintLen(x uint64) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(buf[:], x)
+}
@@ -0,0 +1,48 @@
+package bytecast
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSizeOf(t *testing.T) {
+	cases := []any{
+		true, int8(-1), uint16(2), int32(3), uint64(4), "hello",
+		big.NewInt(0), big.NewInt(-256), new(big.Int).Lsh(big.NewInt(1), 100),
+		[]byte{1, 2, 3}, []string{"a", "", "bcd"},
+		map[string]string{"k": "v", "long": string(make([]byte, 200))},
+		Bytes8{}, Bytes16{}, Bytes20{}, Bytes32{}, Bytes64{}, Address{},
+		dumpTestRecord{Version: 1},
+		&optionalTestRecord{ID: 1},
+	}
+	for _, v := range cases {
+		data, err := Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal(%T): %v", v, err)
+		}
+		size, err := SizeOf(v)
+		if err != nil {
+			t.Fatalf("SizeOf(%T): %v", v, err)
+		}
+		if size != len(data) {
+			t.Errorf("SizeOf(%T) = %d, expected %d", v, size, len(data))
+		}
+	}
+
+	invalid := []any{string(make([]byte, 256)), (*big.Int)(nil), (*dumpTestRecord)(nil), int(1), struct{ N int }{}}
+	for _, v := range invalid {
+		if _, err := SizeOf(v); err == nil {
+			t.Errorf("SizeOf(%T): expected error", v)
+		}
+	}
+}
+
+func TestSchemaSize(t *testing.T) {
+	s, err := SchemaOf(dumpTestRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Size() != 24 {
+		t.Errorf("expected 24, got %d", s.Size())
+	}
+}
@@ -0,0 +1,95 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unsafe"
+)
+
+// Integer is a constraint for fixed-size integer types supported by bulk slice conversions.
+type Integer interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// SliceToBytes
+//
+//	Converts whole slice of integers to bytes in one pass, every element takes its natural size
+//	(e.g. 4 bytes for int32) in chosen byte order (binary.BigEndian / binary.LittleEndian).
+func SliceToBytes[T Integer](values []T, order binary.ByteOrder) []byte {
+	var zero T
+	size := int(unsafe.Sizeof(zero))
+
+	out := make([]byte, len(values)*size)
+
+	switch size {
+	case 1:
+		for i, v := range values {
+			out[i] = byte(v)
+		}
+	case 2:
+		for i, v := range values {
+			order.PutUint16(out[i*2:], uint16(v))
+		}
+	case 4:
+		for i, v := range values {
+			order.PutUint32(out[i*4:], uint32(v))
+		}
+	default:
+		for i, v := range values {
+			order.PutUint64(out[i*8:], uint64(v))
+		}
+	}
+
+	return out
+}
+
+// SliceFromBytes
+//
+//	Inverse of SliceToBytes. Input length must be a multiple of element size.
+func SliceFromBytes[T Integer](b []byte, order binary.ByteOrder) ([]T, error) {
+	var zero T
+	size := int(unsafe.Sizeof(zero))
+
+	if len(b)%size != 0 {
+		return nil, fmt.Errorf("input length %d is not a multiple of element size %d", len(b), size)
+	}
+
+	out := make([]T, len(b)/size)
+
+	switch size {
+	case 1:
+		for i := range out {
+			out[i] = T(b[i])
+		}
+	case 2:
+		for i := range out {
+			out[i] = T(order.Uint16(b[i*2:]))
+		}
+	case 4:
+		for i := range out {
+			out[i] = T(order.Uint32(b[i*4:]))
+		}
+	default:
+		for i := range out {
+			out[i] = T(order.Uint64(b[i*8:]))
+		}
+	}
+
+	return out, nil
+}
+
+func Uint32SliceToBytes(values []uint32, order binary.ByteOrder) []byte {
+	return SliceToBytes(values, order)
+}
+
+func Uint32SliceFromBytes(b []byte, order binary.ByteOrder) ([]uint32, error) {
+	return SliceFromBytes[uint32](b, order)
+}
+
+func Int64SliceToBytes(values []int64, order binary.ByteOrder) []byte {
+	return SliceToBytes(values, order)
+}
+
+func Int64SliceFromBytes(b []byte, order binary.ByteOrder) ([]int64, error) {
+	return SliceFromBytes[int64](b, order)
+}
@@ -0,0 +1,70 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSliceToBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"int8", SliceToBytes([]int8{-1, 2}, binary.BigEndian), "ff02"},
+		{"uint16 BE", SliceToBytes([]uint16{0x0102, 0xfffe}, binary.BigEndian), "0102fffe"},
+		{"int16 LE", SliceToBytes([]int16{-2, 0x0102}, binary.LittleEndian), "feff0201"},
+		{"uint32 BE", Uint32SliceToBytes([]uint32{1, 0xdeadbeef}, binary.BigEndian), "00000001deadbeef"},
+		{"uint32 LE", Uint32SliceToBytes([]uint32{1, 0xdeadbeef}, binary.LittleEndian), "01000000efbeadde"},
+		{"int64 BE", Int64SliceToBytes([]int64{-1, 1}, binary.BigEndian), "ffffffffffffffff0000000000000001"},
+		{"empty", Int64SliceToBytes(nil, binary.BigEndian), ""},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprintf("%x", tt.got); got != tt.want {
+			t.Errorf("%s: got %s; want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSliceRoundTrip(t *testing.T) {
+	int64s := []int64{math.MinInt64, -1, 0, 1, math.MaxInt64}
+	for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		got, err := Int64SliceFromBytes(Int64SliceToBytes(int64s, order), order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, int64s) {
+			t.Fatalf("expected %v got %v", int64s, got)
+		}
+	}
+
+	uint32s := []uint32{0, 1, math.MaxUint32}
+	got32, err := Uint32SliceFromBytes(Uint32SliceToBytes(uint32s, binary.LittleEndian), binary.LittleEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got32, uint32s) {
+		t.Fatalf("expected %v got %v", uint32s, got32)
+	}
+
+	// named types are supported too
+	type reading int16
+	readings := []reading{-300, 0, 300}
+	gotReadings, err := SliceFromBytes[reading](SliceToBytes(readings, binary.BigEndian), binary.BigEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotReadings, readings) {
+		t.Fatalf("expected %v got %v", readings, gotReadings)
+	}
+}
+
+func TestSliceFromBytesBadLength(t *testing.T) {
+	if _, err := Uint32SliceFromBytes([]byte{1, 2, 3, 4, 5}, binary.BigEndian); err == nil {
+		t.Fatal("expected error")
+	}
+}
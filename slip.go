@@ -0,0 +1,182 @@
+package bytecast
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SLIP (RFC 1055) special bytes
+const (
+	SLIPEnd    byte = 0xC0
+	SLIPEsc    byte = 0xDB
+	SLIPEscEnd byte = 0xDC
+	SLIPEscEsc byte = 0xDD
+)
+
+// SLIPEncode
+//
+//	Escapes END and ESC bytes of data and wraps result with END bytes:
+//
+//	C0 | escaped data | C0
+//
+//	Leading END is optional per RFC 1055, but it flushes any line noise accumulated by receiver.
+func SLIPEncode(data []byte) []byte {
+	out := make([]byte, 0, len(data)+2)
+	out = append(out, SLIPEnd)
+
+	for _, b := range data {
+		switch b {
+		case SLIPEnd:
+			out = append(out, SLIPEsc, SLIPEscEnd)
+		case SLIPEsc:
+			out = append(out, SLIPEsc, SLIPEscEsc)
+		default:
+			out = append(out, b)
+		}
+	}
+
+	return append(out, SLIPEnd)
+}
+
+// SLIPDecode
+//
+//	Unescapes single SLIP frame. Leading and trailing END bytes are optional and ignored,
+//	END in the middle of input, dangling ESC or ESC followed by anything except ESC_END / ESC_ESC are errors.
+func SLIPDecode(frame []byte) ([]byte, error) {
+	start, end := 0, len(frame)
+	if start < end && frame[start] == SLIPEnd {
+		start++
+	}
+	if start < end && frame[end-1] == SLIPEnd {
+		end--
+	}
+
+	out := make([]byte, 0, end-start)
+
+	for i := start; i < end; i++ {
+		switch frame[i] {
+		case SLIPEnd:
+			return nil, fmt.Errorf("unexpected SLIP END at offset %d", i)
+		case SLIPEsc:
+			i++
+			if i == end {
+				return nil, fmt.Errorf("dangling SLIP ESC at offset %d", i-1)
+			}
+
+			b, err := slipUnescape(frame[i])
+			if err != nil {
+				return nil, fmt.Errorf("%w at offset %d", err, i)
+			}
+			out = append(out, b)
+		default:
+			out = append(out, frame[i])
+		}
+	}
+
+	return out, nil
+}
+
+// SLIPReader yields complete unescaped frames from a stream of SLIP-encoded data.
+// Empty frames (e.g. back-to-back END bytes) are skipped.
+type SLIPReader struct {
+	src    io.Reader // for deadlines, see ReadFrameContext
+	r      *bufio.Reader
+	limits Limits
+}
+
+// SetLimits sets limits for subsequent frames. Frame longer than MaxFrameSize is discarded
+// up to the next END and ErrFrameTooLarge is returned.
+func (s *SLIPReader) SetLimits(l Limits) {
+	s.limits = l
+}
+
+func NewSLIPReader(r io.Reader) *SLIPReader {
+	return &SLIPReader{src: r, r: bufio.NewReader(r)}
+}
+
+// ReadFrameContext is ReadFrame which can be cancelled by ctx, see package-level ReadFrameContext.
+func (s *SLIPReader) ReadFrameContext(ctx context.Context) ([]byte, error) {
+	var frame []byte
+	err := withReadContext(ctx, s.src, func() (err error) {
+		frame, err = s.ReadFrame()
+		return err
+	})
+	return frame, err
+}
+
+// ReadFrame returns next complete frame.
+//
+// io.EOF is returned when stream ends between frames, io.ErrUnexpectedEOF - when it ends inside a frame.
+// On invalid escape sequence the rest of broken frame is discarded (up to next END) and error is returned,
+// so the next call continues with the following frame.
+func (s *SLIPReader) ReadFrame() ([]byte, error) {
+	var frame []byte
+	escaped := false
+
+	for {
+		b, err := s.r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) && (len(frame) > 0 || escaped) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		if escaped {
+			escaped = false
+
+			unescaped, err := slipUnescape(b)
+			if err != nil {
+				if b != SLIPEnd {
+					s.discardFrame()
+				}
+				return nil, err
+			}
+
+			frame = append(frame, unescaped)
+		} else {
+			switch b {
+			case SLIPEnd:
+				if len(frame) > 0 {
+					return frame, nil
+				}
+			case SLIPEsc:
+				escaped = true
+			default:
+				frame = append(frame, b)
+			}
+		}
+
+		if err := s.limits.checkFrameSize(len(frame)); err != nil {
+			s.discardFrame()
+			return nil, err
+		}
+	}
+}
+
+func (s *SLIPReader) discardFrame() {
+	discardUntil(s.r, SLIPEnd)
+}
+
+// discardUntil skips input up to and including delim without buffering it.
+func discardUntil(r *bufio.Reader, delim byte) {
+	for {
+		if _, err := r.ReadSlice(delim); err != bufio.ErrBufferFull {
+			return
+		}
+	}
+}
+
+func slipUnescape(b byte) (byte, error) {
+	switch b {
+	case SLIPEscEnd:
+		return SLIPEnd, nil
+	case SLIPEscEsc:
+		return SLIPEsc, nil
+	default:
+		return 0, fmt.Errorf("invalid SLIP escape sequence DB %02X", b)
+	}
+}
@@ -0,0 +1,93 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestSLIPEncode(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"", "c0c0"},
+		{"010203", "c0010203c0"},
+		{"c0", "c0dbdcc0"},
+		{"db", "c0dbddc0"},
+		{"01c0dbdc02", "c001dbdcdbdddc02c0"},
+	}
+
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.data)
+
+		encoded := SLIPEncode(data)
+		if got := fmt.Sprintf("%x", encoded); got != tt.want {
+			t.Errorf("SLIPEncode(%s) = %s; want %s", tt.data, got, tt.want)
+			continue
+		}
+
+		decoded, err := SLIPDecode(encoded)
+		if err != nil {
+			t.Errorf("SLIPDecode(%x) returned error: %v", encoded, err)
+			continue
+		}
+
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("SLIPDecode(%x) = %x; want %x", encoded, decoded, data)
+		}
+	}
+}
+
+func TestSLIPDecodeErrors(t *testing.T) {
+	cases := []string{
+		"01c002",   // END in the middle
+		"01db",     // dangling ESC
+		"01db0102", // invalid escape
+	}
+
+	for _, c := range cases {
+		b, _ := hex.DecodeString(c)
+		if _, err := SLIPDecode(b); err == nil {
+			t.Errorf("SLIPDecode(%s): expected error", c)
+		}
+	}
+}
+
+func TestSLIPReader(t *testing.T) {
+	var stream []byte
+	stream = append(stream, SLIPEncode([]byte{0x01, 0xc0})...)
+	stream = append(stream, SLIPEnd, SLIPEnd)                   // empty frames are skipped
+	stream = append(stream, 0x02, SLIPEsc, 0x00, 0x03, SLIPEnd) // broken frame
+	stream = append(stream, SLIPEncode([]byte{0xdb, 0x04})...)
+	stream = append(stream, 0x05) // truncated frame
+
+	r := NewSLIPReader(bytes.NewReader(stream))
+
+	frame, err := r.ReadFrame()
+	if err != nil || !bytes.Equal(frame, []byte{0x01, 0xc0}) {
+		t.Fatalf("expected frame 01c0, got %x (%v)", frame, err)
+	}
+
+	if _, err := r.ReadFrame(); err == nil {
+		t.Fatal("expected error for invalid escape")
+	}
+
+	frame, err = r.ReadFrame()
+	if err != nil || !bytes.Equal(frame, []byte{0xdb, 0x04}) {
+		t.Fatalf("expected frame db04, got %x (%v)", frame, err)
+	}
+
+	if _, err := r.ReadFrame(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	r = NewSLIPReader(bytes.NewReader(SLIPEncode([]byte{0x01})))
+	_, _ = r.ReadFrame()
+	if _, err := r.ReadFrame(); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
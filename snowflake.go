@@ -0,0 +1,131 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// TwitterSnowflakeEpoch is the custom epoch of Twitter Snowflake IDs (2010-11-04 01:42:54.657 UTC).
+var TwitterSnowflakeEpoch = time.UnixMilli(1288834974657)
+
+// SnowflakeOption configures NewSnowflakeCodec.
+type SnowflakeOption func(*SnowflakeCodec)
+
+// WithSnowflakeEpoch sets custom epoch timestamps are counted from, default is TwitterSnowflakeEpoch.
+func WithSnowflakeEpoch(epoch time.Time) SnowflakeOption {
+	return func(c *SnowflakeCodec) {
+		c.epoch = epoch
+	}
+}
+
+// WithSnowflakeTick sets timestamp resolution, default is one millisecond.
+func WithSnowflakeTick(tick time.Duration) SnowflakeOption {
+	return func(c *SnowflakeCodec) {
+		c.tick = tick
+	}
+}
+
+// SnowflakeParts are components of a Snowflake ID.
+type SnowflakeParts struct {
+	Time     time.Time
+	Node     uint64
+	Sequence uint64
+}
+
+// SnowflakeCodec packs Snowflake-style IDs:
+//
+//	[ 0 (unused high bits) | timestamp | node | sequence ]
+//
+// Twitter layout is 41 timestamp bits, 10 node bits and 12 sequence bits. Sum of bits must not exceed 63,
+// so IDs stay positive as int64.
+type SnowflakeCodec struct {
+	timestampBits int
+	nodeBits      int
+	sequenceBits  int
+	epoch         time.Time
+	tick          time.Duration
+}
+
+// NewSnowflakeCodec validates bit budget and returns codec.
+func NewSnowflakeCodec(timestampBits int, nodeBits int, sequenceBits int, opts ...SnowflakeOption) (*SnowflakeCodec, error) {
+	c := &SnowflakeCodec{
+		timestampBits: timestampBits,
+		nodeBits:      nodeBits,
+		sequenceBits:  sequenceBits,
+		epoch:         TwitterSnowflakeEpoch,
+		tick:          time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if timestampBits < 1 || nodeBits < 0 || sequenceBits < 0 {
+		return nil, fmt.Errorf("invalid snowflake layout %d/%d/%d, need timestamp bits >= 1 and non-negative node and sequence bits", timestampBits, nodeBits, sequenceBits)
+	}
+	if total := timestampBits + nodeBits + sequenceBits; total > 63 {
+		return nil, fmt.Errorf("snowflake layout %d/%d/%d uses %d bits, max is 63", timestampBits, nodeBits, sequenceBits, total)
+	}
+	if c.tick <= 0 {
+		return nil, fmt.Errorf("snowflake tick must be positive, got %v", c.tick)
+	}
+	// timestamps are converted through time.Duration, so full timestamp range must fit it
+	if uint64(1<<63-1)/uint64(c.tick) < 1<<timestampBits-1 {
+		return nil, fmt.Errorf("%d timestamp bits of %v exceed time.Duration range", timestampBits, c.tick)
+	}
+
+	return c, nil
+}
+
+// Pack combines components into ID. Time must be within timestampBits ticks after epoch,
+// node and sequence must fit their bit widths.
+func (c *SnowflakeCodec) Pack(t time.Time, node uint64, sequence uint64) (uint64, error) {
+	if t.Before(c.epoch) {
+		return 0, fmt.Errorf("time %v is before snowflake epoch %v", t, c.epoch)
+	}
+
+	ticks := uint64(t.Sub(c.epoch) / c.tick)
+	if t.Sub(c.epoch) == 1<<63-1 || ticks>>c.timestampBits != 0 {
+		return 0, fmt.Errorf("time %v does not fit %d timestamp bits", t, c.timestampBits)
+	}
+	if node>>c.nodeBits != 0 {
+		return 0, fmt.Errorf("node %d does not fit %d bits", node, c.nodeBits)
+	}
+	if sequence>>c.sequenceBits != 0 {
+		return 0, fmt.Errorf("sequence %d does not fit %d bits", sequence, c.sequenceBits)
+	}
+
+	return ticks<<(c.nodeBits+c.sequenceBits) | node<<c.sequenceBits | sequence, nil
+}
+
+// PackBytes is Pack returning 8-byte big-endian ID.
+func (c *SnowflakeCodec) PackBytes(t time.Time, node uint64, sequence uint64) ([8]byte, error) {
+	var out [8]byte
+
+	id, err := c.Pack(t, node, sequence)
+	if err != nil {
+		return out, err
+	}
+
+	binary.BigEndian.PutUint64(out[:], id)
+	return out, nil
+}
+
+// Unpack splits ID into components, returns error if bits above layout are set.
+func (c *SnowflakeCodec) Unpack(id uint64) (SnowflakeParts, error) {
+	total := c.timestampBits + c.nodeBits + c.sequenceBits
+	if id>>total != 0 {
+		return SnowflakeParts{}, fmt.Errorf("id %d has bits set above %d-bit snowflake layout", id, total)
+	}
+
+	return SnowflakeParts{
+		Time:     c.epoch.Add(time.Duration(id>>(c.nodeBits+c.sequenceBits)) * c.tick),
+		Node:     id >> c.sequenceBits & (1<<c.nodeBits - 1),
+		Sequence: id & (1<<c.sequenceBits - 1),
+	}, nil
+}
+
+// UnpackBytes is Unpack for 8-byte big-endian ID.
+func (c *SnowflakeCodec) UnpackBytes(b [8]byte) (SnowflakeParts, error) {
+	return c.Unpack(binary.BigEndian.Uint64(b[:]))
+}
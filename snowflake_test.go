@@ -0,0 +1,90 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestSnowflakeTwitter(t *testing.T) {
+	c, err := NewSnowflakeCodec(41, 10, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// tweet ID 1212092628029698048
+	parts, err := c.Unpack(1212092628029698048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := SnowflakeParts{Time: time.UnixMilli(1577820376771), Node: 327, Sequence: 0}
+	if !parts.Time.Equal(expected.Time) || parts.Node != expected.Node || parts.Sequence != expected.Sequence {
+		t.Errorf("got %+v, expected %+v", parts, expected)
+	}
+
+	id, err := c.Pack(parts.Time, parts.Node, parts.Sequence)
+	if err != nil || id != 1212092628029698048 {
+		t.Errorf("Pack = %d (%v), expected 1212092628029698048", id, err)
+	}
+}
+
+func TestSnowflakeCustomLayout(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c, err := NewSnowflakeCodec(32, 8, 8, WithSnowflakeEpoch(epoch), WithSnowflakeTick(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := c.PackBytes(epoch.Add(time.Hour+500*time.Millisecond), 0xAB, 0xCD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(b[:]); got != "000000000e10abcd" {
+		t.Errorf("unexpected bytes %s", got)
+	}
+
+	parts, err := c.UnpackBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parts.Time.Equal(epoch.Add(time.Hour)) || parts.Node != 0xAB || parts.Sequence != 0xCD {
+		t.Errorf("unexpected parts %+v", parts)
+	}
+}
+
+func TestSnowflakeErrors(t *testing.T) {
+	layouts := []struct {
+		name          string
+		ts, node, seq int
+		opts          []SnowflakeOption
+	}{
+		{"too many bits", 42, 10, 12, nil},
+		{"no timestamp", 0, 10, 12, nil},
+		{"negative node", 41, -1, 12, nil},
+		{"zero tick", 41, 10, 12, []SnowflakeOption{WithSnowflakeTick(0)}},
+		{"duration overflow", 63, 0, 0, []SnowflakeOption{WithSnowflakeTick(time.Second)}},
+	}
+	for _, l := range layouts {
+		if _, err := NewSnowflakeCodec(l.ts, l.node, l.seq, l.opts...); err == nil {
+			t.Errorf("%s: expected error", l.name)
+		}
+	}
+
+	c, _ := NewSnowflakeCodec(41, 10, 12)
+	now := time.UnixMilli(1700000000000)
+	if _, err := c.Pack(now, 1024, 0); err == nil {
+		t.Error("expected error for node overflow")
+	}
+	if _, err := c.Pack(now, 0, 4096); err == nil {
+		t.Error("expected error for sequence overflow")
+	}
+	if _, err := c.Pack(TwitterSnowflakeEpoch.Add(-time.Millisecond), 0, 0); err == nil {
+		t.Error("expected error for time before epoch")
+	}
+	if _, err := c.Pack(TwitterSnowflakeEpoch.Add(1<<41*time.Millisecond), 0, 0); err == nil {
+		t.Error("expected error for timestamp overflow")
+	}
+	if _, err := c.Unpack(1 << 63); err == nil {
+		t.Error("expected error for bits above layout")
+	}
+}
@@ -0,0 +1,119 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// FloatToSortableBytes
+//
+//	Encodes float64 so that lexicographic (bytes.Compare) order of the result matches numeric order,
+//	which allows floats to be used as keys in ordered KV stores and LSM trees.
+//	Positive values get the sign bit set, negative values get all bits inverted.
+//
+//	Resulting total order: -NaN < -Inf < ... < -0 < +0 < ... < +Inf < +NaN.
+//	-0 and +0 are encoded differently, use CanonicalFloat64 first if they must collapse into one key.
+func FloatToSortableBytes(value float64) [8]byte {
+	var out [8]byte
+	binary.BigEndian.PutUint64(out[:], sortableFloat64Bits(math.Float64bits(value)))
+	return out
+}
+
+// AppendSortableFloat appends FloatToSortableBytes encoding of value to dst, applying NaN/Inf policy
+// of opts (see FloatOption). On error dst is returned unchanged.
+func AppendSortableFloat(dst []byte, value float64, opts ...FloatOption) ([]byte, error) {
+	v, err := newFloatPolicy(opts).apply64(value)
+	if err != nil {
+		return dst, err
+	}
+	return binary.BigEndian.AppendUint64(dst, sortableFloat64Bits(math.Float64bits(v))), nil
+}
+
+// FloatFromSortableBytes
+//
+//	Decodes value written by FloatToSortableBytes, bit-exact including NaN payloads.
+func FloatFromSortableBytes(b [8]byte) float64 {
+	u := binary.BigEndian.Uint64(b[:])
+	if u&(1<<63) != 0 {
+		u ^= 1 << 63
+	} else {
+		u = ^u
+	}
+	return math.Float64frombits(u)
+}
+
+// Float32ToSortableBytes is the float32 variant of FloatToSortableBytes.
+func Float32ToSortableBytes(value float32) [4]byte {
+	var out [4]byte
+	binary.BigEndian.PutUint32(out[:], sortableFloat32Bits(math.Float32bits(value)))
+	return out
+}
+
+// AppendSortableFloat32 is the float32 variant of AppendSortableFloat.
+func AppendSortableFloat32(dst []byte, value float32, opts ...FloatOption) ([]byte, error) {
+	v, err := newFloatPolicy(opts).apply32(value)
+	if err != nil {
+		return dst, err
+	}
+	return binary.BigEndian.AppendUint32(dst, sortableFloat32Bits(math.Float32bits(v))), nil
+}
+
+// Float32FromSortableBytes decodes value written by Float32ToSortableBytes.
+func Float32FromSortableBytes(b [4]byte) float32 {
+	u := binary.BigEndian.Uint32(b[:])
+	if u&(1<<31) != 0 {
+		u ^= 1 << 31
+	} else {
+		u = ^u
+	}
+	return math.Float32frombits(u)
+}
+
+func sortableFloat64Bits(u uint64) uint64 {
+	if u&(1<<63) != 0 {
+		return ^u
+	}
+	return u | 1<<63
+}
+
+func sortableFloat32Bits(u uint32) uint32 {
+	if u&(1<<31) != 0 {
+		return ^u
+	}
+	return u | 1<<31
+}
+
+// IntToOrderedBytes
+//
+//	Encodes signed value as width-byte (1..8) big-endian two's complement with the sign bit flipped,
+//	so lexicographic order of keys matches numeric order (bbolt, Badger, Pebble keys):
+//	int16 -1 → 0x7F 0xFF, 0 → 0x80 0x00, 1 → 0x80 0x01.
+func IntToOrderedBytes(value int64, width int) ([]byte, error) {
+	if width < 1 || width > 8 {
+		return nil, fmt.Errorf("unsupported ordered int width %d, must be 1..8 bytes", width)
+	}
+
+	out, err := IntXXToBytesAndExpandWidth(value, 8*width, width)
+	if err != nil {
+		return nil, err
+	}
+	out[0] ^= 0x80
+
+	return out, nil
+}
+
+// IntFromOrderedBytes
+//
+//	Decodes value written by IntToOrderedBytes, width is len(b).
+func IntFromOrderedBytes(b []byte) (int64, error) {
+	if len(b) < 1 || len(b) > 8 {
+		return 0, fmt.Errorf("unsupported ordered int width %d, must be 1..8 bytes", len(b))
+	}
+
+	var buf [8]byte
+	copy(buf[:], b)
+	buf[0] ^= 0x80
+
+	return IntXXFromBytes(buf[:len(b)], 8*len(b))
+}
@@ -0,0 +1,110 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+func TestFloatToSortableBytes(t *testing.T) {
+	cases := []struct {
+		value float64
+		hex   string
+	}{
+		{0, "8000000000000000"},
+		{math.Copysign(0, -1), "7fffffffffffffff"},
+		{1, "bff0000000000000"},
+		{-1, "400fffffffffffff"},
+		{math.Inf(1), "fff0000000000000"},
+		{math.Inf(-1), "000fffffffffffff"},
+	}
+	for _, c := range cases {
+		b := FloatToSortableBytes(c.value)
+		if got := hex.EncodeToString(b[:]); got != c.hex {
+			t.Errorf("FloatToSortableBytes(%v) = %s, expected %s", c.value, got, c.hex)
+		}
+		if got := FloatFromSortableBytes(b); !Float64BitsEqual(got, c.value) {
+			t.Errorf("FloatFromSortableBytes(%s) = %v, expected %v", c.hex, got, c.value)
+		}
+	}
+
+	nan := math.Float64frombits(0x7ff8000000000123)
+	if got := FloatFromSortableBytes(FloatToSortableBytes(nan)); !Float64BitsEqual(got, nan) {
+		t.Errorf("NaN payload lost: %x", math.Float64bits(got))
+	}
+}
+
+func TestFloatSortableOrder(t *testing.T) {
+	ordered := []float64{
+		math.Inf(-1), -math.MaxFloat64, -1e10, -1, -math.SmallestNonzeroFloat64, math.Copysign(0, -1),
+		0, math.SmallestNonzeroFloat64, 0.5, 1, math.Nextafter(1, 2), 1e10, math.MaxFloat64, math.Inf(1), math.NaN(),
+	}
+	for i := 1; i < len(ordered); i++ {
+		a, b := FloatToSortableBytes(ordered[i-1]), FloatToSortableBytes(ordered[i])
+		if bytes.Compare(a[:], b[:]) >= 0 {
+			t.Errorf("expected key of %v < key of %v", ordered[i-1], ordered[i])
+		}
+	}
+
+	ordered32 := []float32{float32(math.Inf(-1)), -3.5, -1, 0, 1, 3.5, math.MaxFloat32, float32(math.Inf(1))}
+	for i, v := range ordered32 {
+		if got := Float32FromSortableBytes(Float32ToSortableBytes(v)); got != v {
+			t.Errorf("float32 round trip of %v gave %v", v, got)
+		}
+		if i > 0 {
+			a, b := Float32ToSortableBytes(ordered32[i-1]), Float32ToSortableBytes(v)
+			if bytes.Compare(a[:], b[:]) >= 0 {
+				t.Errorf("expected key of %v < key of %v", ordered32[i-1], v)
+			}
+		}
+	}
+}
+
+func TestIntToOrderedBytes(t *testing.T) {
+	cases := []struct {
+		value int64
+		width int
+		hex   string
+	}{
+		{0, 2, "8000"},
+		{-1, 2, "7fff"},
+		{1, 2, "8001"},
+		{math.MinInt16, 2, "0000"},
+		{math.MaxInt16, 2, "ffff"},
+		{-2, 1, "7e"},
+		{math.MinInt64, 8, "0000000000000000"},
+		{-1000, 3, "7ffc18"},
+	}
+	for _, c := range cases {
+		b, err := IntToOrderedBytes(c.value, c.width)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("IntToOrderedBytes(%d, %d) = %s, expected %s", c.value, c.width, got, c.hex)
+		}
+		if got, err := IntFromOrderedBytes(b); err != nil || got != c.value {
+			t.Errorf("IntFromOrderedBytes(%s) = %d (%v), expected %d", c.hex, got, err, c.value)
+		}
+	}
+
+	ordered := []int64{math.MinInt32, -70000, -256, -1, 0, 1, 255, 70000, math.MaxInt32}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := IntToOrderedBytes(ordered[i-1], 4)
+		b, _ := IntToOrderedBytes(ordered[i], 4)
+		if bytes.Compare(a, b) >= 0 {
+			t.Errorf("expected key of %d < key of %d", ordered[i-1], ordered[i])
+		}
+	}
+
+	if _, err := IntToOrderedBytes(128, 1); err == nil {
+		t.Error("expected error for value not fitting width")
+	}
+	if _, err := IntToOrderedBytes(0, 9); err == nil {
+		t.Error("expected error for width 9")
+	}
+	if _, err := IntFromOrderedBytes(nil); err == nil {
+		t.Error("expected error for empty input")
+	}
+}
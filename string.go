@@ -0,0 +1,324 @@
+package bytecast
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Charset selects how string runes are stored in StringToNBytes / StringFromNBytes fields.
+type Charset int
+
+const (
+	CharsetUTF8   Charset = iota // raw UTF-8 bytes of Go string (default)
+	CharsetLatin1                // ISO-8859-1
+	CharsetLatin2                // ISO-8859-2, Central European
+	CharsetLatin9                // ISO-8859-15, Latin-1 with € sign
+)
+
+type stringConfig struct {
+	charset     Charset
+	replace     bool
+	replacement byte
+	invalidUTF8 invalidUTF8Policy
+	truncate    TruncatePolicy
+	maxLength   int // -1 if not limited
+}
+
+// TruncatePolicy defines what StringToNBytes does with string longer than the field.
+type TruncatePolicy int
+
+const (
+	TruncateError TruncatePolicy = iota // return error (default)
+	TruncateBytes                       // cut at field capacity, may split multi-byte UTF-8 rune
+	TruncateRunes                       // cut at the last whole UTF-8 rune that fits
+)
+
+type invalidUTF8Policy int
+
+const (
+	utf8KeepInvalid invalidUTF8Policy = iota
+	utf8RejectInvalid
+	utf8ReplaceInvalid
+)
+
+// StringOption configures StringToNBytes / StringFromNBytes.
+type StringOption func(*stringConfig)
+
+// WithCharset stores string in single-byte charset instead of UTF-8.
+func WithCharset(cs Charset) StringOption {
+	return func(c *stringConfig) {
+		c.charset = cs
+	}
+}
+
+// WithReplacement replaces runes unmappable to selected charset with byte r (e.g. '?')
+// instead of returning error.
+func WithReplacement(r byte) StringOption {
+	return func(c *stringConfig) {
+		c.replace = true
+		c.replacement = r
+	}
+}
+
+// WithStrictUTF8 makes decoding of UTF-8 strings fail on invalid UTF-8 sequences,
+// so corrupted records are detected instead of propagated downstream.
+func WithStrictUTF8() StringOption {
+	return func(c *stringConfig) {
+		c.invalidUTF8 = utf8RejectInvalid
+	}
+}
+
+// WithUTF8Replacement makes decoding of UTF-8 strings replace every run of invalid bytes
+// with U+FFFD replacement character instead of failing.
+func WithUTF8Replacement() StringOption {
+	return func(c *stringConfig) {
+		c.invalidUTF8 = utf8ReplaceInvalid
+	}
+}
+
+// WithTruncation sets policy for strings exceeding field capacity, e.g. for logging pipelines
+// which prefer truncated value over dropped record.
+func WithTruncation(p TruncatePolicy) StringOption {
+	return func(c *stringConfig) {
+		c.truncate = p
+	}
+}
+
+// WithMaxLength makes StringFromNBytes reject fields declaring string longer than n bytes
+// with ErrLengthExceedsLimit, regardless of field size. Use it as the first line of defense
+// when decoding untrusted input into size-limited columns or buffers.
+func WithMaxLength(n int) StringOption {
+	return func(c *stringConfig) {
+		c.maxLength = max(n, 0)
+	}
+}
+
+func newStringConfig(opts []StringOption) stringConfig {
+	c := stringConfig{maxLength: -1}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// StringToNBytes is StringTo256Bytes generalized to field of n bytes (2..256):
+//
+//	[ length (1 byte) | zero padding | string bytes ], string is aligned to the end of field
+//
+// so StringToNBytes(s, 256) produces the same bytes as StringTo256Bytes(s).
+// Encoded string must fit in n-1 bytes.
+func StringToNBytes(s string, n int, opts ...StringOption) ([]byte, error) {
+	if n < 2 || n > 256 {
+		return nil, fmt.Errorf("unsupported string field size %d, must be 2..256", n)
+	}
+
+	c := newStringConfig(opts)
+
+	payload, err := c.encode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) > n-1 {
+		if payload, err = c.truncatePayload(payload, n-1); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]byte, n)
+	out[0] = uint8(len(payload))
+	copy(out[n-len(payload):], payload)
+
+	return out, nil
+}
+
+// StringFromNBytes decodes field written by StringToNBytes (or StringTo256Bytes),
+// field size is len(field). Declared length larger than field is an error, and so is declared length
+// larger than WithMaxLength.
+func StringFromNBytes(field []byte, opts ...StringOption) (string, error) {
+	if len(field) < 2 || len(field) > 256 {
+		return "", fmt.Errorf("unsupported string field size %d, must be 2..256", len(field))
+	}
+
+	c := newStringConfig(opts)
+
+	l := int(field[0])
+	if c.maxLength >= 0 && l > c.maxLength {
+		return "", fmt.Errorf("%w: declared string length %d, max %d", ErrLengthExceedsLimit, l, c.maxLength)
+	}
+	if l > len(field)-1 {
+		return "", fmt.Errorf("declared string length %d exceeds field capacity %d", l, len(field)-1)
+	}
+
+	return c.decode(field[len(field)-l:])
+}
+
+// StringFrom256BytesStrict is StringFrom256Bytes which also validates that string is well-formed UTF-8
+// and that declared length is consistent (see StringFromNBytes).
+func StringFrom256BytesStrict(byteVal [256]byte) (string, error) {
+	return StringFromNBytes(byteVal[:], WithStrictUTF8())
+}
+
+// truncatePayload shortens encoded string to limit bytes according to truncation policy.
+func (c *stringConfig) truncatePayload(payload []byte, limit int) ([]byte, error) {
+	switch c.truncate {
+	case TruncateBytes:
+		return payload[:limit], nil
+	case TruncateRunes:
+		if c.charset != CharsetUTF8 {
+			return payload[:limit], nil // single-byte charsets: every byte is a rune
+		}
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(payload[cut]) {
+			cut--
+		}
+		return payload[:cut], nil
+	case TruncateError:
+		return nil, fmt.Errorf("string length exceeded, max %d bytes allowed, got %d", limit, len(payload))
+	}
+	return nil, fmt.Errorf("unsupported truncation policy %d", c.truncate)
+}
+
+// encode converts string to bytes of configured charset.
+func (c *stringConfig) encode(s string) ([]byte, error) {
+	table, err := charsetTable(c.charset)
+	if err != nil {
+		return nil, err
+	}
+
+	if table == nil {
+		return []byte(s), nil
+	}
+
+	out := make([]byte, 0, len(s))
+	for i, r := range s {
+		b, ok := encodeSingleByte(table, r)
+		if !ok {
+			if !c.replace {
+				return nil, fmt.Errorf("rune %q at byte %d cannot be represented in charset %d", r, i, c.charset)
+			}
+			b = c.replacement
+		}
+		out = append(out, b)
+	}
+
+	return out, nil
+}
+
+// decode converts bytes of configured charset to string.
+func (c *stringConfig) decode(b []byte) (string, error) {
+	table, err := charsetTable(c.charset)
+	if err != nil {
+		return "", err
+	}
+
+	if table == nil {
+		switch {
+		case utf8.Valid(b) || c.invalidUTF8 == utf8KeepInvalid:
+			return string(b), nil
+		case c.invalidUTF8 == utf8ReplaceInvalid:
+			return strings.ToValidUTF8(string(b), string(utf8.RuneError)), nil
+		}
+		return "", fmt.Errorf("string is not valid UTF-8: %q", b)
+	}
+
+	out := make([]byte, 0, len(b))
+	for _, x := range b {
+		r := rune(x)
+		if x >= 0x80 {
+			r = table[x-0x80]
+		}
+		out = utf8.AppendRune(out, r)
+	}
+
+	return string(out), nil
+}
+
+// charsetTable returns runes of bytes 0x80..0xFF for single-byte charset (0x00..0x7F is ASCII),
+// nil for UTF-8.
+func charsetTable(cs Charset) (*[128]rune, error) {
+	switch cs {
+	case CharsetUTF8:
+		return nil, nil
+	case CharsetLatin1:
+		return &latin1Table, nil
+	case CharsetLatin2:
+		return &latin2Table, nil
+	case CharsetLatin9:
+		return &latin9Table, nil
+	}
+	return nil, fmt.Errorf("unsupported charset %d", cs)
+}
+
+func encodeSingleByte(table *[128]rune, r rune) (byte, bool) {
+	if r < 0x80 {
+		return byte(r), true
+	}
+
+	// tables are tiny, linear search is fine and avoids building reverse maps
+	for i, x := range table {
+		if x == r {
+			return byte(0x80 + i), true
+		}
+	}
+
+	return 0, false
+}
+
+var latin1Table = [128]rune{
+	0x0080, 0x0081, 0x0082, 0x0083, 0x0084, 0x0085, 0x0086, 0x0087,
+	0x0088, 0x0089, 0x008a, 0x008b, 0x008c, 0x008d, 0x008e, 0x008f,
+	0x0090, 0x0091, 0x0092, 0x0093, 0x0094, 0x0095, 0x0096, 0x0097,
+	0x0098, 0x0099, 0x009a, 0x009b, 0x009c, 0x009d, 0x009e, 0x009f,
+	0x00a0, 0x00a1, 0x00a2, 0x00a3, 0x00a4, 0x00a5, 0x00a6, 0x00a7,
+	0x00a8, 0x00a9, 0x00aa, 0x00ab, 0x00ac, 0x00ad, 0x00ae, 0x00af,
+	0x00b0, 0x00b1, 0x00b2, 0x00b3, 0x00b4, 0x00b5, 0x00b6, 0x00b7,
+	0x00b8, 0x00b9, 0x00ba, 0x00bb, 0x00bc, 0x00bd, 0x00be, 0x00bf,
+	0x00c0, 0x00c1, 0x00c2, 0x00c3, 0x00c4, 0x00c5, 0x00c6, 0x00c7,
+	0x00c8, 0x00c9, 0x00ca, 0x00cb, 0x00cc, 0x00cd, 0x00ce, 0x00cf,
+	0x00d0, 0x00d1, 0x00d2, 0x00d3, 0x00d4, 0x00d5, 0x00d6, 0x00d7,
+	0x00d8, 0x00d9, 0x00da, 0x00db, 0x00dc, 0x00dd, 0x00de, 0x00df,
+	0x00e0, 0x00e1, 0x00e2, 0x00e3, 0x00e4, 0x00e5, 0x00e6, 0x00e7,
+	0x00e8, 0x00e9, 0x00ea, 0x00eb, 0x00ec, 0x00ed, 0x00ee, 0x00ef,
+	0x00f0, 0x00f1, 0x00f2, 0x00f3, 0x00f4, 0x00f5, 0x00f6, 0x00f7,
+	0x00f8, 0x00f9, 0x00fa, 0x00fb, 0x00fc, 0x00fd, 0x00fe, 0x00ff,
+}
+
+var latin2Table = [128]rune{
+	0x0080, 0x0081, 0x0082, 0x0083, 0x0084, 0x0085, 0x0086, 0x0087,
+	0x0088, 0x0089, 0x008a, 0x008b, 0x008c, 0x008d, 0x008e, 0x008f,
+	0x0090, 0x0091, 0x0092, 0x0093, 0x0094, 0x0095, 0x0096, 0x0097,
+	0x0098, 0x0099, 0x009a, 0x009b, 0x009c, 0x009d, 0x009e, 0x009f,
+	0x00a0, 0x0104, 0x02d8, 0x0141, 0x00a4, 0x013d, 0x015a, 0x00a7,
+	0x00a8, 0x0160, 0x015e, 0x0164, 0x0179, 0x00ad, 0x017d, 0x017b,
+	0x00b0, 0x0105, 0x02db, 0x0142, 0x00b4, 0x013e, 0x015b, 0x02c7,
+	0x00b8, 0x0161, 0x015f, 0x0165, 0x017a, 0x02dd, 0x017e, 0x017c,
+	0x0154, 0x00c1, 0x00c2, 0x0102, 0x00c4, 0x0139, 0x0106, 0x00c7,
+	0x010c, 0x00c9, 0x0118, 0x00cb, 0x011a, 0x00cd, 0x00ce, 0x010e,
+	0x0110, 0x0143, 0x0147, 0x00d3, 0x00d4, 0x0150, 0x00d6, 0x00d7,
+	0x0158, 0x016e, 0x00da, 0x0170, 0x00dc, 0x00dd, 0x0162, 0x00df,
+	0x0155, 0x00e1, 0x00e2, 0x0103, 0x00e4, 0x013a, 0x0107, 0x00e7,
+	0x010d, 0x00e9, 0x0119, 0x00eb, 0x011b, 0x00ed, 0x00ee, 0x010f,
+	0x0111, 0x0144, 0x0148, 0x00f3, 0x00f4, 0x0151, 0x00f6, 0x00f7,
+	0x0159, 0x016f, 0x00fa, 0x0171, 0x00fc, 0x00fd, 0x0163, 0x02d9,
+}
+
+var latin9Table = [128]rune{
+	0x0080, 0x0081, 0x0082, 0x0083, 0x0084, 0x0085, 0x0086, 0x0087,
+	0x0088, 0x0089, 0x008a, 0x008b, 0x008c, 0x008d, 0x008e, 0x008f,
+	0x0090, 0x0091, 0x0092, 0x0093, 0x0094, 0x0095, 0x0096, 0x0097,
+	0x0098, 0x0099, 0x009a, 0x009b, 0x009c, 0x009d, 0x009e, 0x009f,
+	0x00a0, 0x00a1, 0x00a2, 0x00a3, 0x20ac, 0x00a5, 0x0160, 0x00a7,
+	0x0161, 0x00a9, 0x00aa, 0x00ab, 0x00ac, 0x00ad, 0x00ae, 0x00af,
+	0x00b0, 0x00b1, 0x00b2, 0x00b3, 0x017d, 0x00b5, 0x00b6, 0x00b7,
+	0x017e, 0x00b9, 0x00ba, 0x00bb, 0x0152, 0x0153, 0x0178, 0x00bf,
+	0x00c0, 0x00c1, 0x00c2, 0x00c3, 0x00c4, 0x00c5, 0x00c6, 0x00c7,
+	0x00c8, 0x00c9, 0x00ca, 0x00cb, 0x00cc, 0x00cd, 0x00ce, 0x00cf,
+	0x00d0, 0x00d1, 0x00d2, 0x00d3, 0x00d4, 0x00d5, 0x00d6, 0x00d7,
+	0x00d8, 0x00d9, 0x00da, 0x00db, 0x00dc, 0x00dd, 0x00de, 0x00df,
+	0x00e0, 0x00e1, 0x00e2, 0x00e3, 0x00e4, 0x00e5, 0x00e6, 0x00e7,
+	0x00e8, 0x00e9, 0x00ea, 0x00eb, 0x00ec, 0x00ed, 0x00ee, 0x00ef,
+	0x00f0, 0x00f1, 0x00f2, 0x00f3, 0x00f4, 0x00f5, 0x00f6, 0x00f7,
+	0x00f8, 0x00f9, 0x00fa, 0x00fb, 0x00fc, 0x00fd, 0x00fe, 0x00ff,
+}
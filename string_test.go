@@ -0,0 +1,196 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestStringToNBytesLayout(t *testing.T) {
+	out, err := StringToNBytes("abc", 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(out) != "0300000000616263" {
+		t.Fatalf("unexpected layout %x", out)
+	}
+
+	// 256-byte field is the same as StringTo256Bytes
+	full, err := StringToNBytes("hello", 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, _ := StringTo256Bytes("hello")
+	if !bytes.Equal(full, legacy[:]) {
+		t.Fatal("StringToNBytes(s, 256) must match StringTo256Bytes")
+	}
+
+	s, err := StringFromNBytes(out)
+	if err != nil || s != "abc" {
+		t.Fatalf("expected %q got %q (%v)", "abc", s, err)
+	}
+}
+
+func TestStringToNBytesErrors(t *testing.T) {
+	if _, err := StringToNBytes("abcd", 4); err == nil {
+		t.Fatal("expected error for string longer than n-1")
+	}
+	for _, n := range []int{0, 1, 257} {
+		if _, err := StringToNBytes("", n); err == nil {
+			t.Errorf("expected error for field size %d", n)
+		}
+	}
+
+	if _, err := StringFromNBytes([]byte{5, 'a', 'b'}); err == nil {
+		t.Fatal("expected error for declared length exceeding field")
+	}
+}
+
+func TestStringCharsets(t *testing.T) {
+	tests := []struct {
+		s       string
+		charset Charset
+		payload string
+	}{
+		{"café", CharsetLatin1, "636166e9"},
+		{"café €", CharsetLatin9, "636166e920a4"},
+		{"Zażółć", CharsetLatin2, "5a61bff3b3e6"},
+		{"café", CharsetUTF8, "636166c3a9"},
+	}
+
+	for _, tt := range tests {
+		out, err := StringToNBytes(tt.s, 16, WithCharset(tt.charset))
+		if err != nil {
+			t.Fatalf("StringToNBytes(%q, charset %d) returned error: %v", tt.s, tt.charset, err)
+		}
+
+		payload := out[16-int(out[0]):]
+		if hex.EncodeToString(payload) != tt.payload {
+			t.Fatalf("charset %d: expected payload %s got %x", tt.charset, tt.payload, payload)
+		}
+
+		back, err := StringFromNBytes(out, WithCharset(tt.charset))
+		if err != nil || back != tt.s {
+			t.Fatalf("charset %d: expected %q got %q (%v)", tt.charset, tt.s, back, err)
+		}
+	}
+}
+
+func TestStringCharsetUnmappable(t *testing.T) {
+	if _, err := StringToNBytes("5 €", 8, WithCharset(CharsetLatin1)); err == nil {
+		t.Fatal("expected error for € in Latin-1")
+	}
+
+	out, err := StringToNBytes("5 €", 8, WithCharset(CharsetLatin1), WithReplacement('?'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := StringFromNBytes(out, WithCharset(CharsetLatin1)); s != "5 ?" {
+		t.Fatalf("expected %q got %q", "5 ?", s)
+	}
+
+	if _, err := StringToNBytes("x", 8, WithCharset(Charset(42))); err == nil {
+		t.Fatal("expected error for unknown charset")
+	}
+}
+
+func TestStringFromNBytesInvalidUTF8(t *testing.T) {
+	field := []byte{3, 'a', 0xff, 'b'}
+
+	s, err := StringFromNBytes(field)
+	if err != nil || s != "a\xffb" {
+		t.Fatalf("default decode must keep bytes as is, got %q (%v)", s, err)
+	}
+
+	if _, err := StringFromNBytes(field, WithStrictUTF8()); err == nil {
+		t.Fatal("expected error for invalid UTF-8 in strict mode")
+	}
+
+	s, err = StringFromNBytes(field, WithUTF8Replacement())
+	if err != nil || s != "a�b" {
+		t.Fatalf("expected replacement character, got %q (%v)", s, err)
+	}
+
+	valid, _ := StringTo256Bytes("привіт")
+	if s, err := StringFrom256BytesStrict(valid); err != nil || s != "привіт" {
+		t.Fatalf("expected %q got %q (%v)", "привіт", s, err)
+	}
+
+	var broken [256]byte
+	broken[0] = 2
+	broken[254], broken[255] = 0xd0, 'x'
+	if _, err := StringFrom256BytesStrict(broken); err == nil {
+		t.Fatal("expected error for truncated UTF-8 sequence")
+	}
+}
+
+func TestStringTruncation(t *testing.T) {
+	// "héllo" is 6 bytes in UTF-8, é takes bytes 1..2
+	tests := []struct {
+		policy TruncatePolicy
+		n      int
+		want   string
+	}{
+		{TruncateBytes, 5, "h\xc3\xa9l"},
+		{TruncateBytes, 3, "h\xc3"},
+		{TruncateRunes, 3, "h"},
+		{TruncateRunes, 4, "hé"},
+		{TruncateRunes, 7, "héllo"},
+	}
+
+	for _, tt := range tests {
+		out, err := StringToNBytes("héllo", tt.n, WithTruncation(tt.policy))
+		if err != nil {
+			t.Fatalf("policy %d, n %d: unexpected error %v", tt.policy, tt.n, err)
+		}
+
+		s, _ := StringFromNBytes(out)
+		if s != tt.want {
+			t.Errorf("policy %d, n %d: expected %q got %q", tt.policy, tt.n, tt.want, s)
+		}
+	}
+
+	if _, err := StringToNBytes("héllo", 4); err == nil {
+		t.Fatal("expected error with default policy")
+	}
+
+	out, err := StringToNBytes("café", 4, WithCharset(CharsetLatin1), WithTruncation(TruncateRunes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := StringFromNBytes(out, WithCharset(CharsetLatin1)); s != "caf" {
+		t.Fatalf("expected %q got %q", "caf", s)
+	}
+
+	if _, err := StringToNBytes("long", 2, WithTruncation(TruncatePolicy(7))); err == nil {
+		t.Fatal("expected error for unknown policy")
+	}
+}
+
+func TestStringFromNBytesMaxLength(t *testing.T) {
+	field, err := StringToNBytes("hello world", 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := StringFromNBytes(field, WithMaxLength(11)); err != nil || s != "hello world" {
+		t.Fatalf("expected string within limit, got %q (%v)", s, err)
+	}
+
+	_, err = StringFromNBytes(field, WithMaxLength(5))
+	if !errors.Is(err, ErrLengthExceedsLimit) || !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected ErrLengthExceedsLimit, got %v", err)
+	}
+
+	// limit is checked before field capacity
+	corrupt := []byte{200, 'a', 'b'}
+	if _, err := StringFromNBytes(corrupt, WithMaxLength(2)); !errors.Is(err, ErrLengthExceedsLimit) {
+		t.Fatalf("expected ErrLengthExceedsLimit for hostile length, got %v", err)
+	}
+
+	empty, _ := StringToNBytes("", 4)
+	if s, err := StringFromNBytes(empty, WithMaxLength(0)); err != nil || s != "" {
+		t.Fatalf("expected empty string with zero limit, got %q (%v)", s, err)
+	}
+}
@@ -0,0 +1,91 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// StringsToBytes encodes list of strings (tags, labels) as count followed by length-prefixed entries:
+//
+//	[ count (uint32) | len0 (uint32) | bytes0 | len1 (uint32) | bytes1 | ... ]
+//
+// All integers are big-endian. Decode with StringsFromBytes.
+func StringsToBytes(values []string) ([]byte, error) {
+	return AppendStrings(nil, values)
+}
+
+// AppendStrings appends encoding of values (see StringsToBytes) to dst. On error dst is returned unchanged.
+func AppendStrings(dst []byte, values []string) ([]byte, error) {
+	if uint64(len(values)) > math.MaxUint32 {
+		return dst, fmt.Errorf("too many strings: %d", len(values))
+	}
+
+	size := 4
+	for i, s := range values {
+		if uint64(len(s)) > math.MaxUint32 {
+			return dst, fmt.Errorf("string %d too long: %d bytes", i, len(s))
+		}
+		size += 4 + len(s)
+	}
+
+	out := slices.Grow(dst, size)
+	out = binary.BigEndian.AppendUint32(out, uint32(len(values)))
+	for _, s := range values {
+		out = binary.BigEndian.AppendUint32(out, uint32(len(s)))
+		out = append(out, s...)
+	}
+
+	return out, nil
+}
+
+// StringsFromBytes decodes list written by StringsToBytes, data must contain exactly one list.
+// Declared count and lengths are validated against data size before allocating,
+// and against Limits given WithLimits.
+func StringsFromBytes(data []byte, opts ...DecodeOption) ([]string, error) {
+	return stringsFromBytes(data, &newDecodeConfig(opts).limits)
+}
+
+func stringsFromBytes(data []byte, limits *Limits) ([]string, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("expected at least 4 bytes for string list count, but got %d bytes", len(data))
+	}
+
+	count := binary.BigEndian.Uint32(data)
+	rest := data[4:]
+
+	// every entry takes at least 4 bytes of length prefix
+	if uint64(count) > uint64(len(rest)/4) {
+		return nil, fmt.Errorf("declared %d strings, but only %d bytes left", count, len(rest))
+	}
+	if err := limits.checkElements(uint64(count)); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, count)
+	for i := range values {
+		if len(rest) < 4 {
+			return nil, fmt.Errorf("string %d: missing length prefix", i)
+		}
+
+		l := binary.BigEndian.Uint32(rest)
+		rest = rest[4:]
+
+		if uint64(l) > uint64(len(rest)) {
+			return nil, fmt.Errorf("string %d: declared length %d, but only %d bytes left", i, l, len(rest))
+		}
+		if err := limits.checkStringLength(uint64(l)); err != nil {
+			return nil, fmt.Errorf("string %d: %w", i, err)
+		}
+
+		values[i] = string(rest[:l])
+		rest = rest[l:]
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d unexpected trailing bytes after string list", len(rest))
+	}
+
+	return values, nil
+}
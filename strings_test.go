@@ -0,0 +1,52 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func TestStringsToBytes(t *testing.T) {
+	out, err := StringsToBytes([]string{"ab", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(out); got != "00000002"+"00000002"+"6162"+"00000000" {
+		t.Fatalf("unexpected layout %s", got)
+	}
+
+	for _, values := range [][]string{nil, {"one"}, {"env=prod", "", "region=eu", "ключ"}} {
+		out, err := StringsToBytes(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		back, err := StringsFromBytes(out)
+		if err != nil {
+			t.Fatalf("StringsFromBytes(%x) returned error: %v", out, err)
+		}
+		if len(values) == 0 && len(back) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(back, values) {
+			t.Fatalf("expected %q got %q", values, back)
+		}
+	}
+}
+
+func TestStringsFromBytesMalformed(t *testing.T) {
+	cases := map[string]string{
+		"short count":      "0000",
+		"hostile count":    "ffffffff00000000",
+		"length too large": "00000001" + "00000005" + "6162",
+		"missing entry":    "00000002" + "00000000" + "00",
+		"trailing bytes":   "00000001" + "00000001" + "61" + "00",
+	}
+
+	for name, h := range cases {
+		data, _ := hex.DecodeString(h)
+		if _, err := StringsFromBytes(data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// SwapEndian16
+//
+//	Reverses byte order of every 16-bit word of b in place, e.g. to convert large little-endian
+//	datasets to big-endian conventions of this package. len(b) must be a multiple of 2.
+//
+//	Works word-at-a-time: 4 values are swapped per 64-bit load/store.
+func SwapEndian16(b []byte) error {
+	if len(b)%2 != 0 {
+		return fmt.Errorf("input length %d is not a multiple of 2", len(b))
+	}
+
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		x := binary.LittleEndian.Uint64(b[i:])
+		x = (x&0x00FF00FF00FF00FF)<<8 | (x>>8)&0x00FF00FF00FF00FF
+		binary.LittleEndian.PutUint64(b[i:], x)
+	}
+
+	for ; i < len(b); i += 2 {
+		b[i], b[i+1] = b[i+1], b[i]
+	}
+
+	return nil
+}
+
+// SwapEndian32
+//
+//	Reverses byte order of every 32-bit word of b in place. len(b) must be a multiple of 4.
+//
+//	Works word-at-a-time: 2 values are swapped per 64-bit load/store.
+func SwapEndian32(b []byte) error {
+	if len(b)%4 != 0 {
+		return fmt.Errorf("input length %d is not a multiple of 4", len(b))
+	}
+
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		// [a b c d e f g h] → reverse → [h g f e d c b a] → rotate → [d c b a h g f e]
+		x := binary.LittleEndian.Uint64(b[i:])
+		binary.LittleEndian.PutUint64(b[i:], bits.RotateLeft64(bits.ReverseBytes64(x), 32))
+	}
+
+	if i < len(b) {
+		binary.LittleEndian.PutUint32(b[i:], bits.ReverseBytes32(binary.LittleEndian.Uint32(b[i:])))
+	}
+
+	return nil
+}
+
+// SwapEndian64
+//
+//	Reverses byte order of every 64-bit word of b in place. len(b) must be a multiple of 8.
+func SwapEndian64(b []byte) error {
+	if len(b)%8 != 0 {
+		return fmt.Errorf("input length %d is not a multiple of 8", len(b))
+	}
+
+	for i := 0; i < len(b); i += 8 {
+		binary.LittleEndian.PutUint64(b[i:], bits.ReverseBytes64(binary.LittleEndian.Uint64(b[i:])))
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+// swapNaive is a reference implementation for swap tests.
+func swapNaive(b []byte, size int) []byte {
+	out := make([]byte, len(b))
+	for i := 0; i < len(b); i += size {
+		for j := 0; j < size; j++ {
+			out[i+j] = b[i+size-1-j]
+		}
+	}
+	return out
+}
+
+func TestSwapEndian(t *testing.T) {
+	tests := []struct {
+		name string
+		swap func([]byte) error
+		in   string
+		want string
+	}{
+		{"16", SwapEndian16, "0102", "0201"},
+		{"16 words", SwapEndian16, "0102030405060708090a", "02010403060508070a09"},
+		{"32", SwapEndian32, "01020304", "04030201"},
+		{"32 words", SwapEndian32, "0102030405060708090a0b0c", "04030201080706050c0b0a09"},
+		{"64", SwapEndian64, "0102030405060708", "0807060504030201"},
+		{"empty", SwapEndian64, "", ""},
+	}
+
+	for _, tt := range tests {
+		b, _ := hex.DecodeString(tt.in)
+		if err := tt.swap(b); err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+
+		if got := fmt.Sprintf("%x", b); got != tt.want {
+			t.Errorf("%s: got %s; want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSwapEndianMatchesNaive(t *testing.T) {
+	data := make([]byte, 8*37)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+
+	for _, tc := range []struct {
+		size int
+		swap func([]byte) error
+	}{
+		{2, SwapEndian16}, {4, SwapEndian32}, {8, SwapEndian64},
+	} {
+		// every length multiple of element size, so both word-at-a-time part and tail are covered
+		for n := 0; n <= len(data); n += tc.size {
+			b := append([]byte(nil), data[:n]...)
+			if err := tc.swap(b); err != nil {
+				t.Fatal(err)
+			}
+			if want := swapNaive(data[:n], tc.size); !bytes.Equal(b, want) {
+				t.Fatalf("SwapEndian%d of %d bytes: got %x; want %x", tc.size*8, n, b, want)
+			}
+		}
+	}
+}
+
+func TestSwapEndianBadLength(t *testing.T) {
+	if err := SwapEndian16(make([]byte, 3)); err == nil {
+		t.Error("SwapEndian16: expected error")
+	}
+	if err := SwapEndian32(make([]byte, 6)); err == nil {
+		t.Error("SwapEndian32: expected error")
+	}
+	if err := SwapEndian64(make([]byte, 12)); err == nil {
+		t.Error("SwapEndian64: expected error")
+	}
+}
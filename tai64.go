@@ -0,0 +1,91 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// tai64Base is TAI64 label of 1970-01-01 00:00:00 TAI: 2^62, plus 10 seconds of TAI-UTC difference
+// in 1970, following the convention of libtai, daemontools and s6 (see TimeToTAI64Bytes).
+const tai64Base = 1<<62 + 10
+
+// TimeToTAI64Bytes
+//
+//	Encodes t as 8-byte TAI64 label: 2^62 + TAI seconds since 1970-01-01 00:00:00 TAI, big-endian.
+//
+//	Leap seconds: like daemontools and most logging tools, UTC is converted to TAI with constant
+//	offset of 10 seconds (TAI-UTC in 1970), i.e. leap seconds inserted since 1972 are NOT added.
+//	Labels are therefore exactly Unix time shifted by a constant, conversion is reversible, and labels
+//	written by those tools decode to the same time.Time they were produced from.
+//	Sub-second part of t is dropped, use TimeToTAI64NBytes to keep it.
+func TimeToTAI64Bytes(t time.Time) [8]byte {
+	var out [8]byte
+	binary.BigEndian.PutUint64(out[:], uint64(t.Unix()+tai64Base))
+	return out
+}
+
+// TimeFromTAI64Bytes
+//
+//	Decodes TAI64 label written by TimeToTAI64Bytes (see it for leap second handling) into UTC time.
+//	Labels with the top bit set are reserved for future extensions and rejected.
+func TimeFromTAI64Bytes(b [8]byte) (time.Time, error) {
+	label := binary.BigEndian.Uint64(b[:])
+	if label>>63 != 0 {
+		return time.Time{}, fmt.Errorf("reserved TAI64 label %016x", label)
+	}
+	return time.Unix(int64(label)-tai64Base, 0).UTC(), nil
+}
+
+// TimeToTAI64NBytes
+//
+//	Encodes t as 12-byte TAI64N label: TAI64 label (see TimeToTAI64Bytes) followed by
+//	nanoseconds as 4-byte big-endian integer (0..999999999).
+func TimeToTAI64NBytes(t time.Time) [12]byte {
+	var out [12]byte
+	label := TimeToTAI64Bytes(t)
+	copy(out[:], label[:])
+	binary.BigEndian.PutUint32(out[8:], uint32(t.Nanosecond()))
+	return out
+}
+
+// TimeFromTAI64NBytes
+//
+//	Decodes TAI64N label written by TimeToTAI64NBytes into UTC time.
+func TimeFromTAI64NBytes(b [12]byte) (time.Time, error) {
+	t, err := TimeFromTAI64Bytes([8]byte(b[:8]))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	nsec := binary.BigEndian.Uint32(b[8:])
+	if nsec >= 1e9 {
+		return time.Time{}, fmt.Errorf("invalid TAI64N nanoseconds %d", nsec)
+	}
+
+	return t.Add(time.Duration(nsec)), nil
+}
+
+// FormatTAI64N formats t as external TAI64N label, as printed by multilog and s6-log: '@' followed
+// by 24 lowercase hex digits, e.g. "@4000000037c219bf2ef02e94".
+func FormatTAI64N(t time.Time) string {
+	label := TimeToTAI64NBytes(t)
+	return "@" + hex.EncodeToString(label[:])
+}
+
+// ParseTAI64N parses external TAI64N label (see FormatTAI64N), the leading '@' is optional.
+func ParseTAI64N(s string) (time.Time, error) {
+	digits := strings.TrimPrefix(s, "@")
+	if len(digits) != 24 {
+		return time.Time{}, fmt.Errorf("invalid TAI64N label %q: expected 24 hex digits", s)
+	}
+
+	var label [12]byte
+	if _, err := hex.Decode(label[:], []byte(digits)); err != nil {
+		return time.Time{}, fmt.Errorf("invalid TAI64N label %q: %w", s, err)
+	}
+
+	return TimeFromTAI64NBytes(label)
+}
@@ -0,0 +1,46 @@
+package bytecast
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTAI64(t *testing.T) {
+	cases := []struct {
+		t     time.Time
+		label string
+	}{
+		{time.Unix(0, 0), "@400000000000000a00000000"},
+		{time.Date(1999, 8, 23, 21, 3, 43, 787689000, time.UTC), "@4000000037c1b7392ef32e28"},
+		{time.Unix(-20, 5), "@3ffffffffffffff600000005"},
+	}
+	for _, c := range cases {
+		if got := FormatTAI64N(c.t); got != c.label {
+			t.Errorf("FormatTAI64N(%v) = %s, expected %s", c.t, got, c.label)
+		}
+
+		parsed, err := ParseTAI64N(c.label)
+		if err != nil || !parsed.Equal(c.t) || parsed.Location() != time.UTC {
+			t.Errorf("ParseTAI64N(%s) = %v (%v), expected %v", c.label, parsed, err, c.t)
+		}
+
+		label := TimeToTAI64Bytes(c.t)
+		seconds, err := TimeFromTAI64Bytes(label)
+		if err != nil || seconds.Unix() != c.t.Unix() || seconds.Nanosecond() != 0 {
+			t.Errorf("TAI64 round trip of %v: got %v (%v)", c.t, seconds, err)
+		}
+	}
+}
+
+func TestTAI64Errors(t *testing.T) {
+	if _, err := TimeFromTAI64Bytes([8]byte{0x80}); err == nil {
+		t.Error("expected error for reserved label")
+	}
+
+	invalid := []string{"@400000000000000a", "@400000000000000a3b9aca00", "@400000000000000a0000000g", "400000000000000a0000000000"}
+	for _, s := range invalid {
+		if _, err := ParseTAI64N(s); err == nil {
+			t.Errorf("ParseTAI64N(%q): expected error", s)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package bytecast
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// TextAlign defines where text is placed inside fixed-width field.
+type TextAlign int
+
+const (
+	AlignLeft  TextAlign = iota // "ABC   " - text first, padding after (COBOL PIC X)
+	AlignRight                  // "   ABC" - padding first, text after
+)
+
+// TrimPolicy defines which padding is removed by TextFieldFromBytes.
+type TrimPolicy int
+
+const (
+	TrimNone  TrimPolicy = iota // keep field as is
+	TrimRight                   // remove trailing pad bytes
+	TrimLeft                    // remove leading pad bytes
+	TrimBoth                    // remove leading and trailing pad bytes
+)
+
+// TextFieldToBytes writes s into field of exactly width bytes filled with pad byte (usually ' ').
+// Width is counted in bytes, not runes. Text longer than width is an error, it is never truncated.
+func TextFieldToBytes(s string, width int, pad byte, align TextAlign) ([]byte, error) {
+	if len(s) > width {
+		return nil, fmt.Errorf("text of %d bytes does not fit in %d-byte field", len(s), width)
+	}
+
+	out := bytes.Repeat([]byte{pad}, width)
+
+	switch align {
+	case AlignLeft:
+		copy(out, s)
+	case AlignRight:
+		copy(out[width-len(s):], s)
+	default:
+		return nil, fmt.Errorf("unsupported text alignment %d", align)
+	}
+
+	return out, nil
+}
+
+// TextFieldFromBytes returns text of fixed-width field with pad bytes removed according to trim policy.
+// Pad is compared byte by byte, so any pad byte works, e.g. 0x40 (EBCDIC space) or 0xFF.
+func TextFieldFromBytes(field []byte, pad byte, trim TrimPolicy) string {
+	if trim == TrimLeft || trim == TrimBoth {
+		for len(field) > 0 && field[0] == pad {
+			field = field[1:]
+		}
+	}
+
+	if trim == TrimRight || trim == TrimBoth {
+		for len(field) > 0 && field[len(field)-1] == pad {
+			field = field[:len(field)-1]
+		}
+	}
+
+	return string(field)
+}
@@ -0,0 +1,64 @@
+package bytecast
+
+import (
+	"testing"
+)
+
+func TestTextFieldToBytes(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		pad   byte
+		align TextAlign
+		want  string
+	}{
+		{"ABC", 6, ' ', AlignLeft, "ABC   "},
+		{"ABC", 6, ' ', AlignRight, "   ABC"},
+		{"42", 5, '0', AlignRight, "00042"},
+		{"", 3, ' ', AlignLeft, "   "},
+		{"FULL", 4, ' ', AlignLeft, "FULL"},
+	}
+
+	for _, tt := range tests {
+		got, err := TextFieldToBytes(tt.s, tt.width, tt.pad, tt.align)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("TextFieldToBytes(%q, %d) = %q (%v); want %q", tt.s, tt.width, got, err, tt.want)
+		}
+	}
+
+	if _, err := TextFieldToBytes("TOO LONG", 3, ' ', AlignLeft); err == nil {
+		t.Fatal("expected error for text longer than field")
+	}
+	if _, err := TextFieldToBytes("A", 3, ' ', TextAlign(9)); err == nil {
+		t.Fatal("expected error for unsupported alignment")
+	}
+}
+
+func TestTextFieldFromBytes(t *testing.T) {
+	field := []byte("  A B  ")
+
+	tests := []struct {
+		trim TrimPolicy
+		want string
+	}{
+		{TrimNone, "  A B  "},
+		{TrimRight, "  A B"},
+		{TrimLeft, "A B  "},
+		{TrimBoth, "A B"},
+	}
+
+	for _, tt := range tests {
+		if got := TextFieldFromBytes(field, ' ', tt.trim); got != tt.want {
+			t.Errorf("trim %d: expected %q got %q", tt.trim, tt.want, got)
+		}
+	}
+
+	// non-ASCII pad byte
+	if got := TextFieldFromBytes([]byte{'X', 0xFF, 0xFF}, 0xFF, TrimRight); got != "X" {
+		t.Fatalf("expected %q got %q", "X", got)
+	}
+
+	if got := TextFieldFromBytes([]byte("   "), ' ', TrimBoth); got != "" {
+		t.Fatalf("expected empty string, got %q", got)
+	}
+}
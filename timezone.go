@@ -0,0 +1,78 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// TimeWithZoneToBytes
+//
+//	Encodes time instant together with its zone, so audit records keep local wall-clock time:
+//
+//	[ unix seconds (int64) | nanoseconds (uint32) | zone offset seconds (int32) | name length (1 byte) | IANA name ]
+//
+//	Name is stored for locations loaded from IANA database (e.g. "Europe/Kyiv"), so decoder can restore
+//	the location with its DST rules. time.Local and fixed zones (time.FixedZone) are stored by offset
+//	only (empty name), since their names do not identify a location on another machine. UTC is stored
+//	as "UTC". Monotonic clock reading is not encoded.
+func TimeWithZoneToBytes(t time.Time) ([]byte, error) {
+	name := ""
+	if loc := t.Location(); loc == time.UTC {
+		name = "UTC"
+	} else if loc != time.Local {
+		// fixed zones created by time.FixedZone can not be loaded by name, skip them
+		if _, err := time.LoadLocation(loc.String()); err == nil {
+			name = loc.String()
+		}
+	}
+
+	if len(name) > 255 {
+		return nil, fmt.Errorf("zone name %q is too long, max 255 bytes allowed", name)
+	}
+
+	_, offset := t.Zone()
+
+	out := make([]byte, 0, 17+len(name))
+	out = binary.BigEndian.AppendUint64(out, uint64(t.Unix()))
+	out = binary.BigEndian.AppendUint32(out, uint32(t.Nanosecond()))
+	out = binary.BigEndian.AppendUint32(out, uint32(int32(offset)))
+	out = append(out, byte(len(name)))
+	return append(out, name...), nil
+}
+
+// TimeWithZoneFromBytes
+//
+//	Decodes time written by TimeWithZoneToBytes. Named zone is loaded with time.LoadLocation; if it
+//	is not available on this machine, or its offset at the decoded instant differs from the stored one
+//	(e.g. zone rules changed since encoding), the result uses fixed zone with the stored offset,
+//	so wall-clock time of the record is always preserved.
+func TimeWithZoneFromBytes(b []byte) (time.Time, error) {
+	if len(b) < 17 {
+		return time.Time{}, fmt.Errorf("expected at least 17 bytes, but got %d bytes", len(b))
+	}
+
+	sec := int64(binary.BigEndian.Uint64(b))
+	nsec := binary.BigEndian.Uint32(b[8:])
+	offset := int(int32(binary.BigEndian.Uint32(b[12:])))
+	name := b[17:]
+
+	if nsec >= 1e9 {
+		return time.Time{}, fmt.Errorf("invalid nanoseconds %d", nsec)
+	}
+	if len(name) != int(b[16]) {
+		return time.Time{}, fmt.Errorf("declared zone name length %d, but got %d bytes", b[16], len(name))
+	}
+
+	t := time.Unix(sec, int64(nsec))
+
+	if len(name) > 0 {
+		if loc, err := time.LoadLocation(string(name)); err == nil {
+			if _, o := t.In(loc).Zone(); o == offset {
+				return t.In(loc), nil
+			}
+		}
+	}
+
+	return t.In(time.FixedZone("", offset)), nil
+}
@@ -0,0 +1,75 @@
+package bytecast
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeWithZoneRoundTrip(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skipf("tz database is not available: %v", err)
+	}
+
+	cases := []struct {
+		t    time.Time
+		zone string // expected location name after decoding
+	}{
+		{time.Date(2024, 7, 1, 12, 30, 0, 123456789, kyiv), "Europe/Kyiv"},
+		{time.Date(2024, 1, 1, 12, 30, 0, 0, kyiv), "Europe/Kyiv"},
+		{time.Date(1969, 12, 31, 23, 59, 59, 1, time.UTC), "UTC"},
+		{time.Date(2024, 3, 5, 8, 0, 0, 0, time.FixedZone("IST", 5*3600+1800)), ""},
+	}
+	for _, c := range cases {
+		b, err := TimeWithZoneToBytes(c.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := TimeWithZoneFromBytes(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.Equal(c.t) || got.Location().String() != c.zone {
+			t.Errorf("expected %v in %q, got %v in %q", c.t, c.zone, got, got.Location())
+		}
+		if got.Format(time.DateTime) != c.t.Format(time.DateTime) {
+			t.Errorf("wall clock changed: %s != %s", got.Format(time.DateTime), c.t.Format(time.DateTime))
+		}
+	}
+}
+
+func TestTimeWithZoneUnknownZone(t *testing.T) {
+	in := time.Date(2024, 7, 1, 12, 0, 0, 0, time.FixedZone("", -3*3600))
+	b, err := TimeWithZoneToBytes(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// pretend the record was written on a machine with zone unknown here
+	b = append(b[:16], append([]byte{12}, "Mars/Olympus"...)...)
+
+	got, err := TimeWithZoneFromBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, offset := got.Zone(); !got.Equal(in) || offset != -3*3600 {
+		t.Fatalf("expected %v with offset -3h, got %v", in, got)
+	}
+}
+
+func TestTimeWithZoneFromBytesErrors(t *testing.T) {
+	valid, _ := TimeWithZoneToBytes(time.Unix(0, 0).UTC())
+
+	invalid := [][]byte{
+		valid[:16],
+		valid[:len(valid)-1],
+		append([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0x3b, 0x9a, 0xca, 0x00}, 0, 0, 0, 0, 0), // 1e9 ns
+	}
+	for _, b := range invalid {
+		if _, err := TimeWithZoneFromBytes(b); err == nil {
+			t.Errorf("expected error for % x", b)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package bytecast
+
+import "fmt"
+
+// TLV is one tag-length-value element. Value aliases the parsed buffer.
+type TLV struct {
+	Tag   uint64
+	Value []byte
+}
+
+// TLVCodec reads and writes tag-length-value elements with fixed-width big-endian tag and length:
+//
+//	[ tag (tagWidth bytes) | length (lengthWidth bytes) | value (length bytes) ] ...
+//
+// e.g. NewTLVCodec(1, 1) for EMV-like simple TLV or NewTLVCodec(2, 2) for many vendor protocols.
+// With lengthWidth TLVLengthBER length is encoded as BER length octets (see EncodeBERLength).
+type TLVCodec struct {
+	tagWidth    int
+	lengthWidth int
+}
+
+// TLVLengthBER as lengthWidth of NewTLVCodec selects variable-size BER length octets.
+const TLVLengthBER = 0
+
+// NewTLVCodec creates codec with tag and length widths in bytes, each 1..8, or TLVLengthBER for length.
+func NewTLVCodec(tagWidth int, lengthWidth int) (*TLVCodec, error) {
+	if tagWidth < 1 || tagWidth > 8 {
+		return nil, fmt.Errorf("unsupported TLV tag width %d, must be 1..8 bytes", tagWidth)
+	}
+	if lengthWidth < TLVLengthBER || lengthWidth > 8 {
+		return nil, fmt.Errorf("unsupported TLV length width %d, must be 1..8 bytes or TLVLengthBER", lengthWidth)
+	}
+	return &TLVCodec{tagWidth: tagWidth, lengthWidth: lengthWidth}, nil
+}
+
+// Append appends one element to dst. On error dst is returned unchanged.
+func (c *TLVCodec) Append(dst []byte, tag uint64, value []byte) ([]byte, error) {
+	t, err := UintXXToBytesAndExpandWidth(tag, 8*c.tagWidth, c.tagWidth)
+	if err != nil {
+		return dst, fmt.Errorf("TLV tag %d does not fit %d bytes", tag, c.tagWidth)
+	}
+
+	out := append(dst, t...)
+	if c.lengthWidth == TLVLengthBER {
+		out = AppendBERLength(out, uint64(len(value)))
+	} else {
+		l, err := UintXXToBytesAndExpandWidth(uint64(len(value)), 8*c.lengthWidth, c.lengthWidth)
+		if err != nil {
+			return dst, fmt.Errorf("TLV value of %d bytes does not fit %d-byte length", len(value), c.lengthWidth)
+		}
+		out = append(out, l...)
+	}
+	return append(out, value...), nil
+}
+
+// Encode concatenates elements in given order.
+func (c *TLVCodec) Encode(elements []TLV) ([]byte, error) {
+	var out []byte
+	for _, e := range elements {
+		var err error
+		if out, err = c.Append(out, e.Tag, e.Value); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Next parses the first element of data and returns it with the rest of data.
+func (c *TLVCodec) Next(data []byte) (TLV, []byte, error) {
+	header := c.tagWidth + max(c.lengthWidth, 1)
+	if len(data) < header {
+		return TLV{}, nil, fmt.Errorf("truncated TLV header: expected %d bytes, but got %d bytes", header, len(data))
+	}
+
+	tag, _ := UintXXFromBytes(data[:c.tagWidth], 8*c.tagWidth)
+
+	var length uint64
+	if c.lengthWidth == TLVLengthBER {
+		l, n, err := DecodeBERLength(data[c.tagWidth:])
+		if err != nil {
+			return TLV{}, nil, fmt.Errorf("TLV tag %d: %w", tag, err)
+		}
+		length, header = l, c.tagWidth+n
+	} else {
+		length, _ = UintXXFromBytes(data[c.tagWidth:header], 8*c.lengthWidth)
+	}
+
+	rest := data[header:]
+	if length > uint64(len(rest)) {
+		return TLV{}, nil, fmt.Errorf("TLV tag %d declares %d bytes, but only %d bytes left", tag, length, len(rest))
+	}
+
+	return TLV{Tag: tag, Value: rest[:length:length]}, rest[length:], nil
+}
+
+// Parse splits data into elements, data must consist of whole elements.
+func (c *TLVCodec) Parse(data []byte, opts ...DecodeOption) ([]TLV, error) {
+	limits := &newDecodeConfig(opts).limits
+
+	var out []TLV
+	for len(data) > 0 {
+		if err := limits.checkElements(uint64(len(out) + 1)); err != nil {
+			return nil, err
+		}
+
+		e, rest, err := c.Next(data)
+		if err != nil {
+			return nil, fmt.Errorf("TLV %d: %w", len(out), err)
+		}
+		out = append(out, e)
+		data = rest
+	}
+	return out, nil
+}
+
+// Find returns value of the first element with given tag. Elements before it must be well-formed,
+// elements after it are not validated.
+func (c *TLVCodec) Find(data []byte, tag uint64) ([]byte, bool, error) {
+	for len(data) > 0 {
+		e, rest, err := c.Next(data)
+		if err != nil {
+			return nil, false, err
+		}
+		if e.Tag == tag {
+			return e.Value, true, nil
+		}
+		data = rest
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package bytecast
+
+import "iter"
+
+// All returns sequence of elements of data, for use with range:
+//
+//	for e, err := range codec.All(data) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Malformed element is yielded once as error and ends the sequence.
+func (c *TLVCodec) All(data []byte) iter.Seq2[TLV, error] {
+	return func(yield func(TLV, error) bool) {
+		for len(data) > 0 {
+			e, rest, err := c.Next(data)
+			if !yield(e, err) || err != nil {
+				return
+			}
+			data = rest
+		}
+	}
+}
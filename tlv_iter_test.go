@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package bytecast
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestTLVCodecAll(t *testing.T) {
+	codec, _ := NewTLVCodec(1, 1)
+	b, _ := hex.DecodeString("010161" + "02026263" + "0300")
+
+	var tags []uint64
+	for e, err := range codec.All(b) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		tags = append(tags, e.Tag)
+	}
+	if len(tags) != 3 || tags[0] != 1 || tags[1] != 2 || tags[2] != 3 {
+		t.Errorf("unexpected tags %v", tags)
+	}
+
+	var errs int
+	for _, err := range codec.All(append(b, 0x04)) {
+		if err != nil {
+			errs++
+		}
+	}
+	if errs != 1 {
+		t.Errorf("expected exactly one error for truncated tail, got %d", errs)
+	}
+
+	for range codec.All(b) {
+		break
+	}
+}
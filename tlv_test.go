@@ -0,0 +1,118 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTLVCodec(t *testing.T) {
+	cases := []struct {
+		tagWidth, lengthWidth int
+		hex                   string
+	}{
+		{1, 1, "0103616263" + "0200" + "ff01ee"},
+		{2, 2, "00010003616263" + "00020000" + "00ff0001ee"},
+		{1, 4, "0100000003616263" + "0200000000" + "ff00000001ee"},
+		{1, TLVLengthBER, "0103616263" + "0200" + "ff01ee"},
+	}
+	elements := []TLV{{1, []byte("abc")}, {2, []byte{}}, {0xff, []byte{0xee}}}
+
+	for _, c := range cases {
+		codec, err := NewTLVCodec(c.tagWidth, c.lengthWidth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := codec.Encode(elements)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("%d/%d: Encode = %s, expected %s", c.tagWidth, c.lengthWidth, got, c.hex)
+		}
+
+		got, err := codec.Parse(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, elements) {
+			t.Errorf("%d/%d: Parse = %v, expected %v", c.tagWidth, c.lengthWidth, got, elements)
+		}
+
+		if v, ok, err := codec.Find(b, 0xff); err != nil || !ok || !bytes.Equal(v, []byte{0xee}) {
+			t.Errorf("%d/%d: Find(0xff) = %x, %v (%v)", c.tagWidth, c.lengthWidth, v, ok, err)
+		}
+		if _, ok, err := codec.Find(b, 3); err != nil || ok {
+			t.Errorf("%d/%d: expected tag 3 to be absent, got %v (%v)", c.tagWidth, c.lengthWidth, ok, err)
+		}
+	}
+}
+
+func TestTLVCodecErrors(t *testing.T) {
+	for _, w := range [][2]int{{0, 1}, {1, -1}, {9, 1}, {1, 9}} {
+		if _, err := NewTLVCodec(w[0], w[1]); err == nil {
+			t.Errorf("NewTLVCodec(%d, %d): expected error", w[0], w[1])
+		}
+	}
+
+	codec, _ := NewTLVCodec(1, 1)
+	dst := []byte{0xAA}
+	if out, err := codec.Append(dst, 256, nil); err == nil || len(out) != 1 {
+		t.Errorf("expected error and unchanged dst for wide tag, got %x (%v)", out, err)
+	}
+	if _, err := codec.Append(nil, 1, make([]byte, 256)); err == nil {
+		t.Error("expected error for value not fitting length")
+	}
+
+	for _, h := range []string{"01", "0105616263"} {
+		b, _ := hex.DecodeString(h)
+		if _, err := codec.Parse(b); err == nil {
+			t.Errorf("Parse(%s): expected error", h)
+		}
+	}
+
+	// Find stops at the first match, malformed tail is not validated
+	b, _ := hex.DecodeString("010161" + "02ff")
+	if v, ok, err := codec.Find(b, 1); err != nil || !ok || string(v) != "a" {
+		t.Errorf("Find(1) = %q, %v (%v)", v, ok, err)
+	}
+	if _, _, err := codec.Find(b, 2); err == nil {
+		t.Error("expected error for malformed element before match")
+	}
+
+	b, _ = hex.DecodeString("0100" + "0200")
+	if _, err := codec.Parse(b, WithLimits(Limits{MaxElements: 1})); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+}
+
+func TestTLVCodecBERLength(t *testing.T) {
+	codec, err := NewTLVCodec(1, TLVLengthBER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := bytes.Repeat([]byte{0x55}, 300)
+	b, err := codec.Append(nil, 0x30, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(b[:4]); got != "3082012c" {
+		t.Errorf("unexpected header %s", got)
+	}
+
+	e, rest, err := codec.Next(b)
+	if err != nil || e.Tag != 0x30 || !bytes.Equal(e.Value, value) || len(rest) != 0 {
+		t.Errorf("Next = %v, %d bytes left (%v)", e.Tag, len(rest), err)
+	}
+
+	for _, h := range []string{"30", "3080", "3082", "3081ff"} {
+		in, _ := hex.DecodeString(h)
+		if _, _, err := codec.Next(in); err == nil {
+			t.Errorf("Next(%s): expected error", h)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package bytecast
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FloatCodec encodes physical values to fixed-width fields, implemented by LinearCodec and
+// codecs returned by Transformed, ByteSwapped and ParseFieldSpec.
+type FloatCodec interface {
+	Append(dst []byte, v float64) ([]byte, error)
+	Decode(b []byte) (float64, error)
+}
+
+// Transform is one step of value conversion between physical value and codec input.
+// Encode goes from physical value towards the wire, Decode is its inverse.
+type Transform interface {
+	Encode(v float64) (float64, error)
+	Decode(v float64) (float64, error)
+}
+
+// Transformed
+//
+//	Wraps codec with transforms listed from the wire outwards, the way field specs are usually written
+//	("uint16, scale 0.01, clamp 0..500"): Decode applies them in order after codec.Decode,
+//	Encode applies them in reverse order before codec.Append.
+func Transformed(codec FloatCodec, transforms ...Transform) FloatCodec {
+	return &transformCodec{codec: codec, transforms: transforms}
+}
+
+type transformCodec struct {
+	codec      FloatCodec
+	transforms []Transform
+}
+
+func (c *transformCodec) Append(dst []byte, v float64) ([]byte, error) {
+	for i := len(c.transforms) - 1; i >= 0; i-- {
+		var err error
+		if v, err = c.transforms[i].Encode(v); err != nil {
+			return dst, err
+		}
+	}
+	return c.codec.Append(dst, v)
+}
+
+func (c *transformCodec) Decode(b []byte) (float64, error) {
+	v, err := c.codec.Decode(b)
+	if err != nil {
+		return 0, err
+	}
+	for _, t := range c.transforms {
+		if v, err = t.Decode(v); err != nil {
+			return 0, err
+		}
+	}
+	return v, nil
+}
+
+// ByteSwapped wraps codec so that its field is stored with reversed byte order, e.g. little-endian
+// integer fields on top of big-endian LinearCodec.
+func ByteSwapped(codec FloatCodec) FloatCodec {
+	return byteSwapCodec{codec: codec}
+}
+
+type byteSwapCodec struct {
+	codec FloatCodec
+}
+
+func (c byteSwapCodec) Append(dst []byte, v float64) ([]byte, error) {
+	out, err := c.codec.Append(dst, v)
+	if err != nil {
+		return dst, err
+	}
+	slices.Reverse(out[len(dst):])
+	return out, nil
+}
+
+func (c byteSwapCodec) Decode(b []byte) (float64, error) {
+	swapped := slices.Clone(b)
+	slices.Reverse(swapped)
+	return c.codec.Decode(swapped)
+}
+
+// Scale returns transform value = v*scale + offset on decode (see LinearCodec for the same on the raw field).
+func Scale(scale float64, offset float64) Transform {
+	return scaleTransform{scale: scale, offset: offset}
+}
+
+type scaleTransform struct {
+	scale, offset float64
+}
+
+func (t scaleTransform) Encode(v float64) (float64, error) {
+	if t.scale == 0 {
+		return 0, fmt.Errorf("scale transform with zero scale is not invertible")
+	}
+	return (v - t.offset) / t.scale, nil
+}
+
+func (t scaleTransform) Decode(v float64) (float64, error) {
+	return v*t.scale + t.offset, nil
+}
+
+// Clamp returns transform limiting value to [lo, hi] in both directions,
+// so out-of-range sensor readings saturate instead of failing.
+func Clamp(lo float64, hi float64) Transform {
+	return clampTransform{lo: lo, hi: hi}
+}
+
+type clampTransform struct {
+	lo, hi float64
+}
+
+func (t clampTransform) Encode(v float64) (float64, error) {
+	return t.Decode(v)
+}
+
+func (t clampTransform) Decode(v float64) (float64, error) {
+	if math.IsNaN(v) {
+		return 0, fmt.Errorf("can not clamp NaN")
+	}
+	return min(max(v, t.lo), t.hi), nil
+}
+
+// EnumMap returns transform mapping raw codes to physical values on decode (e.g. gear 0..3 → ratio),
+// unmapped values are an error in both directions. Physical values must be unique.
+func EnumMap(rawToValue map[float64]float64) (Transform, error) {
+	t := enumTransform{decode: rawToValue, encode: make(map[float64]float64, len(rawToValue))}
+	for raw, v := range rawToValue {
+		if _, ok := t.encode[v]; ok {
+			return nil, fmt.Errorf("enum map value %v is used more than once", v)
+		}
+		t.encode[v] = raw
+	}
+	return t, nil
+}
+
+type enumTransform struct {
+	encode, decode map[float64]float64
+}
+
+func (t enumTransform) Encode(v float64) (float64, error) {
+	raw, ok := t.encode[v]
+	if !ok {
+		return 0, fmt.Errorf("value %v is not in enum map", v)
+	}
+	return raw, nil
+}
+
+func (t enumTransform) Decode(v float64) (float64, error) {
+	value, ok := t.decode[v]
+	if !ok {
+		return 0, fmt.Errorf("raw value %v is not in enum map", v)
+	}
+	return value, nil
+}
+
+// ParseFieldSpec
+//
+//	Builds codec from comma-separated field description, raw type first, transforms from the wire outwards:
+//
+//	"uint16 le, scale 0.01, clamp 0..500"
+//	"int8, scale 0.5 -40"
+//	"uint8, enum 0=1 1=2.5 2=4"
+//
+//	Raw types are int8..int64 and uint8..uint64 (big-endian, "le" suffix for little-endian, any case),
+//	transforms are "scale S [OFFSET]", "clamp LO..HI" and "enum RAW=VALUE ...".
+//
+//	The same spec can be declared on float64 and float32 struct fields with `bytecastspec` tag (see Schema).
+func ParseFieldSpec(spec string) (FloatCodec, error) {
+	codec, _, err := parseFieldSpec(spec)
+	return codec, err
+}
+
+// parseFieldSpec is ParseFieldSpec which also returns encoded width of the field.
+func parseFieldSpec(spec string) (FloatCodec, int, error) {
+	parts := strings.Split(spec, ",")
+
+	codec, width, err := parseRawSpec(strings.Fields(parts[0]))
+	if err != nil {
+		return nil, 0, fmt.Errorf("field spec %q: %w", spec, err)
+	}
+
+	var transforms []Transform
+	for _, part := range parts[1:] {
+		t, err := parseTransformSpec(strings.Fields(part))
+		if err != nil {
+			return nil, 0, fmt.Errorf("field spec %q: %w", spec, err)
+		}
+		transforms = append(transforms, t)
+	}
+
+	if len(transforms) == 0 {
+		return codec, width, nil
+	}
+	return Transformed(codec, transforms...), width, nil
+}
+
+func parseRawSpec(words []string) (FloatCodec, int, error) {
+	if len(words) == 0 || len(words) > 2 {
+		return nil, 0, fmt.Errorf("expected raw type with optional byte order")
+	}
+
+	c := LinearCodec{Scale: 1}
+	name := words[0]
+	if strings.HasPrefix(name, "int") {
+		c.Signed = true
+		name = name[3:]
+	} else if strings.HasPrefix(name, "uint") {
+		name = name[4:]
+	} else {
+		return nil, 0, fmt.Errorf("unknown raw type %q", words[0])
+	}
+
+	switch name {
+	case "8", "16", "32", "64":
+		bits, _ := strconv.Atoi(name)
+		c.Width = bits / 8
+	default:
+		return nil, 0, fmt.Errorf("unknown raw type %q", words[0])
+	}
+
+	if len(words) == 1 || strings.EqualFold(words[1], "be") {
+		return c, c.Width, nil
+	}
+	if strings.EqualFold(words[1], "le") {
+		return ByteSwapped(c), c.Width, nil
+	}
+	return nil, 0, fmt.Errorf("unknown byte order %q", words[1])
+}
+
+func parseTransformSpec(words []string) (Transform, error) {
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty transform")
+	}
+
+	args := words[1:]
+	switch words[0] {
+	case "scale":
+		if len(args) < 1 || len(args) > 2 {
+			return nil, fmt.Errorf("scale expects factor and optional offset")
+		}
+		nums, err := parseFloats(args)
+		if err != nil {
+			return nil, err
+		}
+		if nums[0] == 0 {
+			return nil, fmt.Errorf("scale factor can not be zero")
+		}
+		if len(nums) == 1 {
+			nums = append(nums, 0)
+		}
+		return Scale(nums[0], nums[1]), nil
+	case "clamp":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("clamp expects LO..HI")
+		}
+		lo, hi, ok := strings.Cut(args[0], "..")
+		if !ok {
+			return nil, fmt.Errorf("clamp expects LO..HI, got %q", args[0])
+		}
+		nums, err := parseFloats([]string{lo, hi})
+		if err != nil {
+			return nil, err
+		}
+		if nums[0] > nums[1] {
+			return nil, fmt.Errorf("clamp range %v..%v is empty", nums[0], nums[1])
+		}
+		return Clamp(nums[0], nums[1]), nil
+	case "enum":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("enum expects RAW=VALUE pairs")
+		}
+		m := make(map[float64]float64, len(args))
+		for _, a := range args {
+			raw, value, ok := strings.Cut(a, "=")
+			if !ok {
+				return nil, fmt.Errorf("enum expects RAW=VALUE, got %q", a)
+			}
+			nums, err := parseFloats([]string{raw, value})
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := m[nums[0]]; dup {
+				return nil, fmt.Errorf("enum raw value %v is used more than once", nums[0])
+			}
+			m[nums[0]] = nums[1]
+		}
+		return EnumMap(m)
+	default:
+		return nil, fmt.Errorf("unknown transform %q", words[0])
+	}
+}
+
+func parseFloats(words []string) ([]float64, error) {
+	out := make([]float64, len(words))
+	for i, w := range words {
+		f, err := strconv.ParseFloat(w, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("invalid number %q", w)
+		}
+		out[i] = f
+	}
+	return out, nil
+}
@@ -0,0 +1,187 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldSpec(t *testing.T) {
+	cases := []struct {
+		spec    string
+		value   float64
+		hex     string
+		decoded float64
+	}{
+		{"uint16 le, scale 0.01, clamp 0..500", 123.45, "3930", 123.45},
+		{"uint16 le, scale 0.01, clamp 0..500", 900, "50c3", 500},
+		{"uint16 LE, scale 0.01, clamp 0..500", 123.45, "3930", 123.45},
+		{"uint16 be, scale 0.01", 1, "0064", 1},
+		{"uint16 Be, scale 0.01", 1, "0064", 1},
+		{"int8, scale 0.5 -40", -41, "fe", -41},
+		{"uint8, enum 0=1 1=2.5 2=4", 2.5, "01", 2.5},
+		{"int32 le", -2, "feffffff", -2},
+		{"uint64", 1, "0000000000000001", 1},
+	}
+	for _, c := range cases {
+		codec, err := ParseFieldSpec(c.spec)
+		if err != nil {
+			t.Fatalf("%q: %v", c.spec, err)
+		}
+		b, err := codec.Append(nil, c.value)
+		if err != nil {
+			t.Fatalf("%q: Append(%v): %v", c.spec, c.value, err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("%q: Append(%v) = %s, expected %s", c.spec, c.value, got, c.hex)
+		}
+		got, err := codec.Decode(b)
+		if err != nil {
+			t.Fatalf("%q: Decode: %v", c.spec, err)
+		}
+		if math.Abs(got-c.decoded) > 1e-9 {
+			t.Errorf("%q: Decode(%s) = %v, expected %v", c.spec, c.hex, got, c.decoded)
+		}
+	}
+}
+
+func TestParseFieldSpecErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"float32",
+		"uint12",
+		"uint16 middle",
+		"uint16, scale",
+		"uint16, scale 0",
+		"uint16, scale x",
+		"uint16, clamp 5",
+		"uint16, clamp 5..1",
+		"uint16, enum 1",
+		"uint16, enum 1=2 1=3",
+		"uint16, enum 1=2 2=2",
+		"uint16, offset 4",
+		"uint16,",
+	} {
+		if _, err := ParseFieldSpec(spec); err == nil {
+			t.Errorf("ParseFieldSpec(%q): expected error", spec)
+		}
+	}
+}
+
+func TestTransformed(t *testing.T) {
+	gear, err := EnumMap(map[float64]float64{0: -1, 1: 1, 2: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := Transformed(LinearCodec{Scale: 1, Width: 1}, gear)
+
+	if _, err := codec.Append(nil, 3); err == nil {
+		t.Error("expected error for value not in enum map")
+	}
+	if _, err := codec.Decode([]byte{7}); err == nil {
+		t.Error("expected error for raw value not in enum map")
+	}
+
+	// clamp saturates before raw range check
+	clamped := Transformed(LinearCodec{Scale: 1, Width: 1}, Clamp(0, 255))
+	if b, err := clamped.Append(nil, 1000); err != nil || b[0] != 0xff {
+		t.Errorf("expected saturated 0xff, got %x (%v)", b, err)
+	}
+	if _, err := clamped.Append(nil, math.NaN()); err == nil {
+		t.Error("expected error for NaN")
+	}
+
+	// append failure leaves dst unchanged
+	swapped := ByteSwapped(LinearCodec{Scale: 1, Width: 2})
+	dst := []byte{0xAA}
+	if out, err := swapped.Append(dst, -1); err == nil || len(out) != 1 {
+		t.Errorf("expected error and unchanged dst, got %x (%v)", out, err)
+	}
+	if out, err := swapped.Append(dst, 0x0102); err != nil || hex.EncodeToString(out) != "aa0201" {
+		t.Errorf("expected aa0201, got %x (%v)", out, err)
+	}
+}
+
+func TestSchemaFieldSpec(t *testing.T) {
+	type reading struct {
+		ID      uint8
+		Temp    float64    `bytecastspec:"uint16 LE, scale 0.01, clamp 0..500"`
+		Volts   [2]float32 `bytecastspec:"int8, scale 0.5 -40"`
+		Battery *float64   `bytecastspec:"uint8"`
+	}
+
+	layout, err := DescribeLayout(reading{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []FieldLayout{
+		{"ID", 0, 1, KindUint, false},
+		{"Temp", 1, 2, KindCustom, false},
+		{"Volts[0]", 3, 1, KindCustom, false},
+		{"Volts[1]", 4, 1, KindCustom, false},
+		{"Battery", 5, 1, KindPresence, false},
+		{"Battery", 6, 1, KindCustom, false},
+	}
+	if !reflect.DeepEqual(layout, expected) {
+		t.Fatalf("unexpected layout:\n%v\nexpected:\n%v", layout, expected)
+	}
+
+	battery := 87.0
+	in := reading{ID: 7, Temp: 900, Volts: [2]float32{-41, 12.5}, Battery: &battery}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(data); got != "07"+"50c3"+"fe"+"69"+"01"+"57" {
+		t.Fatalf("unexpected encoding %s", got)
+	}
+
+	var out reading
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Temp != 500 || out.Volts != in.Volts || out.Battery == nil || *out.Battery != battery {
+		t.Fatalf("unexpected decoded record %+v", out)
+	}
+
+	type otherScale struct {
+		ID      uint8
+		Temp    float64    `bytecastspec:"uint16 LE, scale 0.1"`
+		Volts   [2]float32 `bytecastspec:"int8, scale 0.5 -40"`
+		Battery *float64   `bytecastspec:"uint8"`
+	}
+	a, _ := SchemaOf(reading{})
+	b, _ := SchemaOf(otherScale{})
+	if a.Fingerprint() == b.Fingerprint() {
+		t.Error("fingerprint must depend on field spec")
+	}
+}
+
+func TestSchemaFieldSpecErrors(t *testing.T) {
+	invalid := []any{
+		struct {
+			N int16 `bytecastspec:"uint16"`
+		}{},
+		struct {
+			F float64 `bytecastspec:"uint12"`
+		}{},
+		struct {
+			F float64 `bytecast:"width=4" bytecastspec:"uint16"`
+		}{},
+		struct {
+			F float64
+		}{},
+	}
+	for _, v := range invalid {
+		if _, err := SchemaOf(v); err == nil {
+			t.Errorf("SchemaOf(%T): expected error", v)
+		}
+	}
+
+	if _, err := Marshal(struct {
+		F float64 `bytecastspec:"uint8"`
+	}{F: 256}); err == nil {
+		t.Error("expected range error at encode time")
+	}
+}
@@ -0,0 +1,226 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Tuple component type tags, components of different types sort by tag.
+const (
+	tupleTagBytes  byte = 0x01
+	tupleTagString byte = 0x02
+	tupleTagInt    byte = 0x03
+	tupleTagUint   byte = 0x04
+	tupleTagFloat  byte = 0x05
+	tupleTagBool   byte = 0x06
+	tupleTagTime   byte = 0x07
+)
+
+// TupleKey
+//
+//	Builds a composite key for ordered KV stores from a sequence of typed components.
+//	bytes.Compare order of resulting keys equals component-wise order of tuples,
+//	and a key of tuple prefix sorts before keys of all its extensions, so prefix scans work.
+//
+//	Each component is a type tag followed by:
+//	- int:    8 bytes, IntToOrderedBytes
+//	- uint:   8 bytes, big-endian
+//	- float:  8 bytes, FloatToSortableBytes
+//	- bool:   1 byte
+//	- time:   8 bytes ordered unix seconds + 4 bytes nanoseconds (location is not stored)
+//	- string, bytes: content with 0x00 escaped as 0x00 0xFF, terminated by 0x00
+//
+//	Zero value is ready to use.
+type TupleKey struct {
+	buf    []byte
+	floats floatPolicy
+	err    error
+}
+
+// NewTupleKey returns empty TupleKey, opts set NaN/Inf policy of PutFloat64 (see FloatOption).
+func NewTupleKey(opts ...FloatOption) *TupleKey {
+	return &TupleKey{floats: newFloatPolicy(opts)}
+}
+
+// PutInt appends signed integer component.
+func (k *TupleKey) PutInt(v int64) *TupleKey {
+	k.buf = append(k.buf, tupleTagInt)
+	k.buf = binary.BigEndian.AppendUint64(k.buf, uint64(v)^1<<63)
+	return k
+}
+
+// PutUint appends unsigned integer component.
+func (k *TupleKey) PutUint(v uint64) *TupleKey {
+	k.buf = append(k.buf, tupleTagUint)
+	k.buf = binary.BigEndian.AppendUint64(k.buf, v)
+	return k
+}
+
+// PutFloat64 appends float component, see FloatToSortableBytes for NaN and -0 ordering.
+// If the value is rejected by float policy, nothing is appended and the error is reported by Err.
+func (k *TupleKey) PutFloat64(v float64) *TupleKey {
+	v, err := k.floats.apply64(v)
+	if err != nil {
+		if k.err == nil {
+			k.err = err
+		}
+		return k
+	}
+	k.buf = append(k.buf, tupleTagFloat)
+	k.buf = binary.BigEndian.AppendUint64(k.buf, sortableFloat64Bits(math.Float64bits(v)))
+	return k
+}
+
+// PutBool appends bool component, false sorts before true.
+func (k *TupleKey) PutBool(v bool) *TupleKey {
+	b := BoolTo1Byte(v)
+	k.buf = append(k.buf, tupleTagBool, b[0])
+	return k
+}
+
+// PutTime appends time component, ordered by instant.
+func (k *TupleKey) PutTime(v time.Time) *TupleKey {
+	k.buf = append(k.buf, tupleTagTime)
+	k.buf = binary.BigEndian.AppendUint64(k.buf, uint64(v.Unix())^1<<63)
+	k.buf = binary.BigEndian.AppendUint32(k.buf, uint32(v.Nanosecond()))
+	return k
+}
+
+// PutString appends string component.
+func (k *TupleKey) PutString(v string) *TupleKey {
+	k.buf = append(k.buf, tupleTagString)
+	k.buf = appendTupleEscaped(k.buf, []byte(v))
+	return k
+}
+
+// PutBytes appends raw bytes component.
+func (k *TupleKey) PutBytes(v []byte) *TupleKey {
+	k.buf = append(k.buf, tupleTagBytes)
+	k.buf = appendTupleEscaped(k.buf, v)
+	return k
+}
+
+// Bytes returns built key. The slice aliases internal buffer until next Put call.
+func (k *TupleKey) Bytes() []byte {
+	return k.buf
+}
+
+// Len returns length of built key in bytes.
+func (k *TupleKey) Len() int {
+	return len(k.buf)
+}
+
+// Err returns the first error of Put calls since creation or last Reset. Key with error is incomplete.
+func (k *TupleKey) Err() error {
+	return k.err
+}
+
+// Reset clears key and error keeping allocated buffer and float policy.
+func (k *TupleKey) Reset() {
+	k.buf = k.buf[:0]
+	k.err = nil
+}
+
+// DecodeTupleKey
+//
+//	Splits key built by TupleKey back into components:
+//	int64, uint64, float64, bool, time.Time (UTC), string or []byte.
+func DecodeTupleKey(key []byte) ([]any, error) {
+	var out []any
+
+	for off := 0; off < len(key); {
+		tag := key[off]
+		off++
+
+		var v any
+		var n int
+
+		switch tag {
+		case tupleTagInt, tupleTagUint, tupleTagFloat:
+			if len(key)-off < 8 {
+				return nil, fmt.Errorf("truncated tuple component at offset %d", off-1)
+			}
+			u := binary.BigEndian.Uint64(key[off:])
+			switch tag {
+			case tupleTagInt:
+				v = int64(u ^ 1<<63)
+			case tupleTagUint:
+				v = u
+			default:
+				v = FloatFromSortableBytes([8]byte(key[off:]))
+			}
+			n = 8
+		case tupleTagBool:
+			if len(key)-off < 1 {
+				return nil, fmt.Errorf("truncated tuple component at offset %d", off-1)
+			}
+			if key[off] > 1 {
+				return nil, fmt.Errorf("invalid bool 0x%02x in tuple component at offset %d", key[off], off-1)
+			}
+			v, n = key[off] == 1, 1
+		case tupleTagTime:
+			if len(key)-off < 12 {
+				return nil, fmt.Errorf("truncated tuple component at offset %d", off-1)
+			}
+			sec := int64(binary.BigEndian.Uint64(key[off:]) ^ 1<<63)
+			nsec := binary.BigEndian.Uint32(key[off+8:])
+			if nsec >= 1e9 {
+				return nil, fmt.Errorf("invalid nanoseconds %d in tuple component at offset %d", nsec, off-1)
+			}
+			v, n = time.Unix(sec, int64(nsec)).UTC(), 12
+		case tupleTagString, tupleTagBytes:
+			raw, consumed, err := readTupleEscaped(key[off:])
+			if err != nil {
+				return nil, fmt.Errorf("tuple component at offset %d: %w", off-1, err)
+			}
+			if tag == tupleTagString {
+				v = string(raw)
+			} else {
+				v = raw
+			}
+			n = consumed
+		default:
+			return nil, fmt.Errorf("unknown tuple component tag 0x%02x at offset %d", tag, off-1)
+		}
+
+		out = append(out, v)
+		off += n
+	}
+
+	return out, nil
+}
+
+func appendTupleEscaped(dst []byte, v []byte) []byte {
+	for {
+		i := bytes.IndexByte(v, 0x00)
+		if i < 0 {
+			break
+		}
+		dst = append(dst, v[:i+1]...)
+		dst = append(dst, 0xFF)
+		v = v[i+1:]
+	}
+	dst = append(dst, v...)
+	return append(dst, 0x00)
+}
+
+// readTupleEscaped returns unescaped content and number of consumed bytes including terminator.
+func readTupleEscaped(b []byte) ([]byte, int, error) {
+	out := []byte{}
+	for i := 0; i < len(b); i++ {
+		if b[i] != 0x00 {
+			out = append(out, b[i])
+			continue
+		}
+		if i+1 < len(b) && b[i+1] == 0xFF {
+			out = append(out, 0x00)
+			i++
+			continue
+		}
+		return out, i + 1, nil
+	}
+	return nil, 0, fmt.Errorf("unterminated tuple string")
+}
@@ -0,0 +1,98 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTupleKeyEncoding(t *testing.T) {
+	cases := []struct {
+		name string
+		key  *TupleKey
+		hex  string
+	}{
+		{"int", NewTupleKey().PutInt(-1), "037fffffffffffffff"},
+		{"uint", NewTupleKey().PutUint(258), "040000000000000102"},
+		{"bool", NewTupleKey().PutBool(true), "0601"},
+		{"string escape", NewTupleKey().PutString("a\x00b"), "026100ff6200"},
+		{"bytes", NewTupleKey().PutBytes(nil), "0100"},
+		{"time", NewTupleKey().PutTime(time.Unix(1, 5)), "07800000000000000100000005"},
+		{"float", NewTupleKey().PutFloat64(1), "05bff0000000000000"},
+	}
+	for _, c := range cases {
+		if got := hex.EncodeToString(c.key.Bytes()); got != c.hex {
+			t.Errorf("%s: got %s, expected %s", c.name, got, c.hex)
+		}
+	}
+}
+
+func TestTupleKeyOrder(t *testing.T) {
+	ordered := []*TupleKey{
+		NewTupleKey().PutString("user"),
+		NewTupleKey().PutString("user").PutInt(-5),
+		NewTupleKey().PutString("user").PutInt(0),
+		NewTupleKey().PutString("user").PutInt(0).PutString(""),
+		NewTupleKey().PutString("user").PutInt(0).PutString("\x00"),
+		NewTupleKey().PutString("user").PutInt(0).PutString("a"),
+		NewTupleKey().PutString("user").PutInt(3),
+		NewTupleKey().PutString("user\x00"),
+		NewTupleKey().PutString("userA"),
+		NewTupleKey().PutString("users").PutTime(time.Date(1969, 1, 1, 0, 0, 0, 0, time.UTC)),
+		NewTupleKey().PutString("users").PutTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		NewTupleKey().PutString("users").PutTime(time.Date(2024, 1, 1, 0, 0, 0, 1, time.UTC)),
+	}
+	for i := 1; i < len(ordered); i++ {
+		if bytes.Compare(ordered[i-1].Bytes(), ordered[i].Bytes()) >= 0 {
+			t.Errorf("expected key %d < key %d: %x >= %x", i-1, i, ordered[i-1].Bytes(), ordered[i].Bytes())
+		}
+	}
+}
+
+func TestDecodeTupleKey(t *testing.T) {
+	tm := time.Date(2024, 5, 1, 13, 14, 15, 123, time.UTC)
+	k := NewTupleKey().
+		PutString("orders\x00x").
+		PutInt(math.MinInt64).
+		PutUint(math.MaxUint64).
+		PutFloat64(-2.5).
+		PutBool(false).
+		PutTime(tm).
+		PutBytes([]byte{0, 0xff, 0})
+
+	got, err := DecodeTupleKey(k.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []any{"orders\x00x", int64(math.MinInt64), uint64(math.MaxUint64), -2.5, false, tm, []byte{0, 0xff, 0}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v, expected %#v", got, expected)
+	}
+
+	k.Reset()
+	if k.Len() != 0 {
+		t.Errorf("expected empty key after Reset, got %d bytes", k.Len())
+	}
+}
+
+func TestDecodeTupleKeyErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		hex  string
+	}{
+		{"unknown tag", "ff"},
+		{"truncated int", "030000"},
+		{"unterminated string", "026162"},
+		{"invalid bool", "0602"},
+		{"invalid nanoseconds", "0780000000000000003b9aca00"},
+	}
+	for _, c := range cases {
+		b, _ := hex.DecodeString(c.hex)
+		if _, err := DecodeTupleKey(b); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+	}
+}
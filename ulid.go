@@ -0,0 +1,114 @@
+package bytecast
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID is a 16-byte Universally Unique Lexicographically Sortable Identifier:
+//
+//	[ unix milliseconds (48 bits) | entropy (80 bits) ]
+//
+// Canonical string form is 26 characters of Crockford base32, which sorts the same way as bytes.
+type ULID [16]byte
+
+// NewULID builds ULID from t and 10 bytes read from entropy (e.g. crypto/rand.Reader).
+func NewULID(t time.Time, entropy io.Reader) (ULID, error) {
+	var u ULID
+
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return u, fmt.Errorf("time %v is out of ULID range", t)
+	}
+
+	b, _ := UintXXToBytesAndExpandWidth(uint64(ms), 48, 6)
+	copy(u[:6], b)
+
+	if _, err := io.ReadFull(entropy, u[6:]); err != nil {
+		return ULID{}, fmt.Errorf("read ULID entropy: %w", err)
+	}
+
+	return u, nil
+}
+
+// ULIDFromBytes converts slice of exactly 16 bytes to ULID.
+func ULIDFromBytes(b []byte) (ULID, error) {
+	if len(b) != 16 {
+		return ULID{}, fmt.Errorf("expected exactly 16 bytes for ULID, but got %d bytes", len(b))
+	}
+	return ULID(b), nil
+}
+
+// ULIDFromString parses 26-character Crockford base32 form, case-insensitive.
+func ULIDFromString(s string) (ULID, error) {
+	if len(s) != 26 {
+		return ULID{}, fmt.Errorf("expected 26 characters in ULID, but got %d in %q", len(s), s)
+	}
+
+	upper := []byte(s)
+	for i, c := range upper {
+		upper[i] = upperASCII(c) // strings.ToUpper could change length of non-ASCII input
+	}
+
+	n, err := decodeBaseN(string(upper), crockfordAlphabet)
+	if err != nil {
+		return ULID{}, fmt.Errorf("invalid ULID %q: %w", s, err)
+	}
+	if n.BitLen() > 128 {
+		return ULID{}, fmt.Errorf("ULID %q overflows 128 bits", s)
+	}
+
+	var u ULID
+	n.FillBytes(u[:])
+	return u, nil
+}
+
+// String returns canonical 26-character Crockford base32 form.
+func (u ULID) String() string {
+	return encodeBaseN(u[:], crockfordAlphabet, 26)
+}
+
+// Time returns timestamp component of ULID.
+func (u ULID) Time() time.Time {
+	ms, _ := UintXXFromBytes(u[:6], 48)
+	return time.UnixMilli(int64(ms))
+}
+
+// Entropy returns 10-byte random component of ULID.
+func (u ULID) Entropy() [10]byte {
+	return [10]byte(u[6:])
+}
+
+// encodeBaseN encodes b as big-endian number in given alphabet, left-padded with zero digit to width.
+func encodeBaseN(b []byte, alphabet string, width int) string {
+	n := new(big.Int).SetBytes(b)
+	base := big.NewInt(int64(len(alphabet)))
+	mod := new(big.Int)
+
+	out := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		n.DivMod(n, base, mod)
+		out[i] = alphabet[mod.Int64()]
+	}
+	return string(out)
+}
+
+func decodeBaseN(s string, alphabet string) (*big.Int, error) {
+	n := new(big.Int)
+	base := big.NewInt(int64(len(alphabet)))
+
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(alphabet, s[i])
+		if d < 0 {
+			return nil, fmt.Errorf("invalid character %q at position %d", s[i], i)
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	return n, nil
+}
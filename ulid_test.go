@@ -0,0 +1,80 @@
+package bytecast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestULIDString(t *testing.T) {
+	// example from the ULID specification
+	u, err := ULIDFromString("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(u[:]); got != "01563e3ab5d3d6764c61efb99302bd5b" {
+		t.Errorf("unexpected bytes %s", got)
+	}
+	if got := u.Time().UnixMilli(); got != 1469922850259 {
+		t.Errorf("unexpected timestamp %d", got)
+	}
+	if got := u.String(); got != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("unexpected string %s", got)
+	}
+
+	lower, err := ULIDFromString("01arz3ndektsv4rrffq69g5fav")
+	if err != nil || lower != u {
+		t.Errorf("expected lowercase form to parse to the same ULID, got %v (%v)", lower, err)
+	}
+
+	if got := (ULID{}).String(); got != "00000000000000000000000000" {
+		t.Errorf("unexpected zero ULID string %s", got)
+	}
+	maxID := ULID(bytes.Repeat([]byte{0xff}, 16))
+	if got := maxID.String(); got != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Errorf("unexpected max ULID string %s", got)
+	}
+}
+
+func TestNewULID(t *testing.T) {
+	tm := time.UnixMilli(1700000000123)
+	entropy := bytes.NewReader([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	u, err := NewULID(tm, entropy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !u.Time().Equal(tm) {
+		t.Errorf("expected time %v, got %v", tm, u.Time())
+	}
+	if u.Entropy() != [10]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10} {
+		t.Errorf("unexpected entropy %x", u.Entropy())
+	}
+	if back, err := ULIDFromBytes(u[:]); err != nil || back != u {
+		t.Errorf("ULIDFromBytes round trip failed: %v (%v)", back, err)
+	}
+
+	if _, err := NewULID(tm, bytes.NewReader([]byte{1, 2})); err == nil {
+		t.Error("expected error for short entropy")
+	}
+	if _, err := NewULID(time.Unix(-1, 0), entropy); err == nil {
+		t.Error("expected error for time before epoch")
+	}
+}
+
+func TestULIDErrors(t *testing.T) {
+	for _, s := range []string{
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",  // short
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU", // U is not in Crockford alphabet
+		"80000000000000000000000000", // overflows 128 bits
+		"ſ1ARZ3NDEKTSV4RRFFQ69G5FA",  // 26 bytes, but ſ upper-cases to S
+	} {
+		if _, err := ULIDFromString(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+	if _, err := ULIDFromBytes(make([]byte, 15)); err == nil {
+		t.Error("expected error for 15 bytes")
+	}
+}
@@ -0,0 +1,82 @@
+package bytecast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Union encodes value which may have one of several registered Go types (variants)
+// as tag byte followed by the value encoded with Marshal:
+//
+//	[ tag (1 byte) | Marshal(value) ]
+//
+// Decode dispatches on the tag and returns value of the registered type:
+//
+//	u := NewUnion()
+//	_ = RegisterVariant[Ping](u, 0x01)
+//	_ = RegisterVariant[Transfer](u, 0x02)
+//	msg, err := u.Decode(data)
+//	switch m := msg.(type) { case Ping: ...; case Transfer: ... }
+type Union struct {
+	byTag  map[byte]reflect.Type
+	byType map[reflect.Type]byte
+}
+
+func NewUnion() *Union {
+	return &Union{byTag: make(map[byte]reflect.Type), byType: make(map[reflect.Type]byte)}
+}
+
+// RegisterVariant registers type T under tag. Both tag and type may be registered only once,
+// T must be supported by Marshal / Unmarshal.
+func RegisterVariant[T any](u *Union, tag byte) error {
+	t := reflect.TypeFor[T]()
+
+	if prev, ok := u.byTag[tag]; ok {
+		return fmt.Errorf("union tag 0x%02x is already registered for %s", tag, prev)
+	}
+	if prev, ok := u.byType[t]; ok {
+		return fmt.Errorf("type %s is already registered with union tag 0x%02x", t, prev)
+	}
+
+	u.byTag[tag] = t
+	u.byType[t] = tag
+	return nil
+}
+
+// Encode writes tag of v's type followed by encoded v.
+func (u *Union) Encode(v any) ([]byte, error) {
+	return u.Append(nil, v)
+}
+
+// Append appends encoding of v (see Encode) to dst. On error dst is returned unchanged.
+func (u *Union) Append(dst []byte, v any) ([]byte, error) {
+	tag, ok := u.byType[reflect.TypeOf(v)]
+	if !ok {
+		return dst, fmt.Errorf("type %T is not registered in union", v)
+	}
+	out, err := appendValue(append(dst, tag), v)
+	if err != nil {
+		return dst, err
+	}
+	return out, nil
+}
+
+// Decode reads tag and decodes the rest of data into value of the registered type.
+// Returned value has the registered type itself (not pointer to it).
+func (u *Union) Decode(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("expected at least 1 byte for union tag, but got 0 bytes")
+	}
+
+	t, ok := u.byTag[data[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown union tag 0x%02x", data[0])
+	}
+
+	ptr := reflect.New(t)
+	if err := Unmarshal(data[1:], ptr.Interface()); err != nil {
+		return nil, fmt.Errorf("union variant %s: %w", t, err)
+	}
+
+	return ptr.Elem().Interface(), nil
+}
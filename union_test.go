@@ -0,0 +1,90 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type unionTestPing struct {
+	Seq uint16
+}
+
+type unionTestTransfer struct {
+	To     Address
+	Amount uint64
+}
+
+func TestUnion(t *testing.T) {
+	u := NewUnion()
+	if err := RegisterVariant[unionTestPing](u, 0x01); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterVariant[unionTestTransfer](u, 0x02); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterVariant[int32](u, 0x03); err != nil {
+		t.Fatal(err)
+	}
+
+	ping, err := u.Encode(unionTestPing{Seq: 7})
+	if err != nil || hex.EncodeToString(ping) != "010007" {
+		t.Fatalf("expected 010007 got %x (%v)", ping, err)
+	}
+
+	values := []any{
+		unionTestPing{Seq: 7},
+		unionTestTransfer{To: Address{19: 1}, Amount: 1000},
+		int32(-1),
+	}
+
+	for _, v := range values {
+		data, err := u.Encode(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		back, err := u.Decode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(back, v) {
+			t.Fatalf("expected %#v got %#v", v, back)
+		}
+	}
+}
+
+func TestUnionErrors(t *testing.T) {
+	u := NewUnion()
+	_ = RegisterVariant[unionTestPing](u, 0x01)
+
+	if err := RegisterVariant[int8](u, 0x01); err == nil {
+		t.Fatal("expected error for duplicated tag")
+	}
+	if err := RegisterVariant[unionTestPing](u, 0x02); err == nil {
+		t.Fatal("expected error for duplicated type")
+	}
+
+	if _, err := u.Encode(int64(1)); err == nil {
+		t.Fatal("expected error for unregistered type")
+	}
+
+	for _, bad := range []string{"", "ff0001", "0100"} {
+		data, _ := hex.DecodeString(bad)
+		if _, err := u.Decode(data); err == nil {
+			t.Errorf("Decode(%s): expected error", bad)
+		}
+	}
+}
+
+func TestUnionAppendError(t *testing.T) {
+	u := NewUnion()
+	_ = RegisterVariant[string](u, 0x01)
+
+	dst := []byte{0xAA}
+	out, err := u.Append(dst, strings.Repeat("x", 300))
+	if err == nil || len(out) != len(dst) {
+		t.Fatalf("expected error and unchanged dst, got %x (%v)", out, err)
+	}
+}
@@ -0,0 +1,84 @@
+package bytecast
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrYear2038 is returned by TimeToUnixBytes in 4-byte mode for times after 2038-01-19 03:14:07 UTC
+// (or before 1901-12-13 20:45:52 UTC), which do not fit signed 32-bit Unix timestamp.
+var ErrYear2038 = errors.New("time does not fit 32-bit Unix timestamp (year 2038 problem)")
+
+// TimeToUnixBytes
+//
+//	Encodes t as signed big-endian Unix time in seconds of width 4, 5, 6 or 8 bytes.
+//	Sub-second part is truncated. Times not fitting the width are rejected, in 4-byte mode with ErrYear2038.
+func TimeToUnixBytes(t time.Time, width int) ([]byte, error) {
+	if err := validateUnixWidth(width); err != nil {
+		return nil, err
+	}
+
+	out, err := IntXXToBytesAndExpandWidth(t.Unix(), 8*width, width)
+	if err != nil {
+		if width == 4 {
+			return nil, fmt.Errorf("%w: %v", ErrYear2038, t)
+		}
+		return nil, fmt.Errorf("time %v does not fit %d-byte Unix timestamp", t, width)
+	}
+	return out, nil
+}
+
+// TimeFromUnixBytes
+//
+//	Decodes Unix time in seconds written by TimeToUnixBytes, width is len(b). Returns UTC time.
+func TimeFromUnixBytes(b []byte) (time.Time, error) {
+	if err := validateUnixWidth(len(b)); err != nil {
+		return time.Time{}, err
+	}
+
+	sec, err := IntXXFromBytes(b, 8*len(b))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// TimeToUnixMilliBytes
+//
+//	Encodes t as signed big-endian Unix time in milliseconds of width 4, 5, 6 or 8 bytes,
+//	e.g. 6 bytes cover years 6429 BC..10429 AD. Sub-millisecond part is truncated.
+func TimeToUnixMilliBytes(t time.Time, width int) ([]byte, error) {
+	if err := validateUnixWidth(width); err != nil {
+		return nil, err
+	}
+
+	out, err := IntXXToBytesAndExpandWidth(t.UnixMilli(), 8*width, width)
+	if err != nil {
+		return nil, fmt.Errorf("time %v does not fit %d-byte Unix timestamp in milliseconds", t, width)
+	}
+	return out, nil
+}
+
+// TimeFromUnixMilliBytes
+//
+//	Decodes Unix time in milliseconds written by TimeToUnixMilliBytes, width is len(b). Returns UTC time.
+func TimeFromUnixMilliBytes(b []byte) (time.Time, error) {
+	if err := validateUnixWidth(len(b)); err != nil {
+		return time.Time{}, err
+	}
+
+	ms, err := IntXXFromBytes(b, 8*len(b))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ms).UTC(), nil
+}
+
+func validateUnixWidth(width int) error {
+	switch width {
+	case 4, 5, 6, 8:
+		return nil
+	}
+	return fmt.Errorf("unsupported Unix timestamp width %d, must be 4, 5, 6 or 8 bytes", width)
+}
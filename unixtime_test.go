@@ -0,0 +1,81 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeToUnixBytes(t *testing.T) {
+	y2038 := time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC)
+
+	cases := []struct {
+		t     time.Time
+		width int
+		hex   string
+	}{
+		{time.Unix(0, 0), 4, "00000000"},
+		{y2038, 4, "7fffffff"},
+		{time.Unix(-1, 0), 4, "ffffffff"},
+		{y2038.Add(time.Second), 5, "0080000000"},
+		{time.Date(2100, 1, 1, 0, 0, 0, 999, time.UTC), 6, "0000f4865700"},
+		{time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC), 8, "0000003afff4417f"},
+	}
+	for _, c := range cases {
+		b, err := TimeToUnixBytes(c.t, c.width)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b); got != c.hex {
+			t.Errorf("TimeToUnixBytes(%v, %d) = %s, expected %s", c.t, c.width, got, c.hex)
+		}
+
+		decoded, err := TimeFromUnixBytes(b)
+		if err != nil || !decoded.Equal(c.t.Truncate(time.Second)) {
+			t.Errorf("TimeFromUnixBytes(%s) = %v (%v), expected %v", c.hex, decoded, err, c.t)
+		}
+	}
+
+	if _, err := TimeToUnixBytes(y2038.Add(time.Second), 4); !errors.Is(err, ErrYear2038) {
+		t.Errorf("expected ErrYear2038, got %v", err)
+	}
+	if _, err := TimeToUnixBytes(time.Date(1901, 1, 1, 0, 0, 0, 0, time.UTC), 4); !errors.Is(err, ErrYear2038) {
+		t.Errorf("expected ErrYear2038 before 1901, got %v", err)
+	}
+	if _, err := TimeToUnixBytes(time.Date(40000, 1, 1, 0, 0, 0, 0, time.UTC), 5); err == nil {
+		t.Error("expected error for time out of 5-byte range")
+	}
+	for _, width := range []int{0, 3, 7, 9} {
+		if _, err := TimeToUnixBytes(time.Unix(0, 0), width); err == nil {
+			t.Errorf("expected error for width %d", width)
+		}
+		if _, err := TimeFromUnixBytes(make([]byte, width)); err == nil {
+			t.Errorf("expected error for width %d", width)
+		}
+	}
+}
+
+func TestTimeToUnixMilliBytes(t *testing.T) {
+	tm := time.Date(2024, 5, 1, 12, 0, 0, 123456789, time.UTC)
+
+	for _, width := range []int{6, 8} {
+		b, err := TimeToUnixMilliBytes(tm, width)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := TimeFromUnixMilliBytes(b)
+		if err != nil || !decoded.Equal(tm.Truncate(time.Millisecond)) {
+			t.Errorf("width %d: expected %v, got %v (%v)", width, tm.Truncate(time.Millisecond), decoded, err)
+		}
+	}
+
+	b, _ := TimeToUnixMilliBytes(tm, 6)
+	if got := hex.EncodeToString(b); got != "018f34069e7b" {
+		t.Errorf("expected 018f34069e7b, got %s", got)
+	}
+
+	if _, err := TimeToUnixMilliBytes(tm, 4); err == nil {
+		t.Error("expected error for time out of 4-byte millisecond range")
+	}
+}
@@ -0,0 +1,161 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// urlSchemes are well-known schemes stored as a single byte, code 0 means scheme is stored as string.
+// Codes are part of the wire format, append only.
+var urlSchemes = []string{"", "http", "https", "ws", "wss", "ftp", "sftp", "ssh", "file", "grpc", "mqtt", "amqp", "redis", "postgres"}
+
+// Flags stored in high bits of scheme byte, scheme codes use the low 6 bits.
+const (
+	urlFlagForceQuery byte = 0x80 // "?" with empty query (url.URL.ForceQuery)
+	urlFlagEmptyPort  byte = 0x40 // ":" with empty port ("http://host:/")
+	urlSchemeMask     byte = 0x3F
+)
+
+// URLToBytes
+//
+//	Encodes URL compactly for access logs, splitting it into typed fields:
+//
+//	[ flags (2 bits) + scheme code (6 bits) | scheme (uvarint len + bytes, only if code is 0) |
+//	  host (uvarint len + bytes) | port (uint16, 0 if absent) | path | query | fragment (each uvarint len + bytes) ]
+//
+//	Common schemes (http, https, ws, wss, ftp, ...) take one byte. Port is stored as written,
+//	default ports are not stripped. Path and fragment are stored escaped, and flags keep "?" with empty
+//	query (ForceQuery) and ":" with empty port, so String() of decoded URL equals u.String().
+//	Note that url.URL itself has no way to hold empty fragment: url.Parse drops trailing "#".
+//	URLs with user info or opaque part (mailto:) and explicit port 0 are not supported.
+func URLToBytes(u *url.URL) ([]byte, error) {
+	if u.User != nil {
+		return nil, fmt.Errorf("url with user info is not supported")
+	}
+	if u.Opaque != "" {
+		return nil, fmt.Errorf("opaque url %q is not supported", u.String())
+	}
+
+	var port uint64
+	if p := u.Port(); p != "" {
+		var err error
+		if port, err = strconv.ParseUint(p, 10, 16); err != nil || port == 0 {
+			return nil, fmt.Errorf("invalid port %q in url", p)
+		}
+	}
+
+	code := 0
+	for i := 1; i < len(urlSchemes); i++ {
+		if urlSchemes[i] == u.Scheme {
+			code = i
+			break
+		}
+	}
+
+	flags := byte(0)
+	if u.ForceQuery && u.RawQuery == "" {
+		flags |= urlFlagForceQuery
+	}
+	if strings.HasSuffix(u.Host, ":") {
+		flags |= urlFlagEmptyPort
+	}
+
+	out := []byte{flags | byte(code)}
+	if code == 0 {
+		out = appendUvarintString(out, u.Scheme)
+	}
+	out = appendUvarintString(out, u.Hostname())
+	out = binary.BigEndian.AppendUint16(out, uint16(port))
+	out = appendUvarintString(out, u.EscapedPath())
+	out = appendUvarintString(out, u.RawQuery)
+	out = appendUvarintString(out, u.EscapedFragment())
+
+	return out, nil
+}
+
+// URLFromBytes decodes URL written by URLToBytes, data must contain exactly one URL.
+func URLFromBytes(data []byte, opts ...DecodeOption) (*url.URL, error) {
+	limits := &newDecodeConfig(opts).limits
+
+	if len(data) < 1 {
+		return nil, fmt.Errorf("expected at least 1 byte for url scheme, but got 0 bytes")
+	}
+
+	flags := data[0] &^ urlSchemeMask
+	code := int(data[0] & urlSchemeMask)
+	if code >= len(urlSchemes) {
+		return nil, fmt.Errorf("unknown url scheme code %d", code)
+	}
+	rest := data[1:]
+
+	var scheme, host, path, query, fragment string
+	var err error
+
+	scheme = urlSchemes[code]
+	if code == 0 {
+		if scheme, rest, err = readUvarintString(rest, limits); err != nil {
+			return nil, fmt.Errorf("url scheme: %w", err)
+		}
+	}
+	if host, rest, err = readUvarintString(rest, limits); err != nil {
+		return nil, fmt.Errorf("url host: %w", err)
+	}
+	if len(rest) < 2 {
+		return nil, fmt.Errorf("url port: expected 2 bytes, but got %d bytes", len(rest))
+	}
+	port := binary.BigEndian.Uint16(rest)
+	rest = rest[2:]
+	if path, rest, err = readUvarintString(rest, limits); err != nil {
+		return nil, fmt.Errorf("url path: %w", err)
+	}
+	if query, rest, err = readUvarintString(rest, limits); err != nil {
+		return nil, fmt.Errorf("url query: %w", err)
+	}
+	if fragment, rest, err = readUvarintString(rest, limits); err != nil {
+		return nil, fmt.Errorf("url fragment: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d unexpected trailing bytes after url", len(rest))
+	}
+
+	if port != 0 && flags&urlFlagEmptyPort != 0 {
+		return nil, fmt.Errorf("url with both port %d and empty port flag", port)
+	}
+	if query != "" && flags&urlFlagForceQuery != 0 {
+		return nil, fmt.Errorf("url with both query and empty query flag")
+	}
+
+	u := &url.URL{Scheme: scheme, RawQuery: query, ForceQuery: flags&urlFlagForceQuery != 0}
+
+	switch {
+	case port != 0:
+		u.Host = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	case strings.Contains(host, ":"):
+		u.Host = "[" + host + "]"
+	default:
+		u.Host = host
+	}
+	if flags&urlFlagEmptyPort != 0 {
+		u.Host += ":"
+	}
+
+	if u.Path, err = url.PathUnescape(path); err != nil {
+		return nil, fmt.Errorf("url path: %w", err)
+	}
+	if u.EscapedPath() != path {
+		u.RawPath = path
+	}
+
+	if u.Fragment, err = url.PathUnescape(fragment); err != nil {
+		return nil, fmt.Errorf("url fragment: %w", err)
+	}
+	if u.EscapedFragment() != fragment {
+		u.RawFragment = fragment
+	}
+
+	return u, nil
+}
@@ -0,0 +1,96 @@
+package bytecast
+
+import "fmt"
+
+// Versioned encodes records with leading version byte, so new record layouts can be rolled out
+// while old data is still readable:
+//
+//	[ version (1 byte) | Marshal(record) ]
+//
+// Records are always written with the current version. On decode, current version is decoded
+// directly into T, older versions are handled by registered decoders:
+//
+//	codec := NewVersioned[OrderV2](2)
+//	_ = RegisterUpgrade(codec, 1, func(old OrderV1) (OrderV2, error) { return OrderV2{ID: old.ID}, nil })
+//	order, err := codec.Decode(data) // accepts both v1 and v2 records
+type Versioned[T any] struct {
+	current  byte
+	decoders map[byte]func([]byte) (T, error)
+}
+
+func NewVersioned[T any](current byte) *Versioned[T] {
+	return &Versioned[T]{current: current, decoders: make(map[byte]func([]byte) (T, error))}
+}
+
+// Version returns version byte written by Encode.
+func (c *Versioned[T]) Version() byte {
+	return c.current
+}
+
+// RegisterDecoder registers decoder of payload (data after version byte) of older version.
+func (c *Versioned[T]) RegisterDecoder(version byte, decode func(payload []byte) (T, error)) error {
+	if version == c.current {
+		return fmt.Errorf("version %d is current, it is decoded into %T directly", version, *new(T))
+	}
+	if _, ok := c.decoders[version]; ok {
+		return fmt.Errorf("decoder for version %d is already registered", version)
+	}
+
+	c.decoders[version] = decode
+	return nil
+}
+
+// RegisterUpgrade registers older version whose payload is Old record (decoded with Unmarshal)
+// converted to current T with upgrade function.
+func RegisterUpgrade[Old, T any](c *Versioned[T], version byte, upgrade func(Old) (T, error)) error {
+	return c.RegisterDecoder(version, func(payload []byte) (T, error) {
+		var old Old
+		if err := Unmarshal(payload, &old); err != nil {
+			var zero T
+			return zero, err
+		}
+		return upgrade(old)
+	})
+}
+
+// Encode writes current version byte followed by encoded v.
+func (c *Versioned[T]) Encode(v T) ([]byte, error) {
+	return c.Append(nil, v)
+}
+
+// Append appends encoding of v (see Encode) to dst. On error dst is returned unchanged.
+func (c *Versioned[T]) Append(dst []byte, v T) ([]byte, error) {
+	out, err := appendValue(append(dst, c.current), v)
+	if err != nil {
+		return dst, err
+	}
+	return out, nil
+}
+
+// Decode reads version byte and decodes the rest of data with decoder of that version.
+func (c *Versioned[T]) Decode(data []byte) (T, error) {
+	var v T
+
+	if len(data) == 0 {
+		return v, fmt.Errorf("expected at least 1 byte for record version, but got 0 bytes")
+	}
+
+	version, payload := data[0], data[1:]
+
+	if version == c.current {
+		err := Unmarshal(payload, &v)
+		return v, err
+	}
+
+	decode, ok := c.decoders[version]
+	if !ok {
+		return v, fmt.Errorf("unsupported record version %d", version)
+	}
+
+	v, err := decode(payload)
+	if err != nil {
+		return v, fmt.Errorf("record version %d: %w", version, err)
+	}
+
+	return v, nil
+}
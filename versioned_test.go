@@ -0,0 +1,75 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+type versionedTestV1 struct {
+	ID uint32
+}
+
+type versionedTestV2 struct {
+	ID       uint32
+	Priority uint8
+}
+
+func TestVersioned(t *testing.T) {
+	codec := NewVersioned[versionedTestV2](2)
+	err := RegisterUpgrade(codec, 1, func(old versionedTestV1) (versionedTestV2, error) {
+		return versionedTestV2{ID: old.ID, Priority: 5}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := codec.Encode(versionedTestV2{ID: 1, Priority: 9})
+	if err != nil || hex.EncodeToString(data) != "02"+"00000001"+"09" {
+		t.Fatalf("unexpected encoding %x (%v)", data, err)
+	}
+
+	v, err := codec.Decode(data)
+	if err != nil || v != (versionedTestV2{ID: 1, Priority: 9}) {
+		t.Fatalf("unexpected current record %+v (%v)", v, err)
+	}
+
+	legacy, _ := hex.DecodeString("01" + "0000002a")
+	v, err = codec.Decode(legacy)
+	if err != nil || v != (versionedTestV2{ID: 42, Priority: 5}) {
+		t.Fatalf("unexpected upgraded record %+v (%v)", v, err)
+	}
+}
+
+func TestVersionedErrors(t *testing.T) {
+	codec := NewVersioned[versionedTestV2](2)
+
+	if err := codec.RegisterDecoder(2, nil); err == nil {
+		t.Fatal("expected error registering current version")
+	}
+
+	decode := func([]byte) (versionedTestV2, error) { return versionedTestV2{}, nil }
+	if err := codec.RegisterDecoder(1, decode); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.RegisterDecoder(1, decode); err == nil {
+		t.Fatal("expected error for duplicated version")
+	}
+
+	for _, bad := range []string{"", "07", "02000001"} {
+		data, _ := hex.DecodeString(bad)
+		if _, err := codec.Decode(data); err == nil {
+			t.Errorf("Decode(%s): expected error", bad)
+		}
+	}
+}
+
+func TestVersionedAppendError(t *testing.T) {
+	codec := NewVersioned[string](1)
+
+	dst := []byte{0xAA}
+	out, err := codec.Append(dst, strings.Repeat("x", 300))
+	if err == nil || len(out) != len(dst) {
+		t.Fatalf("expected error and unchanged dst, got %x (%v)", out, err)
+	}
+}
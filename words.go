@@ -0,0 +1,120 @@
+package bytecast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// PackWords32 packs heterogeneous field list into flat sequence of 32-byte words
+// (e.g. to build Merkle tree leaves). Every field starts at word boundary, padding rule:
+//
+//	int8..int64, *big.Int        → one word, sign-extended to the left (two's complement)
+//	uint8..uint64, bool, Address → one word, zero-padded to the left
+//	Bytes32                      → one word, as is
+//	Bytes8/16/20, Bytes64        → zero-padded to the RIGHT to full words (like Solidity bytesN)
+//	[]byte, string               → length word (uint64, left-padded), then data zero-padded
+//	                               to the RIGHT to full words; empty data takes only the length word
+//
+// Numbers are right-aligned and byte strings left-aligned, so the same value always lands
+// at the same place of its word regardless of the declared Go type width.
+func PackWords32(fields ...any) ([]byte, error) {
+	return AppendWords32(make([]byte, 0, 32*len(fields)), fields...)
+}
+
+// AppendWords32 appends encoding of fields (see PackWords32) to dst. On error dst is returned unchanged.
+func AppendWords32(dst []byte, fields ...any) ([]byte, error) {
+	out := dst
+
+	for i, field := range fields {
+		var err error
+		out, err = appendWords32(out, field)
+		if err != nil {
+			return dst, fmt.Errorf("field %d: %w", i, err)
+		}
+	}
+
+	return out, nil
+}
+
+func appendWords32(dst []byte, field any) ([]byte, error) {
+	var signed int64
+	var unsigned uint64
+	isSigned := false
+
+	switch x := field.(type) {
+	case int8:
+		signed, isSigned = int64(x), true
+	case int16:
+		signed, isSigned = int64(x), true
+	case int32:
+		signed, isSigned = int64(x), true
+	case int64:
+		signed, isSigned = x, true
+	case uint8:
+		unsigned = uint64(x)
+	case uint16:
+		unsigned = uint64(x)
+	case uint32:
+		unsigned = uint64(x)
+	case uint64:
+		unsigned = x
+	case bool:
+		if x {
+			unsigned = 1
+		}
+	case *big.Int:
+		if x == nil {
+			return dst, fmt.Errorf("nil *big.Int")
+		}
+		w, err := Bytes32FromBigInt(x)
+		return append(dst, w[:]...), err
+	case Address:
+		w := x.ToWord()
+		return append(dst, w[:]...), nil
+	case Bytes32:
+		return append(dst, x[:]...), nil
+	case Bytes8:
+		return appendRightPadded32(dst, x[:]), nil
+	case Bytes16:
+		return appendRightPadded32(dst, x[:]), nil
+	case Bytes20:
+		return appendRightPadded32(dst, x[:]), nil
+	case Bytes64:
+		return appendRightPadded32(dst, x[:]), nil
+	case []byte:
+		return appendDynamic32(dst, x), nil
+	case string:
+		return appendDynamic32(dst, []byte(x)), nil
+	default:
+		return dst, fmt.Errorf("unsupported type %T for 32-byte word packing", field)
+	}
+
+	var word [32]byte
+	if isSigned {
+		unsigned = uint64(signed)
+		if signed < 0 {
+			copy(word[:24], LeftPadBytesFF(nil, 24))
+		}
+	}
+	binary.BigEndian.PutUint64(word[24:], unsigned)
+
+	return append(dst, word[:]...), nil
+}
+
+func appendDynamic32(dst []byte, data []byte) []byte {
+	var lengthWord [32]byte
+	binary.BigEndian.PutUint64(lengthWord[24:], uint64(len(data)))
+	dst = append(dst, lengthWord[:]...)
+
+	return appendRightPadded32(dst, data)
+}
+
+// appendRightPadded32 appends data followed by zeros up to the next word boundary.
+func appendRightPadded32(dst []byte, data []byte) []byte {
+	dst = append(dst, data...)
+	if rem := len(data) % 32; rem != 0 {
+		dst = append(dst, make([]byte, 32-rem)...)
+	}
+	return dst
+}
@@ -0,0 +1,65 @@
+package bytecast
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestPackWords32(t *testing.T) {
+	zeros := func(n int) string { return strings.Repeat("00", n) }
+	ffs := func(n int) string { return strings.Repeat("ff", n) }
+
+	cases := []struct {
+		name     string
+		field    any
+		expected string
+	}{
+		{"int8 negative", int8(-1), ffs(32)},
+		{"int64 positive", int64(0x0102), zeros(30) + "0102"},
+		{"uint16", uint16(0xabcd), zeros(30) + "abcd"},
+		{"bool", true, zeros(31) + "01"},
+		{"big.Int negative", big.NewInt(-2), ffs(31) + "fe"},
+		{"address", Address{0: 0xaa, 19: 0xbb}, zeros(12) + "aa" + zeros(18) + "bb"},
+		{"bytes8 right padded", Bytes8{0: 0x11, 7: 0x22}, "11" + zeros(6) + "22" + zeros(24)},
+		{"empty string", "", zeros(32)},
+		{"string", "ab", zeros(31) + "02" + "6162" + zeros(30)},
+		{"32-byte slice", make([]byte, 32), zeros(31) + "20" + zeros(32)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := PackWords32(c.field)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if hex.EncodeToString(got) != c.expected {
+				t.Fatalf("expected %s\n got %x", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestPackWords32Sequence(t *testing.T) {
+	got, err := PackWords32(uint8(1), Bytes64{}, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 32*5 {
+		t.Fatalf("expected 5 words, got %d bytes", len(got))
+	}
+
+	if _, err := PackWords32(uint8(1), 1.5); err == nil || !strings.Contains(err.Error(), "field 1") {
+		t.Fatalf("expected error for field 1, got %v", err)
+	}
+
+	if _, err := PackWords32(new(big.Int).Lsh(big.NewInt(1), 256)); err == nil {
+		t.Fatal("expected error for 2^256")
+	}
+	belowMin := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	belowMin.Sub(belowMin, big.NewInt(1))
+	if _, err := PackWords32(belowMin); err == nil {
+		t.Fatal("expected error for -(2^255)-1")
+	}
+}
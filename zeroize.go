@@ -0,0 +1,82 @@
+package bytecast
+
+import (
+	"math/big"
+	"runtime"
+)
+
+// Zeroize overwrites b with zeros, e.g. to wipe key material after it was encoded or decoded.
+//
+// NOTE: Go runtime may keep copies of data elsewhere (registers, stack frames of callee functions,
+// old backing arrays after append), Zeroize only wipes the memory it is given.
+func Zeroize(b []byte) {
+	clear(b)
+	runtime.KeepAlive(b)
+}
+
+// ZeroizeBigInt overwrites internal words of x with zeros and sets it to 0.
+func ZeroizeBigInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+	clear(x.Bits())
+	x.SetInt64(0)
+}
+
+// IntXXFromBytesAndZeroize works as IntXXFromBytes, but wipes input bytes afterwards (also on error).
+func IntXXFromBytesAndZeroize(bytes []byte, xx int) (int64, error) {
+	defer Zeroize(bytes)
+	return IntXXFromBytes(bytes, xx)
+}
+
+// UintXXFromBytesAndZeroize works as UintXXFromBytes, but wipes input bytes afterwards (also on error).
+func UintXXFromBytesAndZeroize(bytes []byte, xx int) (uint64, error) {
+	defer Zeroize(bytes)
+	return UintXXFromBytes(bytes, xx)
+}
+
+// BigIntXXXFromBytesAndZeroize works as BigIntXXXFromBytes, but wipes input bytes afterwards (also on error).
+// Returned value holds the secret as well, wipe it with ZeroizeBigInt when done.
+func BigIntXXXFromBytesAndZeroize(bytes []byte, xxx int) (*big.Int, error) {
+	defer Zeroize(bytes)
+	return BigIntXXXFromBytes(bytes, xxx)
+}
+
+// SecureBytes holds sensitive bytes (e.g. key material) and wipes them on Destroy.
+//
+// Finalizer wipes the buffer as a last resort if Destroy was not called, but it runs only when (and if)
+// garbage collector decides so. Always call Destroy explicitly, usually with defer.
+type SecureBytes struct {
+	b []byte
+}
+
+// NewSecureBytes allocates zeroed buffer of n bytes.
+func NewSecureBytes(n int) *SecureBytes {
+	s := &SecureBytes{b: make([]byte, n)}
+	runtime.SetFinalizer(s, (*SecureBytes).Destroy)
+	return s
+}
+
+// NewSecureBytesFrom copies src into new SecureBytes and wipes src.
+func NewSecureBytesFrom(src []byte) *SecureBytes {
+	s := NewSecureBytes(len(src))
+	copy(s.b, src)
+	Zeroize(src)
+	return s
+}
+
+// Bytes returns underlying buffer (not a copy!), nil after Destroy.
+func (s *SecureBytes) Bytes() []byte {
+	return s.b
+}
+
+func (s *SecureBytes) Len() int {
+	return len(s.b)
+}
+
+// Destroy wipes and releases underlying buffer. It is safe to call Destroy several times.
+func (s *SecureBytes) Destroy() {
+	Zeroize(s.b)
+	s.b = nil
+	runtime.SetFinalizer(s, nil)
+}
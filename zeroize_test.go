@@ -0,0 +1,98 @@
+package bytecast
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestZeroize(t *testing.T) {
+	b := []byte{1, 2, 3}
+	Zeroize(b)
+	if !bytes.Equal(b, []byte{0, 0, 0}) {
+		t.Fatalf("expected zeros, got %x", b)
+	}
+
+	Zeroize(nil) // must not panic
+}
+
+func TestZeroizeBigInt(t *testing.T) {
+	x := new(big.Int).Lsh(big.NewInt(1), 200)
+	words := x.Bits()
+
+	ZeroizeBigInt(x)
+
+	if x.Sign() != 0 {
+		t.Fatalf("expected 0, got %s", x)
+	}
+	for _, w := range words[:cap(words)] {
+		if w != 0 {
+			t.Fatal("expected internal words to be wiped")
+		}
+	}
+
+	ZeroizeBigInt(nil) // must not panic
+}
+
+func TestFromBytesAndZeroize(t *testing.T) {
+	in, _ := IntXXToBytesAndExpandWidth(-193630, 24, 32)
+	v, err := IntXXFromBytesAndZeroize(in, 24)
+	if err != nil || v != -193630 {
+		t.Fatalf("expected -193630, got %d (%v)", v, err)
+	}
+	if !bytes.Equal(in, make([]byte, 32)) {
+		t.Fatal("expected input to be wiped")
+	}
+
+	in, _ = UintXXToBytesAndExpandWidth(16777215, 24, 32)
+	u, err := UintXXFromBytesAndZeroize(in, 24)
+	if err != nil || u != 16777215 {
+		t.Fatalf("expected 16777215, got %d (%v)", u, err)
+	}
+	if !bytes.Equal(in, make([]byte, 32)) {
+		t.Fatal("expected input to be wiped")
+	}
+
+	in, _ = BigIntToBytesAndExpandWidth(big.NewInt(-5), 16)
+	x, err := BigIntXXXFromBytesAndZeroize(in, 128)
+	if err != nil || x.Int64() != -5 {
+		t.Fatalf("expected -5, got %s (%v)", x, err)
+	}
+	if !bytes.Equal(in, make([]byte, 16)) {
+		t.Fatal("expected input to be wiped")
+	}
+
+	// input is wiped on error too
+	in = []byte{0xff}
+	if _, err := IntXXFromBytesAndZeroize(in, 24); err == nil {
+		t.Fatal("expected error")
+	}
+	if in[0] != 0 {
+		t.Fatal("expected input to be wiped on error")
+	}
+}
+
+func TestSecureBytes(t *testing.T) {
+	src := []byte("secret key")
+	s := NewSecureBytesFrom(src)
+
+	if !bytes.Equal(src, make([]byte, len(src))) {
+		t.Fatal("expected source to be wiped")
+	}
+
+	if string(s.Bytes()) != "secret key" || s.Len() != 10 {
+		t.Fatalf("unexpected content %q", s.Bytes())
+	}
+
+	buf := s.Bytes()
+	s.Destroy()
+
+	if !bytes.Equal(buf, make([]byte, 10)) {
+		t.Fatal("expected buffer to be wiped on Destroy")
+	}
+	if s.Bytes() != nil || s.Len() != 0 {
+		t.Fatal("expected no buffer after Destroy")
+	}
+
+	s.Destroy() // second call is a no-op
+}